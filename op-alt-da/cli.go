@@ -0,0 +1,60 @@
+package altda
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	EnabledFlagName  = "altda.enabled"
+	DAServerFlagName = "altda.da-server"
+)
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    EnabledFlagName,
+			Usage:   "Enable alt-DA mode: batcher data is stored on a DA server, and only its commitment is posted on L1",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "ALTDA_ENABLED"),
+		},
+		&cli.StringFlag{
+			Name:    DAServerFlagName,
+			Usage:   "HTTP address of the DA server to store and retrieve the input data of alt-DA commitments",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "ALTDA_DA_SERVER"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled     bool
+	DAServerURL string
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.DAServerURL == "" {
+		return errors.New("alt-DA is enabled, but no DA server url is configured")
+	}
+	if _, err := url.Parse(c.DAServerURL); err != nil {
+		return errors.New("invalid DA server url")
+	}
+	return nil
+}
+
+// NewDAClient returns a DA client for the configured DA server.
+func (c CLIConfig) NewDAClient() *DAClient {
+	return NewDAClient(c.DAServerURL)
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:     ctx.Bool(EnabledFlagName),
+		DAServerURL: ctx.String(DAServerFlagName),
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const envVarPrefix = "OP_DA_SERVER"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(envVarPrefix, name)
+}
+
+var (
+	ListenAddrFlag = &cli.StringFlag{
+		Name:    "addr",
+		Usage:   "DA server listening address",
+		Value:   "127.0.0.1",
+		EnvVars: prefixEnvVars("ADDR"),
+	}
+	PortFlag = &cli.IntFlag{
+		Name:    "port",
+		Usage:   "DA server listening port",
+		Value:   3100,
+		EnvVars: prefixEnvVars("PORT"),
+	}
+	FileStorePathFlag = &cli.StringFlag{
+		Name:    "file.path",
+		Usage:   "Directory in which the input data of commitments is stored, one file per commitment",
+		EnvVars: prefixEnvVars("FILE_PATH"),
+	}
+)
+
+var Flags = []cli.Flag{
+	ListenAddrFlag,
+	PortFlag,
+	FileStorePathFlag,
+}
+
+func init() {
+	Flags = append(Flags, oplog.CLIFlags(envVarPrefix)...)
+}
+
+type CLIConfig struct {
+	ListenAddr    string
+	ListenPort    int
+	FileStorePath string
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		ListenAddr:    ctx.String(ListenAddrFlag.Name),
+		ListenPort:    ctx.Int(PortFlag.Name),
+		FileStorePath: ctx.String(FileStorePathFlag.Name),
+	}
+}
+
+func (c CLIConfig) Check() error {
+	if c.FileStorePath == "" {
+		return errors.New("no file store path configured")
+	}
+	if c.ListenPort < 0 || c.ListenPort > 65535 {
+		return fmt.Errorf("invalid port %d", c.ListenPort)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+var (
+	Version   = ""
+	GitCommit = ""
+	GitDate   = ""
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Flags = Flags
+	app.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
+	app.Name = "da-server"
+	app.Usage = "Alt-DA reference server"
+	app.Description = "Stores and serves the input data of alt-DA commitments, backed by files on disk"
+	app.Action = StartDAServer
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+func StartDAServer(cliCtx *cli.Context) error {
+	cfg := ReadCLIConfig(cliCtx)
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+	logger := oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx))
+	oplog.SetGlobalLogHandler(logger.GetHandler())
+
+	store, err := altda.NewFileStore(cfg.FileStorePath)
+	if err != nil {
+		return err
+	}
+	server := altda.NewDAServer(cfg.ListenAddr, cfg.ListenPort, store, logger)
+	if err := server.Start(); err != nil {
+		return err
+	}
+	logger.Info("Using file store", "path", cfg.FileStorePath)
+
+	opio.BlockOnInterrupts()
+
+	if err := server.Stop(context.Background()); err != nil {
+		return fmt.Errorf("failed to stop DA server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package altda
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TxDataVersion1 is the version byte of batcher tx data that carries an alt-DA commitment
+// instead of frames. Frame data always starts with derive.DerivationVersion0.
+const TxDataVersion1 byte = 1
+
+// Keccak256CommitmentType is the commitment type byte of keccak256 commitments.
+const Keccak256CommitmentType byte = 0
+
+var (
+	ErrInvalidCommitment  = errors.New("invalid commitment")
+	ErrCommitmentMismatch = errors.New("commitment does not match input")
+)
+
+// Keccak256Commitment is the keccak256 hash of the input data stored on the DA server.
+type Keccak256Commitment common.Hash
+
+// Keccak256 computes the commitment to the given input data.
+func Keccak256(input []byte) Keccak256Commitment {
+	return Keccak256Commitment(crypto.Keccak256Hash(input))
+}
+
+// Encode returns the commitment type byte followed by the hash, as used by the DA server.
+func (c Keccak256Commitment) Encode() []byte {
+	return append([]byte{Keccak256CommitmentType}, c[:]...)
+}
+
+// TxData returns the batcher tx data that is posted on L1 for the commitment.
+func (c Keccak256Commitment) TxData() []byte {
+	return append([]byte{TxDataVersion1}, c.Encode()...)
+}
+
+// Verify checks that the commitment matches the given input data.
+func (c Keccak256Commitment) Verify(input []byte) error {
+	if Keccak256(input) != c {
+		return ErrCommitmentMismatch
+	}
+	return nil
+}
+
+func (c Keccak256Commitment) String() string {
+	return hexutil.Encode(c.Encode())
+}
+
+// DecodeKeccak256 decodes an encoded commitment, i.e. the commitment type byte followed by the hash.
+func DecodeKeccak256(commitment []byte) (Keccak256Commitment, error) {
+	if len(commitment) != 1+common.HashLength {
+		return Keccak256Commitment{}, fmt.Errorf("%w: length %d", ErrInvalidCommitment, len(commitment))
+	}
+	if commitment[0] != Keccak256CommitmentType {
+		return Keccak256Commitment{}, fmt.Errorf("%w: unknown commitment type %d", ErrInvalidCommitment, commitment[0])
+	}
+	return Keccak256Commitment(common.BytesToHash(commitment[1:])), nil
+}
+
+// DecodeTxData decodes the commitment of batcher tx data that starts with TxDataVersion1.
+func DecodeTxData(data []byte) (Keccak256Commitment, error) {
+	if len(data) == 0 || data[0] != TxDataVersion1 {
+		return Keccak256Commitment{}, fmt.Errorf("%w: not an alt-DA tx data version", ErrInvalidCommitment)
+	}
+	return DecodeKeccak256(data[1:])
+}
@@ -0,0 +1,78 @@
+package altda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound is returned when the DA server doesn't have the input of a commitment.
+var ErrNotFound = errors.New("not found")
+
+// DAClient stores and retrieves the inputs of commitments on a DA server with a simple HTTP protocol:
+//   - PUT <url>/put/<hex encoded commitment> stores the request body as input of the commitment.
+//   - GET <url>/get/<hex encoded commitment> returns the input of the commitment, or 404 if unknown.
+type DAClient struct {
+	url    string
+	client *http.Client
+}
+
+func NewDAClient(url string) *DAClient {
+	return &DAClient{
+		url:    strings.TrimSuffix(url, "/"),
+		client: http.DefaultClient,
+	}
+}
+
+// GetInput returns the input data of the given commitment, which is verified against the commitment.
+func (c *DAClient) GetInput(ctx context.Context, comm Keccak256Commitment) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/get/%s", c.url, comm), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("input of commitment %s: %w", comm, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get input of commitment %s: status %d", comm, resp.StatusCode)
+	}
+	input, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input of commitment %s: %w", comm, err)
+	}
+	if err := comm.Verify(input); err != nil {
+		return nil, fmt.Errorf("invalid input of commitment %s: %w", comm, err)
+	}
+	return input, nil
+}
+
+// SetInput stores the input data on the DA server and returns its commitment.
+func (c *DAClient) SetInput(ctx context.Context, input []byte) (Keccak256Commitment, error) {
+	if len(input) == 0 {
+		return Keccak256Commitment{}, errors.New("empty input")
+	}
+	comm := Keccak256(input)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/put/%s", c.url, comm), bytes.NewReader(input))
+	if err != nil {
+		return Keccak256Commitment{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Keccak256Commitment{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Keccak256Commitment{}, fmt.Errorf("failed to store input of commitment %s: status %d", comm, resp.StatusCode)
+	}
+	return comm, nil
+}
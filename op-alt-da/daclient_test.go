@@ -0,0 +1,91 @@
+package altda
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func newTestDAServer(t *testing.T) *DAServer {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	server := NewDAServer("127.0.0.1", 0, store, testlog.Logger(t, log.LvlDebug))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(context.Background()))
+	})
+	return server
+}
+
+func TestDAClientRoundtrip(t *testing.T) {
+	ctx := context.Background()
+	server := newTestDAServer(t)
+	client := NewDAClient(server.Endpoint())
+
+	input := []byte("frame data")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, Keccak256(input), comm)
+
+	got, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+
+	// storing the same input again is fine
+	_, err = client.SetInput(ctx, input)
+	require.NoError(t, err)
+
+	_, err = client.GetInput(ctx, Keccak256([]byte("unknown")))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.SetInput(ctx, nil)
+	require.ErrorContains(t, err, "empty input")
+}
+
+func TestDAServerRejectsInvalidRequests(t *testing.T) {
+	server := newTestDAServer(t)
+	put := func(path string, body []byte) int {
+		req, err := http.NewRequest(http.MethodPut, server.Endpoint()+path, bytes.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	input := []byte("frame data")
+	comm := Keccak256(input)
+	require.Equal(t, http.StatusBadRequest, put("/put/"+comm.String(), []byte("other data")))
+	require.Equal(t, http.StatusBadRequest, put("/put/0x1234", input))
+	require.Equal(t, http.StatusBadRequest, put("/put/"+Keccak256Commitment{}.String()[:10], input))
+	require.Equal(t, http.StatusOK, put("/put/"+comm.String(), input))
+
+	resp, err := http.Get(server.Endpoint() + "/get/not-hex")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDecodeTxData(t *testing.T) {
+	comm := Keccak256([]byte("frame data"))
+	txData := comm.TxData()
+	require.Equal(t, TxDataVersion1, txData[0])
+	require.Equal(t, Keccak256CommitmentType, txData[1])
+
+	got, err := DecodeTxData(txData)
+	require.NoError(t, err)
+	require.Equal(t, comm, got)
+
+	_, err = DecodeTxData(txData[:len(txData)-1])
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+	_, err = DecodeTxData(append([]byte{0}, txData[1:]...))
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+	_, err = DecodeTxData(append([]byte{TxDataVersion1, 1}, txData[2:]...))
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+}
@@ -0,0 +1,133 @@
+package altda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+)
+
+// MaxInputSize is the max size of the input data the DA server accepts for a single commitment.
+const MaxInputSize = 16 * 1024 * 1024
+
+// KVStore is the storage backend of the DA server. Get returns ErrNotFound for unknown keys.
+type KVStore interface {
+	Get(ctx context.Context, key []byte) ([]byte, error)
+	Put(ctx context.Context, key []byte, value []byte) error
+}
+
+// DAServer is a reference DA server, which serves the protocol of the DAClient from a KVStore.
+// The input data of each commitment is verified before it is stored.
+type DAServer struct {
+	log        log.Logger
+	endpoint   string
+	store      KVStore
+	httpServer *httputil.HTTPServer
+}
+
+func NewDAServer(host string, port int, store KVStore, log log.Logger) *DAServer {
+	return &DAServer{
+		log:      log,
+		endpoint: net.JoinHostPort(host, strconv.Itoa(port)),
+		store:    store,
+	}
+}
+
+func (d *DAServer) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get/", d.HandleGet)
+	mux.HandleFunc("/put/", d.HandlePut)
+
+	srv, err := httputil.StartHTTPServer(d.endpoint, mux)
+	if err != nil {
+		return fmt.Errorf("failed to start DA server: %w", err)
+	}
+	d.httpServer = srv
+	d.log.Info("Started DA server", "addr", srv.Addr())
+	return nil
+}
+
+func (d *DAServer) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	comm, err := commitmentFromPath(r.URL.Path, "/get/")
+	if err != nil {
+		d.log.Debug("Invalid commitment", "path", r.URL.Path, "err", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	input, err := d.store.Get(r.Context(), comm.Encode())
+	if errors.Is(err, ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		d.log.Error("Failed to read input", "commitment", comm, "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if _, err := w.Write(input); err != nil {
+		d.log.Debug("Failed to write response", "commitment", comm, "err", err)
+	}
+}
+
+func (d *DAServer) HandlePut(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	comm, err := commitmentFromPath(r.URL.Path, "/put/")
+	if err != nil {
+		d.log.Debug("Invalid commitment", "path", r.URL.Path, "err", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxInputSize))
+	if err != nil {
+		d.log.Debug("Failed to read input", "commitment", comm, "err", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := comm.Verify(input); err != nil {
+		d.log.Debug("Input doesn't match commitment", "commitment", comm)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := d.store.Put(r.Context(), comm.Encode(), input); err != nil {
+		d.log.Error("Failed to store input", "commitment", comm, "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	d.log.Debug("Stored input", "commitment", comm, "size", len(input))
+	w.WriteHeader(http.StatusOK)
+}
+
+func commitmentFromPath(path string, prefix string) (Keccak256Commitment, error) {
+	encoded, err := hexutil.Decode(strings.TrimPrefix(path, prefix))
+	if err != nil {
+		return Keccak256Commitment{}, fmt.Errorf("%w: %w", ErrInvalidCommitment, err)
+	}
+	return DecodeKeccak256(encoded)
+}
+
+// Endpoint returns the http endpoint of the running server.
+func (d *DAServer) Endpoint() string {
+	return "http://" + d.httpServer.Addr().String()
+}
+
+func (d *DAServer) Stop(ctx context.Context) error {
+	if d.httpServer == nil {
+		return nil
+	}
+	return d.httpServer.Stop(ctx)
+}
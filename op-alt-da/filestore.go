@@ -0,0 +1,53 @@
+package altda
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileStore is a KVStore that keeps every value in its own file, named after the hex encoded key.
+type FileStore struct {
+	directory string
+}
+
+var _ KVStore = (*FileStore)(nil)
+
+func NewFileStore(directory string) (*FileStore, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory %q: %w", directory, err)
+	}
+	return &FileStore{directory: directory}, nil
+}
+
+func (s *FileStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	data, err := os.ReadFile(s.fileName(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Put writes the value to a temporary file first, so that readers never see partial values.
+func (s *FileStore) Put(_ context.Context, key []byte, value []byte) error {
+	tmp, err := os.CreateTemp(s.directory, "put-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(value); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.fileName(key))
+}
+
+func (s *FileStore) fileName(key []byte) string {
+	return filepath.Join(s.directory, hex.EncodeToString(key))
+}
@@ -1,10 +1,13 @@
 package batcher
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -21,10 +24,13 @@ type channel struct {
 
 	// pending channel builder
 	channelBuilder *channelBuilder
-	// Set of unconfirmed txID -> frame data. For tx resubmission
-	pendingTransactions map[txID]txData
+	// Set of unconfirmed txID -> frame data of this channel. For tx resubmission
+	pendingTransactions map[string]txData
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
-	confirmedTransactions map[txID]eth.BlockID
+	confirmedTransactions map[string]eth.BlockID
+	// flushed is set if the channel got force closed, to submit its frames even
+	// while the submission policy holds back frames.
+	flushed bool
 }
 
 func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *rollup.Config) (*channel, error) {
@@ -37,26 +43,23 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *
 		metr:                  metr,
 		cfg:                   cfg,
 		channelBuilder:        cb,
-		pendingTransactions:   make(map[txID]txData),
-		confirmedTransactions: make(map[txID]eth.BlockID),
+		pendingTransactions:   make(map[string]txData),
+		confirmedTransactions: make(map[string]eth.BlockID),
 	}, nil
 }
 
-// TxFailed records a transaction as failed. It will attempt to resubmit the data
-// in the failed transaction.
+// TxFailed records a transaction as failed. It will attempt to resubmit the frames
+// of this channel in the failed transaction.
 func (s *channel) TxFailed(id txID) {
-	if data, ok := s.pendingTransactions[id]; ok {
+	if data, ok := s.pendingTransactions[id.String()]; ok {
 		s.log.Trace("marked transaction as failed", "id", id)
-		// Note: when the batcher is changed to send multiple frames per tx,
-		// this needs to be changed to iterate over all frames of the tx data
-		// and re-queue them.
-		s.channelBuilder.PushFrame(data.Frame())
-		delete(s.pendingTransactions, id)
+		for _, frame := range data.Frames() {
+			s.channelBuilder.PushFrame(frame)
+		}
+		delete(s.pendingTransactions, id.String())
 	} else {
 		s.log.Warn("unknown transaction marked as failed", "id", id)
 	}
-
-	s.metr.RecordBatchTxFailed()
 }
 
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
@@ -64,16 +67,15 @@ func (s *channel) TxFailed(id txID) {
 // resubmitted.
 // This function may reset the pending channel if the pending channel has timed out.
 func (s *channel) TxConfirmed(id txID, inclusionBlock eth.BlockID) (bool, []*types.Block) {
-	s.metr.RecordBatchTxSubmitted()
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
-	if _, ok := s.pendingTransactions[id]; !ok {
+	if _, ok := s.pendingTransactions[id.String()]; !ok {
 		s.log.Warn("unknown transaction marked as confirmed", "id", id, "block", inclusionBlock)
 		// TODO: This can occur if we clear the channel while there are still pending transactions
 		// We need to keep track of stale transactions instead
 		return false, nil
 	}
-	delete(s.pendingTransactions, id)
-	s.confirmedTransactions[id] = inclusionBlock
+	delete(s.pendingTransactions, id.String())
+	s.confirmedTransactions[id.String()] = inclusionBlock
 	s.channelBuilder.FramePublished(inclusionBlock.Number)
 
 	// If this channel timed out, put the pending blocks back into the local saved blocks
@@ -127,16 +129,21 @@ func (s *channel) ID() derive.ChannelID {
 	return s.channelBuilder.ID()
 }
 
-func (s *channel) NextTxData() txData {
-	frame := s.channelBuilder.NextFrame()
-
-	txdata := txData{frame}
-	id := txdata.ID()
+// NextFrame returns the next pending frame of the channel.
+// The frame must be registered with TxPending once it is packed into a tx.
+func (s *channel) NextFrame() frameData {
+	return s.channelBuilder.NextFrame()
+}
 
-	s.log.Trace("returning next tx data", "id", id)
-	s.pendingTransactions[id] = txdata
+// NextFrameLen returns the size of the next pending frame.
+func (s *channel) NextFrameLen() int {
+	return s.channelBuilder.PeekFrame().Len()
+}
 
-	return txdata
+// TxPending records the frames of this channel that are submitted in the tx with the given id.
+func (s *channel) TxPending(id txID, data txData) {
+	s.log.Trace("returning next tx data", "id", id, "num_frames", len(data.Frames()))
+	s.pendingTransactions[id.String()] = data
 }
 
 func (s *channel) HasFrame() bool {
@@ -155,6 +162,18 @@ func (s *channel) RegisterL1Block(l1BlockNum uint64) {
 	s.channelBuilder.RegisterL1Block(l1BlockNum)
 }
 
+func (s *channel) SetMaxChannelDuration(duration uint64) {
+	s.channelBuilder.SetMaxChannelDuration(duration)
+}
+
+func (s *channel) SetDurationExtension(blocks uint64) {
+	s.channelBuilder.SetDurationExtension(blocks)
+}
+
+func (s *channel) HardTimedOut(l1BlockNum uint64) bool {
+	return s.channelBuilder.HardTimedOut(l1BlockNum)
+}
+
 func (s *channel) AddBlock(block *types.Block) (derive.L1BlockInfo, error) {
 	return s.channelBuilder.AddBlock(block)
 }
@@ -186,3 +205,39 @@ func (s *channel) OutputFrames() error {
 func (s *channel) Close() {
 	s.channelBuilder.Close()
 }
+
+// info returns information about the channel, for inspection.
+func (s *channel) info() rpc.ChannelInfo {
+	cb := s.channelBuilder
+	info := rpc.ChannelInfo{
+		ID:           s.ID(),
+		NumBlocks:    len(cb.Blocks()),
+		InputBytes:   cb.InputBytes(),
+		OutputBytes:  cb.OutputBytes(),
+		Full:         cb.IsFull(),
+		Timeout:      cb.timeout,
+		TotalFrames:  cb.TotalFrames(),
+		QueuedFrames: cb.PendingFrames(),
+		PendingTxs:   make([]rpc.PendingTxInfo, 0, len(s.pendingTransactions)),
+		ConfirmedTxs: make(map[string]eth.BlockID, len(s.confirmedTransactions)),
+	}
+	if n := len(cb.Blocks()); n > 0 {
+		info.FirstBlock = eth.ToBlockID(cb.Blocks()[0])
+		info.LastBlock = eth.ToBlockID(cb.Blocks()[n-1])
+	}
+	if err := cb.FullErr(); err != nil {
+		info.FullReason = errors.Unwrap(err).Error()
+	}
+	for id, data := range s.pendingTransactions {
+		tx := rpc.PendingTxInfo{ID: id}
+		for _, f := range data.Frames() {
+			tx.Frames = append(tx.Frames, f.id.frameNumber)
+		}
+		info.PendingTxs = append(info.PendingTxs, tx)
+	}
+	sort.Slice(info.PendingTxs, func(i, j int) bool { return info.PendingTxs[i].ID < info.PendingTxs[j].ID })
+	for id, inclusionBlock := range s.confirmedTransactions {
+		info.ConfirmedTxs[id] = inclusionBlock
+	}
+	return info
+}
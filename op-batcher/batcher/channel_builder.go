@@ -10,7 +10,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 var (
@@ -62,6 +65,22 @@ type ChannelConfig struct {
 
 	// BatchType indicates whether the channel uses SingularBatch or SpanBatch.
 	BatchType uint
+
+	// UseBlobs indicates whether frames are submitted in blob transactions,
+	// with the tx data in a single blob. Frames must then fit into a single blob.
+	// The batcher only uses blobs once they are active, see [channelFrameConfig].
+	UseBlobs bool
+
+	// MultiFrameTxs indicates whether multiple frames, possibly of different
+	// channels, are packed into a single tx. The tx data, including the
+	// derivation version byte, then still fits into MaxFrameSize+1 bytes.
+	MultiFrameTxs bool
+}
+
+// MaxTxDataSize returns the maximum size of the data of a batcher tx,
+// including the derivation version byte.
+func (cc *ChannelConfig) MaxTxDataSize() int {
+	return int(cc.MaxFrameSize) + 1
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -87,6 +106,11 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("max frame size %d is less than the minimum 23", cc.MaxFrameSize)
 	}
 
+	// A frame must fit into a single blob, including the derivation version byte.
+	if cc.UseBlobs && cc.MaxFrameSize > eth.MaxBlobDataSize-1 {
+		return fmt.Errorf("max frame size %d exceeds the maximum blob frame size %d", cc.MaxFrameSize, eth.MaxBlobDataSize-1)
+	}
+
 	if cc.BatchType > derive.SpanBatchType {
 		return fmt.Errorf("unrecognized batch type: %d", cc.BatchType)
 	}
@@ -104,6 +128,11 @@ type frameData struct {
 	id   frameID
 }
 
+// Len returns the encoded size of the frame.
+func (f frameData) Len() int {
+	return len(f.data)
+}
+
 // channelBuilder uses a ChannelOut to create a channel with output frame
 // size approximation.
 type channelBuilder struct {
@@ -117,6 +146,16 @@ type channelBuilder struct {
 	timeout uint64
 	// reason for currently set timeout
 	timeoutReason error
+	// L1 block number timeout of combined
+	// - consensus channel timeout,
+	// - sequencing window timeout.
+	// These hard timeouts can't be extended, unlike the channel duration timeout.
+	// 0 if no hard timeout set yet.
+	hardTimeout uint64
+	// reason for currently set hard timeout
+	hardTimeoutReason error
+	// number of L1 blocks by which the channel duration timeout is extended
+	durationExtension uint64
 
 	// Reason for the channel being full. Set by setFullErr so it's always
 	// guaranteed to be a ChannelFullError wrapping the specific reason.
@@ -127,6 +166,8 @@ type channelBuilder struct {
 	blocks []*types.Block
 	// frames data queue, to be send as txs
 	frames []frameData
+	// hashes of all frames created yet, by frame number. Used to verify rebuilt channels
+	frameHashes []common.Hash
 	// total frames counter
 	numFrames int
 	// total amount of output data of all frames created yet
@@ -140,11 +181,26 @@ func newChannelBuilder(cfg ChannelConfig, rcfg *rollup.Config) (*channelBuilder,
 	if err != nil {
 		return nil, err
 	}
-	var spanBatchBuilder *derive.SpanBatchBuilder
-	if cfg.BatchType == derive.SpanBatchType {
-		spanBatchBuilder = derive.NewSpanBatchBuilder(rcfg.Genesis.L2Time, rcfg.L2ChainID)
+	co, err := derive.NewChannelOut(cfg.BatchType, c, newSpanBatchBuilder(cfg, rcfg))
+	if err != nil {
+		return nil, err
 	}
-	co, err := derive.NewChannelOut(cfg.BatchType, c, spanBatchBuilder)
+
+	return &channelBuilder{
+		cfg: cfg,
+		co:  co,
+	}, nil
+}
+
+// rebuildChannelBuilder creates a channel builder for the channel with the given
+// id. Adding the same blocks as to the original channel builder creates the same
+// frames, as long as the channel config didn't change.
+func rebuildChannelBuilder(cfg ChannelConfig, rcfg *rollup.Config, id derive.ChannelID) (*channelBuilder, error) {
+	c, err := cfg.CompressorConfig.NewCompressor()
+	if err != nil {
+		return nil, err
+	}
+	co, err := derive.NewChannelOutWithID(cfg.BatchType, c, newSpanBatchBuilder(cfg, rcfg), id)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +211,14 @@ func newChannelBuilder(cfg ChannelConfig, rcfg *rollup.Config) (*channelBuilder,
 	}, nil
 }
 
+// newSpanBatchBuilder returns a span batch builder if the channel uses span batches.
+func newSpanBatchBuilder(cfg ChannelConfig, rcfg *rollup.Config) *derive.SpanBatchBuilder {
+	if cfg.BatchType != derive.SpanBatchType {
+		return nil
+	}
+	return derive.NewSpanBatchBuilder(rcfg.Genesis.L2Time, rcfg.L2ChainID)
+}
+
 func (c *channelBuilder) ID() derive.ChannelID {
 	return c.co.ID()
 }
@@ -185,7 +249,10 @@ func (c *channelBuilder) Blocks() []*types.Block {
 func (c *channelBuilder) Reset() error {
 	c.blocks = c.blocks[:0]
 	c.frames = c.frames[:0]
+	c.frameHashes = c.frameHashes[:0]
 	c.timeout = 0
+	c.hardTimeout = 0
+	c.durationExtension = 0
 	c.fullErr = nil
 	return c.co.Reset()
 }
@@ -244,7 +311,7 @@ func (c *channelBuilder) RegisterL1Block(l1BlockNum uint64) {
 // in.
 func (c *channelBuilder) FramePublished(l1BlockNum uint64) {
 	timeout := l1BlockNum + c.cfg.ChannelTimeout - c.cfg.SubSafetyMargin
-	c.updateTimeout(timeout, ErrChannelTimeoutClose)
+	c.updateHardTimeout(timeout, ErrChannelTimeoutClose)
 }
 
 // updateDurationTimeout updates the block timeout with the channel duration
@@ -266,7 +333,7 @@ func (c *channelBuilder) updateDurationTimeout(l1BlockNum uint64) {
 // timeout.
 func (c *channelBuilder) updateSwTimeout(batch *derive.SingularBatch) {
 	timeout := uint64(batch.EpochNum) + c.cfg.SeqWindowSize - c.cfg.SubSafetyMargin
-	c.updateTimeout(timeout, ErrSeqWindowClose)
+	c.updateHardTimeout(timeout, ErrSeqWindowClose)
 }
 
 // updateTimeout updates the timeout block to the given block number if it is
@@ -281,20 +348,61 @@ func (c *channelBuilder) updateTimeout(timeoutBlockNum uint64, reason error) {
 	}
 }
 
+// updateHardTimeout updates the hard timeout block, like updateTimeout does for
+// the combined timeout block, which it updates as well.
+func (c *channelBuilder) updateHardTimeout(timeoutBlockNum uint64, reason error) {
+	if c.hardTimeout == 0 || c.hardTimeout > timeoutBlockNum {
+		c.hardTimeout = timeoutBlockNum
+		c.hardTimeoutReason = reason
+	}
+	c.updateTimeout(timeoutBlockNum, reason)
+}
+
+// SetMaxChannelDuration sets the max channel duration of the channel. A lower
+// duration only takes effect for L1 blocks registered afterwards, and an already
+// set channel duration timeout isn't extended by a higher duration.
+func (c *channelBuilder) SetMaxChannelDuration(duration uint64) {
+	c.cfg.MaxChannelDuration = duration
+}
+
+// SetDurationExtension sets the number of L1 blocks by which the max channel
+// duration timeout is extended. The hard timeouts are never extended.
+func (c *channelBuilder) SetDurationExtension(blocks uint64) {
+	c.durationExtension = blocks
+}
+
 // checkTimeout checks if the channel is timed out at the given block number and
 // in this case marks the channel as full, if it wasn't full already.
 func (c *channelBuilder) checkTimeout(blockNum uint64) {
-	if !c.IsFull() && c.TimedOut(blockNum) {
+	if c.IsFull() {
+		return
+	}
+	if c.HardTimedOut(blockNum) {
+		c.setFullErr(c.hardTimeoutReason)
+	} else if c.TimedOut(blockNum) && !c.durationExtended(blockNum) {
 		c.setFullErr(c.timeoutReason)
 	}
 }
 
+// durationExtended returns whether the channel duration timeout is reached at
+// the passed block number, but extended beyond it.
+func (c *channelBuilder) durationExtended(blockNum uint64) bool {
+	return errors.Is(c.timeoutReason, ErrMaxDurationReached) && blockNum < c.timeout+c.durationExtension
+}
+
 // TimedOut returns whether the passed block number is after the timeout block
 // number. If no block timeout is set yet, it returns false.
 func (c *channelBuilder) TimedOut(blockNum uint64) bool {
 	return c.timeout != 0 && blockNum >= c.timeout
 }
 
+// HardTimedOut returns whether the passed block number is after the hard
+// timeout block number, so that the channel must be submitted. If no hard
+// timeout is set yet, it returns false.
+func (c *channelBuilder) HardTimedOut(blockNum uint64) bool {
+	return c.hardTimeout != 0 && blockNum >= c.hardTimeout
+}
+
 // IsFull returns whether the channel is full.
 // FullErr returns the reason for the channel being full.
 func (c *channelBuilder) IsFull() bool {
@@ -396,6 +504,7 @@ func (c *channelBuilder) outputFrame() error {
 		data: buf.Bytes(),
 	}
 	c.frames = append(c.frames, frame)
+	c.frameHashes = append(c.frameHashes, crypto.Keccak256Hash(frame.data))
 	c.numFrames++
 	c.outputBytes += len(frame.data)
 	return err // possibly io.EOF (last frame)
@@ -443,6 +552,22 @@ func (c *channelBuilder) NextFrame() frameData {
 	return f
 }
 
+// PeekFrame returns the next available frame, without removing it from the queue.
+// HasFrame must be called prior to check if there's a next frame available.
+// Panics if called when there's no next frame.
+func (c *channelBuilder) PeekFrame() frameData {
+	if len(c.frames) == 0 {
+		panic("no next frame")
+	}
+	return c.frames[0]
+}
+
+// FrameHashes returns the hashes of all frames that were created in this channel
+// so far, by frame number.
+func (c *channelBuilder) FrameHashes() []common.Hash {
+	return c.frameHashes
+}
+
 // PushFrame adds the frame back to the internal frames queue. Panics if not of
 // the same channel.
 func (c *channelBuilder) PushFrame(frame frameData) {
@@ -53,6 +53,11 @@ func TestChannelConfig_Check(t *testing.T) {
 	timeoutChannelConfig := defaultTestChannelConfig
 	timeoutChannelConfig.ChannelTimeout = 0
 	timeoutChannelConfig.SubSafetyMargin = 1
+	blobChannelConfig := defaultTestChannelConfig
+	blobChannelConfig.UseBlobs = true
+	blobChannelConfig.MaxFrameSize = eth.MaxBlobDataSize - 1
+	largeBlobChannelConfig := blobChannelConfig
+	largeBlobChannelConfig.MaxFrameSize = eth.MaxBlobDataSize
 	tests := []test{
 		{
 			input: defaultTestChannelConfig,
@@ -72,6 +77,18 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.EqualError(t, output, "max frame size cannot be zero")
 			},
 		},
+		{
+			input: blobChannelConfig,
+			assertion: func(output error) {
+				require.NoError(t, output)
+			},
+		},
+		{
+			input: largeBlobChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, fmt.Sprintf("max frame size %d exceeds the maximum blob frame size %d", eth.MaxBlobDataSize, eth.MaxBlobDataSize-1))
+			},
+		},
 	}
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
@@ -139,10 +156,16 @@ func newMiniL2Block(numTx int) *types.Block {
 //
 // If numTx > 0, that many empty DynamicFeeTxs will be added to the txs.
 func newMiniL2BlockWithNumberParent(numTx int, number *big.Int, parent common.Hash) *types.Block {
+	return newMiniL2BlockWithNumberParentOrigin(numTx, number, parent, 100)
+}
+
+// newMiniL2BlockWithNumberParentOrigin returns a minimal L2 block with the
+// given L1 origin block number.
+func newMiniL2BlockWithNumberParentOrigin(numTx int, number *big.Int, parent common.Hash, l1Number uint64) *types.Block {
 	l1Block := types.NewBlock(&types.Header{
 		BaseFee:    big.NewInt(10),
 		Difficulty: common.Big0,
-		Number:     big.NewInt(100),
+		Number:     new(big.Int).SetUint64(l1Number),
 	}, nil, nil, nil, trie.NewStackTrie(nil))
 	l1InfoTx, err := derive.L1InfoDeposit(0, eth.BlockToInfo(l1Block), eth.SystemConfig{}, false)
 	if err != nil {
@@ -410,7 +433,7 @@ func TestChannelBuilder_NextFrame(t *testing.T) {
 	require.NoError(t, err)
 
 	// Push one frame into to the channel builder
-	expectedTx := txID{chID: co.ID(), frameNumber: fn}
+	expectedTx := frameID{chID: co.ID(), frameNumber: fn}
 	expectedBytes := buf.Bytes()
 	frameData := frameData{
 		id: frameID{
@@ -758,6 +781,43 @@ func TestFramePublished(t *testing.T) {
 	require.Equal(t, uint64(1000), cb.timeout)
 }
 
+// TestDurationExtension tests that the duration extension delays the channel
+// duration timeout, but not the sequencing window timeout.
+func TestDurationExtension(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.MaxChannelDuration = 2
+	channelConfig.SeqWindowSize = 10
+	channelConfig.SubSafetyMargin = 2
+
+	cb, err := newChannelBuilder(channelConfig, nil)
+	require.NoError(t, err)
+	cb.SetDurationExtension(3)
+
+	// The mini block's L1 origin is block 100, so the sequencing window timeout is at 108.
+	require.NoError(t, addMiniBlock(cb))
+	cb.RegisterL1Block(100)
+	require.Equal(t, uint64(102), cb.timeout)
+	require.Equal(t, uint64(108), cb.hardTimeout)
+
+	cb.RegisterL1Block(104)
+	require.False(t, cb.IsFull())
+	cb.RegisterL1Block(105)
+	require.ErrorIs(t, cb.FullErr(), ErrMaxDurationReached)
+
+	// The extension doesn't delay the sequencing window timeout.
+	cb, err = newChannelBuilder(channelConfig, nil)
+	require.NoError(t, err)
+	cb.SetDurationExtension(10)
+	require.NoError(t, addMiniBlock(cb))
+	cb.RegisterL1Block(100)
+	cb.RegisterL1Block(107)
+	require.False(t, cb.IsFull())
+	require.False(t, cb.HardTimedOut(107))
+	cb.RegisterL1Block(108)
+	require.True(t, cb.HardTimedOut(108))
+	require.ErrorIs(t, cb.FullErr(), ErrSeqWindowClose)
+}
+
 func ChannelBuilder_PendingFrames_TotalFrames(t *testing.T, batchType uint) {
 	const tnf = 9
 	rng := rand.New(rand.NewSource(94572314))
@@ -1,12 +1,15 @@
 package batcher
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -40,11 +43,24 @@ type channelManager struct {
 	currentChannel *channel
 	// channels to read frame data from, for writing batches onchain
 	channelQueue []*channel
-	// used to lookup channels by tx ID upon tx success / failure
-	txChannels map[txID]*channel
+	// used to lookup the channels of the frames of a tx by tx ID upon tx success / failure
+	txChannels map[string][]*channel
 
 	// if set to true, prevents production of any new channel frames
 	closed bool
+
+	// decision of the submission policy at the latest L1 head
+	decision submissionDecision
+
+	// temporary override of the configured max channel duration, nil if not set
+	durationOverride *durationOverride
+}
+
+// durationOverride temporarily overrides the configured max channel duration.
+type durationOverride struct {
+	duration uint64
+	// time at which the override expires, zero if it doesn't expire
+	expiry time.Time
 }
 
 func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *rollup.Config) *channelManager {
@@ -53,7 +69,7 @@ func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig,
 		metr:       metr,
 		cfg:        cfg,
 		rcfg:       rcfg,
-		txChannels: make(map[txID]*channel),
+		txChannels: make(map[string][]*channel),
 	}
 }
 
@@ -68,7 +84,7 @@ func (s *channelManager) Clear() {
 	s.closed = false
 	s.currentChannel = nil
 	s.channelQueue = nil
-	s.txChannels = make(map[txID]*channel)
+	s.txChannels = make(map[string][]*channel)
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
@@ -76,32 +92,40 @@ func (s *channelManager) Clear() {
 func (s *channelManager) TxFailed(id txID) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if channel, ok := s.txChannels[id]; ok {
-		delete(s.txChannels, id)
-		channel.TxFailed(id)
-		if s.closed && channel.NoneSubmitted() {
-			s.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", channel.ID())
-			s.removePendingChannel(channel)
+	if channels, ok := s.txChannels[id.String()]; ok {
+		delete(s.txChannels, id.String())
+		for _, channel := range channels {
+			channel.TxFailed(id)
+			if s.closed && channel.NoneSubmitted() {
+				s.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", channel.ID())
+				s.removePendingChannel(channel)
+			}
 		}
 	} else {
 		s.log.Warn("transaction from unknown channel marked as failed", "id", id)
 	}
+	s.metr.RecordBatchTxFailed()
 }
 
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
 // a channel have been marked as confirmed on L1 the channel may be invalid & need to be
 // resubmitted.
-// This function may reset the pending channel if the pending channel has timed out.
+// This function may reset the pending channels of the tx if they have timed out.
 func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if channel, ok := s.txChannels[id]; ok {
-		delete(s.txChannels, id)
-		done, blocks := channel.TxConfirmed(id, inclusionBlock)
-		s.blocks = append(blocks, s.blocks...)
-		if done {
-			s.removePendingChannel(channel)
+	if channels, ok := s.txChannels[id.String()]; ok {
+		delete(s.txChannels, id.String())
+		// the channels are in queue order, so are the blocks of timed out channels
+		var requeued []*types.Block
+		for _, channel := range channels {
+			done, blocks := channel.TxConfirmed(id, inclusionBlock)
+			requeued = append(requeued, blocks...)
+			if done {
+				s.removePendingChannel(channel)
+			}
 		}
+		s.blocks = append(requeued, s.blocks...)
 	} else {
 		s.log.Warn("transaction from unknown channel marked as confirmed", "id", id)
 	}
@@ -128,22 +152,107 @@ func (s *channelManager) removePendingChannel(channel *channel) {
 	s.channelQueue = append(s.channelQueue[:index], s.channelQueue[index+1:]...)
 }
 
-// nextTxData pops off s.datas & handles updating the internal state
-func (s *channelManager) nextTxData(channel *channel) (txData, error) {
-	if channel == nil || !channel.HasFrame() {
+// nextTxData pops the next frames off the first channel & handles updating the internal state.
+// If multi-frame txs are enabled, it packs as many frames as fit into the tx, continuing with
+// the channels queued after the first channel, up to and including the last channel, or up to
+// the end of the queue if last is nil. The tx size is limited by the frame size of the
+// first channel, so the tx fits the data availability type that channel was sized for.
+func (s *channelManager) nextTxData(first, last *channel) (txData, error) {
+	if first == nil || !first.HasFrame() {
 		s.log.Trace("no next tx data")
 		return txData{}, io.EOF // TODO: not enough data error instead
 	}
-	tx := channel.NextTxData()
-	s.txChannels[tx.ID()] = channel
+
+	var tx txData
+	for _, ch := range s.channelsFrom(first, last) {
+		for ch.HasFrame() && (len(tx.frames) == 0 || tx.Len()+ch.NextFrameLen() <= first.cfg.MaxTxDataSize()) {
+			tx.frames = append(tx.frames, ch.NextFrame())
+			if !s.cfg.MultiFrameTxs {
+				break
+			}
+		}
+		// stop at the first frame that doesn't fit, to submit frames in order
+		if !s.cfg.MultiFrameTxs || ch.HasFrame() {
+			break
+		}
+	}
+
+	if err := s.txPending(tx); err != nil {
+		return txData{}, err
+	}
 	return tx, nil
 }
 
+// txPending registers the frames of the tx as pending at their channels.
+// The frames of a single channel must be contiguous in the tx.
+func (s *channelManager) txPending(tx txData) error {
+	var (
+		channels []*channel
+		parts    []txData
+	)
+	for _, frame := range tx.frames {
+		if n := len(channels); n > 0 && channels[n-1].ID() == frame.id.chID {
+			parts[n-1].frames = append(parts[n-1].frames, frame)
+			continue
+		}
+		ch := s.channelByID(frame.id.chID)
+		if ch == nil {
+			return fmt.Errorf("unknown channel %s of frame %d", frame.id.chID, frame.id.frameNumber)
+		}
+		channels = append(channels, ch)
+		parts = append(parts, singleFrameTxData(frame))
+	}
+
+	id := tx.ID()
+	for i, ch := range channels {
+		ch.TxPending(id, parts[i])
+	}
+	s.txChannels[id.String()] = channels
+	return nil
+}
+
+// channelByID returns the queued channel with the given id, or nil if there's none.
+func (s *channelManager) channelByID(id derive.ChannelID) *channel {
+	for _, ch := range s.channelQueue {
+		if ch.ID() == id {
+			return ch
+		}
+	}
+	return nil
+}
+
+// channelsFrom returns the first channel, followed by the channels queued after it,
+// up to and including the last channel, or up to the end of the queue if last is nil.
+func (s *channelManager) channelsFrom(first, last *channel) []*channel {
+	for i, ch := range s.channelQueue {
+		if ch != first {
+			continue
+		}
+		for j, ch := range s.channelQueue[i:] {
+			if ch == last {
+				return s.channelQueue[i : i+j+1]
+			}
+		}
+		return s.channelQueue[i:]
+	}
+	return []*channel{first}
+}
+
+// SetSubmissionDecision sets the decision of the submission policy, which is
+// applied by subsequent calls to TxData.
+func (s *channelManager) SetSubmissionDecision(decision submissionDecision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decision = decision
+}
+
 // TxData returns the next tx data that should be submitted to L1.
 //
-// It currently only uses one frame per transaction. If the pending channel is
-// full, it only returns the remaining frames of this channel until it got
-// successfully fully sent to L1. It returns io.EOF if there's no pending frame.
+// It uses one frame per transaction, unless multi-frame txs are enabled. If the
+// pending channel is full, it only returns the remaining frames of the queued
+// channels until they got successfully fully sent to L1. It returns io.EOF if
+// there's no pending frame, or if the submission policy holds back the pending
+// frames.
 func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -158,36 +267,74 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	dataPending := firstWithFrame != nil && firstWithFrame.HasFrame()
 	s.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(s.blocks))
 
-	// Short circuit if there is a pending frame or the channel manager is closed.
-	if dataPending || s.closed {
-		return s.nextTxData(firstWithFrame)
+	// Short circuit if the channel manager is closed or there is a pending
+	// frame that isn't held back.
+	if s.closed || (dataPending && !s.decision.Hold) {
+		return s.nextTxData(firstWithFrame, nil)
 	}
 
-	// No pending frame, so we have to add new blocks to the channel
+	// No pending frame or pending frames are held back, so we have to add new
+	// blocks to the channel
 
 	// If we have no saved blocks, we will not be able to create valid frames
-	if len(s.blocks) == 0 {
+	if len(s.blocks) == 0 && !dataPending {
 		return txData{}, io.EOF
 	}
 
-	if err := s.ensureChannelWithSpace(l1Head); err != nil {
-		return txData{}, err
-	}
+	if len(s.blocks) > 0 {
+		if err := s.ensureChannelWithSpace(l1Head); err != nil {
+			return txData{}, err
+		}
 
-	if err := s.processBlocks(); err != nil {
-		return txData{}, err
-	}
+		if err := s.processBlocks(); err != nil {
+			return txData{}, err
+		}
 
-	// Register current L1 head only after all pending blocks have been
-	// processed. Even if a timeout will be triggered now, it is better to have
-	// all pending blocks be included in this channel for submission.
-	s.registerL1Block(l1Head)
+		// Register current L1 head only after all pending blocks have been
+		// processed. Even if a timeout will be triggered now, it is better to have
+		// all pending blocks be included in this channel for submission.
+		s.registerL1Block(l1Head)
 
-	if err := s.outputFrames(); err != nil {
-		return txData{}, err
+		if err := s.outputFrames(); err != nil {
+			return txData{}, err
+		}
 	}
 
-	return s.nextTxData(s.currentChannel)
+	if s.decision.Hold {
+		return s.heldTxData(l1Head)
+	}
+	return s.nextTxData(s.currentChannel, nil)
+}
+
+// heldTxData returns the next tx data while the submission policy holds back
+// pending frames. Frames are only submitted if a queued channel with pending
+// frames got close to its channel timeout or the end of its sequencing window,
+// or was flushed. The channels up to and including the last such channel are
+// then submitted in order, starting with the first one with a pending frame.
+// Later channels stay held back, also in multi-frame txs. Otherwise, it returns
+// io.EOF.
+func (s *channelManager) heldTxData(l1Head eth.BlockID) (txData, error) {
+	var firstWithFrame, lastForced *channel
+	for _, ch := range s.channelQueue {
+		if !ch.HasFrame() {
+			continue
+		}
+		if firstWithFrame == nil {
+			firstWithFrame = ch
+		}
+		if ch.HardTimedOut(l1Head.Number) || ch.flushed {
+			lastForced = ch
+		}
+	}
+	if lastForced != nil {
+		s.log.Info("Forcing submission of held back channel", "id", lastForced.ID(), "l1Head", l1Head, "flushed", lastForced.flushed)
+		s.metr.RecordSubmissionDecision(metrics.SubmissionForced)
+		return s.nextTxData(firstWithFrame, lastForced)
+	}
+	if firstWithFrame != nil {
+		s.log.Debug("Holding back pending frames", "l1Head", l1Head, "first_channel", firstWithFrame.ID())
+	}
+	return txData{}, io.EOF
 }
 
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
@@ -198,7 +345,10 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 		return nil
 	}
 
-	pc, err := newChannel(s.log, s.metr, s.cfg, s.rcfg)
+	cfg := channelFrameConfig(s.cfg, s.rcfg, s.blocks)
+	cfg.MaxChannelDuration = s.maxChannelDuration()
+	cfg.CompressorConfig.CompressionAlgo = channelCompressionAlgo(s.cfg, s.rcfg, s.blocks)
+	pc, err := newChannel(s.log, s.metr, cfg, s.rcfg)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -207,14 +357,64 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 	s.log.Info("Created channel",
 		"id", pc.ID(),
 		"l1Head", l1Head,
-		"blocks_pending", len(s.blocks))
+		"blocks_pending", len(s.blocks),
+		"compression_algo", cfg.CompressorConfig.CompressionAlgo,
+		"use_blobs", cfg.UseBlobs)
 	s.metr.RecordChannelOpened(pc.ID(), len(s.blocks))
 
 	return nil
 }
 
-// registerL1Block registers the given block at the pending channel.
+// channelCompressionAlgo returns the compression algorithm of a channel that
+// starts with the first of the given blocks. Versioned channels are only read
+// by derivation from L1 blocks at or after the channel compression time, and a
+// channel is never included before the L1 origin of its first block. So the
+// configured algorithm is only used once that L1 origin is at or after the
+// channel compression time, and zlib before.
+func channelCompressionAlgo(cfg ChannelConfig, rcfg *rollup.Config, blocks []*types.Block) derive.CompressionAlgo {
+	algo := cfg.CompressorConfig.CompressionAlgo
+	if algo == "" || algo == derive.Zlib {
+		return algo
+	}
+	if len(blocks) == 0 {
+		return derive.Zlib
+	}
+	_, l1Info, err := derive.BlockToSingularBatch(blocks[0])
+	if err != nil || !rcfg.IsChannelCompression(l1Info.Time) {
+		return derive.Zlib
+	}
+	return algo
+}
+
+// channelFrameConfig returns the config of a channel that starts with the
+// first of the given blocks, with its frames sized for the data availability
+// type they are submitted with. Blob txs are only sent once the L1 head is at
+// or after the blobs time, and a channel is never included before the L1
+// origin of its first block. So the frames are only sized to fill a blob once
+// that L1 origin is at or after the blobs time, and sized for calldata before.
+func channelFrameConfig(cfg ChannelConfig, rcfg *rollup.Config, blocks []*types.Block) ChannelConfig {
+	if !cfg.UseBlobs {
+		return cfg
+	}
+	if len(blocks) > 0 {
+		_, l1Info, err := derive.BlockToSingularBatch(blocks[0])
+		if err == nil && rcfg.IsBlobs(l1Info.Time) {
+			// leave room for the derivation version byte
+			cfg.MaxFrameSize = eth.MaxBlobDataSize - 1
+			cfg.CompressorConfig.TargetFrameSize = eth.MaxBlobDataSize - 1
+			return cfg
+		}
+	}
+	cfg.UseBlobs = false
+	return cfg
+}
+
+// registerL1Block registers the given block at the pending channel, after
+// applying the max channel duration and the duration extension of the
+// submission policy.
 func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
+	s.currentChannel.SetMaxChannelDuration(s.maxChannelDuration())
+	s.currentChannel.SetDurationExtension(s.decision.DurationExtension)
 	s.currentChannel.RegisterL1Block(l1Head.Number)
 	s.log.Debug("new L1-block registered at channel builder",
 		"l1Head", l1Head,
@@ -362,3 +562,81 @@ func (s *channelManager) Close() error {
 
 	return s.outputFrames()
 }
+
+// maxChannelDuration returns the max channel duration, which is the duration of
+// the override while it isn't expired, or else the configured duration.
+func (s *channelManager) maxChannelDuration() uint64 {
+	if o := s.durationOverride; o != nil {
+		if o.expiry.IsZero() || time.Now().Before(o.expiry) {
+			return o.duration
+		}
+		s.log.Info("Max channel duration override expired", "duration", o.duration, "configured_duration", s.cfg.MaxChannelDuration)
+		s.durationOverride = nil
+	}
+	return s.cfg.MaxChannelDuration
+}
+
+// SetMaxChannelDuration overrides the configured max channel duration until the
+// given expiry, or indefinitely if the expiry is zero. The override applies to
+// new channels and to the current channel, see channelBuilder.SetMaxChannelDuration.
+func (s *channelManager) SetMaxChannelDuration(duration uint64, expiry time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.durationOverride = &durationOverride{duration: duration, expiry: expiry}
+	s.log.Info("Overriding max channel duration", "duration", duration, "expiry", expiry)
+}
+
+// FlushChannel closes the current channel and outputs all its frames, which are
+// then submitted even while the submission policy holds back frames. Blocks that
+// aren't added to a channel yet are added to the next channel.
+// It returns an error if there is no open channel.
+func (s *channelManager) FlushChannel() (derive.ChannelID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.currentChannel == nil || s.currentChannel.IsFull() {
+		return derive.ChannelID{}, errors.New("no open channel")
+	}
+	s.currentChannel.channelBuilder.setFullErr(ErrTerminated)
+	s.currentChannel.flushed = true
+	if err := s.outputFrames(); err != nil {
+		return derive.ChannelID{}, err
+	}
+	s.log.Info("Flushed channel", "id", s.currentChannel.ID())
+	return s.currentChannel.ID(), nil
+}
+
+// ChannelInfos returns information about the queued channels, in order.
+func (s *channelManager) ChannelInfos() []rpc.ChannelInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos := make([]rpc.ChannelInfo, 0, len(s.channelQueue))
+	for _, ch := range s.channelQueue {
+		infos = append(infos, ch.info())
+	}
+	return infos
+}
+
+// ChannelFrames rebuilds the queued channel with the given id from its blocks,
+// and returns all of its frames created yet, in order.
+func (s *channelManager) ChannelFrames(id derive.ChannelID) ([]derive.Frame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.channelByID(id)
+	if ch == nil {
+		return nil, fmt.Errorf("unknown channel %s", id)
+	}
+	jc := ch.journal()
+	_, all, err := rebuildChannel(s.log, s.metr, ch.cfg, s.rcfg, &jc, ch.channelBuilder.Blocks())
+	if err != nil {
+		return nil, fmt.Errorf("rebuilding channel %s: %w", id, err)
+	}
+	frames := make([]derive.Frame, 0, len(all))
+	for _, f := range all {
+		var frame derive.Frame
+		if err := frame.UnmarshalBinary(bytes.NewReader(f.data)); err != nil {
+			return nil, fmt.Errorf("decoding frame %d: %w", f.id.frameNumber, err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
@@ -1,6 +1,7 @@
 package batcher
 
 import (
+	"fmt"
 	"io"
 	"math/big"
 	"math/rand"
@@ -166,7 +167,7 @@ func ChannelManager_Clear(t *testing.T, batchType uint) {
 	require.NoError(m.processBlocks())
 	require.NoError(m.currentChannel.channelBuilder.co.Flush())
 	require.NoError(m.currentChannel.OutputFrames())
-	_, err := m.nextTxData(m.currentChannel)
+	_, err := m.nextTxData(m.currentChannel, nil)
 	require.NoError(err)
 	require.Len(m.blocks, 0)
 	require.Equal(newL1Tip, m.tip)
@@ -413,3 +414,300 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 	_, err = m.TxData(eth.BlockID{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// TestChannelManager_MultiFrameTxs ensures that the channel manager packs frames of
+// multiple channels into a single tx, and that it handles failed & confirmed txs
+// for each channel of the tx.
+func TestChannelManager_MultiFrameTxs(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		ChannelTimeout: 10,
+		MaxFrameSize:   100,
+		MultiFrameTxs:  true,
+	}, &defaultTestRollupConfig)
+	m.Clear()
+
+	// Queue two closed channels with two small frames each.
+	var frames []frameData
+	for c := 0; c < 2; c++ {
+		require.NoError(m.ensureChannelWithSpace(eth.BlockID{}))
+		ch := m.currentChannel
+		for i := 0; i < 2; i++ {
+			frame := frameData{
+				id:   frameID{chID: ch.ID(), frameNumber: uint16(i)},
+				data: make([]byte, 30),
+			}
+			ch.channelBuilder.PushFrame(frame)
+			frames = append(frames, frame)
+		}
+		ch.Close()
+	}
+	require.Len(m.channelQueue, 2)
+	chA, chB := m.channelQueue[0], m.channelQueue[1]
+
+	// Three frames fit into the max tx data size of 101 bytes, the fourth doesn't.
+	tx0, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(frames[:3], tx0.Frames())
+	require.Equal(91, tx0.Len())
+	require.Equal([]*channel{chA, chB}, m.txChannels[tx0.ID().String()])
+
+	tx1, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(frames[3:], tx1.Frames())
+	require.Equal([]*channel{chB}, m.txChannels[tx1.ID().String()])
+
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+
+	// A failed tx requeues its frames at each of its channels.
+	m.TxFailed(tx0.ID())
+	require.Equal(2, chA.PendingFrames())
+	require.Equal(1, chB.PendingFrames())
+	require.Empty(chA.pendingTransactions)
+	require.Len(chB.pendingTransactions, 1)
+
+	tx2, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(frames[:3], tx2.Frames())
+
+	// Confirming all txs fully submits both channels.
+	m.TxConfirmed(tx1.ID(), eth.BlockID{Number: 1})
+	require.Len(m.channelQueue, 2)
+	m.TxConfirmed(tx2.ID(), eth.BlockID{Number: 2})
+	require.Empty(m.channelQueue)
+	require.Empty(m.txChannels)
+	require.Empty(m.blocks)
+}
+
+func TestChannelManager_FlushChannel(t *testing.T) {
+	require := require.New(t)
+	cfg := defaultTestChannelConfig
+	cfg.MaxChannelDuration = 0
+	cfg.SeqWindowSize = 1000
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear()
+	m.SetSubmissionDecision(submissionDecision{Hold: true})
+
+	_, err := m.FlushChannel()
+	require.ErrorContains(err, "no open channel")
+
+	var parent common.Hash
+	for i := 0; i < 3; i++ {
+		block := newMiniL2BlockWithNumberParent(0, big.NewInt(int64(i)), parent)
+		parent = block.Hash()
+		require.NoError(m.AddL2Block(block))
+	}
+	_, err = m.TxData(eth.BlockID{Number: 100})
+	require.ErrorIs(err, io.EOF)
+
+	id, err := m.FlushChannel()
+	require.NoError(err)
+	require.Equal(m.currentChannel.ID(), id)
+	_, err = m.FlushChannel()
+	require.ErrorContains(err, "no open channel")
+
+	// The flushed channel is submitted although frames are held back.
+	tx, err := m.TxData(eth.BlockID{Number: 100})
+	require.NoError(err)
+	require.Equal(id, tx.Frames()[0].id.chID)
+	_, err = m.TxData(eth.BlockID{Number: 100})
+	require.ErrorIs(err, io.EOF)
+
+	infos := m.ChannelInfos()
+	require.Len(infos, 1)
+	require.Equal(id, infos[0].ID)
+	require.True(infos[0].Full)
+	require.Equal(ErrTerminated.Error(), infos[0].FullReason)
+	require.Equal(3, infos[0].NumBlocks)
+	require.Equal(uint64(2), infos[0].LastBlock.Number)
+	require.Equal(1, infos[0].TotalFrames)
+	require.Zero(infos[0].QueuedFrames)
+	require.Len(infos[0].PendingTxs, 1)
+	require.Equal(tx.ID().String(), infos[0].PendingTxs[0].ID)
+	require.Equal([]uint16{0}, infos[0].PendingTxs[0].Frames)
+}
+
+func TestChannelManager_SetMaxChannelDuration(t *testing.T) {
+	require := require.New(t)
+	cfg := defaultTestChannelConfig
+	cfg.MaxChannelDuration = 0
+	cfg.SeqWindowSize = 1000
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear()
+	m.SetMaxChannelDuration(2, time.Time{})
+
+	block := newMiniL2BlockWithNumberParent(0, big.NewInt(0), common.Hash{})
+	require.NoError(m.AddL2Block(block))
+	_, err := m.TxData(eth.BlockID{Number: 100})
+	require.ErrorIs(err, io.EOF)
+
+	block = newMiniL2BlockWithNumberParent(0, big.NewInt(1), block.Hash())
+	require.NoError(m.AddL2Block(block))
+	_, err = m.TxData(eth.BlockID{Number: 102})
+	require.NoError(err)
+	require.ErrorIs(m.channelQueue[0].FullErr(), ErrMaxDurationReached)
+
+	// An expired override falls back to the configured duration.
+	m.SetMaxChannelDuration(2, time.Now().Add(-time.Second))
+	require.Zero(m.maxChannelDuration())
+	require.Nil(m.durationOverride)
+}
+
+func TestChannelManager_ChannelFrames(t *testing.T) {
+	require := require.New(t)
+	cfg := ChannelConfig{
+		SeqWindowSize:  1000,
+		ChannelTimeout: 100,
+		MaxFrameSize:   500,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  500,
+			TargetNumFrames:  100,
+			ApproxComprRatio: 1.0,
+		},
+	}
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear()
+	rng := rand.New(rand.NewSource(123))
+	for i := 0; i < 3; i++ {
+		m.blocks = append(m.blocks, derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID))
+	}
+	_, err := m.TxData(eth.BlockID{Number: 1})
+	require.ErrorIs(err, io.EOF)
+	id, err := m.FlushChannel()
+	require.NoError(err)
+	tx, err := m.TxData(eth.BlockID{Number: 1})
+	require.NoError(err)
+
+	// Collect the submitted frames, some of which got confirmed already.
+	var submitted []derive.Frame
+	for ; err == nil; tx, err = m.TxData(eth.BlockID{Number: 1}) {
+		frames, err := derive.ParseFrames(tx.Bytes())
+		require.NoError(err)
+		submitted = append(submitted, frames...)
+		if len(submitted) == 1 {
+			m.TxConfirmed(tx.ID(), eth.BlockID{Number: 2})
+		}
+	}
+	require.ErrorIs(err, io.EOF)
+	require.Greater(len(submitted), 1)
+
+	frames, err := m.ChannelFrames(id)
+	require.NoError(err)
+	require.Equal(submitted, frames)
+	require.True(frames[len(frames)-1].IsLast)
+
+	_, err = m.ChannelFrames(derive.ChannelID{})
+	require.ErrorContains(err, "unknown channel")
+}
+
+// TestChannelManager_CompressionAlgoActivation ensures that channels are only
+// compressed with the configured algorithm once the L1 origin of their first
+// block is at or after the channel compression time, and with zlib before, so
+// that derivation reads them.
+func TestChannelManager_CompressionAlgoActivation(t *testing.T) {
+	const activation = 1000
+	rcfg := defaultTestRollupConfig
+	rcfg.ChannelCompressionTime = new(uint64)
+	*rcfg.ChannelCompressionTime = activation
+
+	for _, tt := range []struct {
+		l1Time    uint64
+		algo      derive.CompressionAlgo
+		versioned bool
+	}{
+		{l1Time: activation - 1, algo: derive.Zlib, versioned: false},
+		{l1Time: activation, algo: derive.Brotli, versioned: true},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("l1_time_%d", tt.l1Time), func(t *testing.T) {
+			require := require.New(t)
+			cfg := defaultTestChannelConfig
+			cfg.CompressorConfig.CompressionAlgo = derive.Brotli
+			m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &rcfg)
+			m.Clear()
+
+			require.NoError(m.AddL2Block(newMiniL2BlockWithL1Time(tt.l1Time)))
+			_, err := m.TxData(eth.BlockID{Number: 100})
+			require.ErrorIs(err, io.EOF)
+			_, err = m.FlushChannel()
+			require.NoError(err)
+			require.Equal(tt.algo, m.currentChannel.cfg.CompressorConfig.CompressionAlgo)
+
+			tx, err := m.TxData(eth.BlockID{Number: 100})
+			require.NoError(err)
+			frames, err := derive.ParseFrames(tx.Bytes())
+			require.NoError(err)
+			ch := derive.NewChannel(frames[0].ID, eth.L1BlockRef{})
+			for _, frame := range frames {
+				require.NoError(ch.AddFrame(frame, eth.L1BlockRef{}))
+			}
+			require.True(ch.IsReady())
+			next, err := derive.BatchReader(ch.Reader(), tt.versioned)
+			require.NoError(err)
+			_, err = next()
+			require.NoError(err, "derivation should read the channel")
+		})
+	}
+}
+
+func TestChannelManager_BlobsActivation(t *testing.T) {
+	const activation = 1000
+	rcfg := defaultTestRollupConfig
+	rcfg.BlobsTime = new(uint64)
+	*rcfg.BlobsTime = activation
+
+	for _, tt := range []struct {
+		l1Time          uint64
+		useBlobs        bool
+		maxFrameSize    uint64
+		targetFrameSize uint64
+	}{
+		{
+			l1Time:          activation - 1,
+			useBlobs:        false,
+			maxFrameSize:    defaultTestChannelConfig.MaxFrameSize,
+			targetFrameSize: defaultTestChannelConfig.CompressorConfig.TargetFrameSize,
+		},
+		{
+			l1Time:          activation,
+			useBlobs:        true,
+			maxFrameSize:    eth.MaxBlobDataSize - 1,
+			targetFrameSize: eth.MaxBlobDataSize - 1,
+		},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("l1_time_%d", tt.l1Time), func(t *testing.T) {
+			require := require.New(t)
+			cfg := defaultTestChannelConfig
+			cfg.UseBlobs = true
+			m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &rcfg)
+			m.Clear()
+
+			require.NoError(m.AddL2Block(newMiniL2BlockWithL1Time(tt.l1Time)))
+			_, err := m.TxData(eth.BlockID{Number: 100})
+			require.ErrorIs(err, io.EOF)
+			chCfg := m.currentChannel.cfg
+			require.Equal(tt.useBlobs, chCfg.UseBlobs)
+			require.Equal(tt.maxFrameSize, chCfg.MaxFrameSize)
+			require.Equal(tt.targetFrameSize, chCfg.CompressorConfig.TargetFrameSize)
+		})
+	}
+}
+
+// newMiniL2BlockWithL1Time returns a minimal L2 block with an L1 origin of the
+// given timestamp.
+func newMiniL2BlockWithL1Time(l1Time uint64) *types.Block {
+	l1Info, err := derive.L1InfoDeposit(0, eth.HeaderBlockInfo(&types.Header{
+		BaseFee:    big.NewInt(10),
+		Difficulty: common.Big0,
+		Number:     big.NewInt(100),
+		Time:       l1Time,
+	}), eth.SystemConfig{}, false)
+	if err != nil {
+		panic(err)
+	}
+	return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)}).
+		WithBody([]*types.Transaction{types.NewTx(l1Info)}, nil)
+}
@@ -1,10 +1,13 @@
 package batcher
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -50,21 +53,50 @@ type CLIConfig struct {
 	// MaxL1TxSize is the maximum size of a batch tx submitted to L1.
 	MaxL1TxSize uint64
 
+	// MultiFrameTxs packs multiple frames, possibly of different channels,
+	// into a single batch tx of up to MaxL1TxSize bytes.
+	MultiFrameTxs bool
+
 	Stopped bool
 
+	// ChannelJournal is the file to journal the open channels and in-flight txs
+	// to, so that they are resumed after a restart. Journaling is disabled if empty.
+	ChannelJournal string
+
+	// L1BaseFeeThreshold is the L1 base fee (in gwei) above which pending
+	// channel data is held back, unless channels get close to their channel
+	// timeout or sequencing window. If 0, channel data is always submitted.
+	L1BaseFeeThreshold float64
+
+	// MaxChannelDurationExtension is the number of L1 blocks by which the
+	// MaxChannelDuration is extended while the L1 base fee is above the
+	// L1BaseFeeThreshold, to improve the compression of channels.
+	MaxChannelDurationExtension uint64
+
 	BatchType uint
 
+	// DataAvailabilityType is one of the values defined in op-batcher/flags/types.go and dictates
+	// the data availability type to use for posting batches, e.g. blobs vs calldata.
+	DataAvailabilityType flags.DataAvailabilityType
+
 	TxMgrConfig      txmgr.CLIConfig
 	LogConfig        oplog.CLIConfig
 	MetricsConfig    opmetrics.CLIConfig
 	PprofConfig      oppprof.CLIConfig
 	CompressorConfig compressor.CLIConfig
 	RPC              oprpc.CLIConfig
+	AltDA            altda.CLIConfig
 }
 
 func (c *CLIConfig) Check() error {
 	// TODO(7512): check the sanity of flags loaded directly https://github.com/ethereum-optimism/optimism/issues/7512
 
+	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
+		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
+	}
+	if err := c.CompressorConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -77,9 +109,25 @@ func (c *CLIConfig) Check() error {
 	if err := c.RPC.Check(); err != nil {
 		return err
 	}
+	if err := c.AltDA.Check(); err != nil {
+		return err
+	}
+	if c.AltDA.Enabled && c.DataAvailabilityType == flags.BlobsType {
+		return ErrAltDAWithBlobs
+	}
+	if c.L1BaseFeeThreshold < 0 {
+		return errors.New("l1 base fee threshold must not be negative")
+	}
+	if c.MaxChannelDurationExtension > 0 && c.L1BaseFeeThreshold == 0 {
+		return errors.New("max channel duration extension requires an l1 base fee threshold")
+	}
 	return nil
 }
 
+// ErrAltDAWithBlobs is returned if alt-DA mode is enabled together with the blobs data availability type.
+// In alt-DA mode, only commitments are posted on L1, in calldata.
+var ErrAltDAWithBlobs = errors.New("alt-DA mode cannot be combined with the blobs data availability type")
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
@@ -91,16 +139,22 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		PollInterval:    ctx.Duration(flags.PollIntervalFlag.Name),
 
 		/* Optional Flags */
-		MaxPendingTransactions: ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
-		MaxChannelDuration:     ctx.Uint64(flags.MaxChannelDurationFlag.Name),
-		MaxL1TxSize:            ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
-		Stopped:                ctx.Bool(flags.StoppedFlag.Name),
-		BatchType:              ctx.Uint(flags.BatchTypeFlag.Name),
-		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
-		LogConfig:              oplog.ReadCLIConfig(ctx),
-		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:            oppprof.ReadCLIConfig(ctx),
-		CompressorConfig:       compressor.ReadCLIConfig(ctx),
-		RPC:                    oprpc.ReadCLIConfig(ctx),
+		MaxPendingTransactions:      ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
+		MaxChannelDuration:          ctx.Uint64(flags.MaxChannelDurationFlag.Name),
+		MaxL1TxSize:                 ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
+		MultiFrameTxs:               ctx.Bool(flags.MultiFrameTxsFlag.Name),
+		Stopped:                     ctx.Bool(flags.StoppedFlag.Name),
+		ChannelJournal:              ctx.String(flags.ChannelJournalFlag.Name),
+		L1BaseFeeThreshold:          ctx.Float64(flags.L1BaseFeeThresholdFlag.Name),
+		MaxChannelDurationExtension: ctx.Uint64(flags.MaxChannelDurationExtensionFlag.Name),
+		BatchType:                   ctx.Uint(flags.BatchTypeFlag.Name),
+		DataAvailabilityType:        flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
+		TxMgrConfig:                 txmgr.ReadCLIConfig(ctx),
+		LogConfig:                   oplog.ReadCLIConfig(ctx),
+		MetricsConfig:               opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:                 oppprof.ReadCLIConfig(ctx),
+		CompressorConfig:            compressor.ReadCLIConfig(ctx),
+		RPC:                         oprpc.ReadCLIConfig(ctx),
+		AltDA:                       altda.ReadCLIConfig(ctx),
 	}
 }
@@ -10,11 +10,16 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -23,14 +28,26 @@ import (
 
 var ErrBatcherNotRunning = errors.New("batcher is not running")
 
+// journalTxTimeout is how long a restarted batcher takes at most to restore its
+// journal, including the wait for the journaled txs, which were in flight before
+// the restart, to be included.
+const journalTxTimeout = 10 * time.Minute
+
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 }
 
 type L2Client interface {
 	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 }
 
+// AltDAClient stores frame data on a DA server in alt-DA mode.
+type AltDAClient interface {
+	SetInput(ctx context.Context, input []byte) (altda.Keccak256Commitment, error)
+}
+
 type RollupClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 }
@@ -46,6 +63,8 @@ type DriverSetup struct {
 	L2Client      L2Client
 	RollupClient  RollupClient
 	ChannelConfig ChannelConfig
+	// AltDA is the DA server client in alt-DA mode, nil if alt-DA is disabled.
+	AltDA AltDAClient
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -68,14 +87,29 @@ type BatchSubmitter struct {
 	lastL1Tip       eth.L1BlockRef
 
 	state *channelManager
+	// journal of the channel state, nil if journaling is disabled
+	journal *channelJournal
+
+	// L1-fee-aware submission policy and its decision at the last L1 tip
+	policy       submissionPolicy
+	lastDecision submissionDecision
+
+	// flushSignal triggers the submission of a flushed channel
+	flushSignal chan struct{}
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
 func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
-	return &BatchSubmitter{
+	l := &BatchSubmitter{
 		DriverSetup: setup,
 		state:       NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
+		policy:      newSubmissionPolicy(setup.Config.L1BaseFeeThreshold, setup.Config.MaxChannelDurationExtension),
+		flushSignal: make(chan struct{}, 1),
+	}
+	if setup.Config.ChannelJournal != "" {
+		l.journal = newChannelJournal(setup.Config.ChannelJournal)
 	}
+	return l
 }
 
 func (l *BatchSubmitter) StartBatchSubmitting() error {
@@ -101,6 +135,58 @@ func (l *BatchSubmitter) StartBatchSubmitting() error {
 	return nil
 }
 
+// PendingChannels returns information about the channels pending submission.
+func (l *BatchSubmitter) PendingChannels(_ context.Context) ([]rpc.ChannelInfo, error) {
+	return l.state.ChannelInfos(), nil
+}
+
+// FlushChannel closes the current channel and triggers the submission of its frames.
+func (l *BatchSubmitter) FlushChannel(_ context.Context) (derive.ChannelID, error) {
+	id, err := l.state.FlushChannel()
+	if err != nil {
+		return id, err
+	}
+	l.persistJournal()
+	select {
+	case l.flushSignal <- struct{}{}:
+	default: // submission already triggered
+	}
+	return id, nil
+}
+
+// SetMaxChannelDuration overrides the max channel duration for the given time, or
+// until the batcher is restarted if validFor is zero.
+func (l *BatchSubmitter) SetMaxChannelDuration(_ context.Context, duration uint64, validFor time.Duration) error {
+	var expiry time.Time
+	if validFor != 0 {
+		expiry = time.Now().Add(validFor)
+	}
+	l.state.SetMaxChannelDuration(duration, expiry)
+	return nil
+}
+
+// DumpChannel returns the frames of the pending channel with the given id, as
+// batcher txs in the format of the batch_decoder fetch command.
+func (l *BatchSubmitter) DumpChannel(_ context.Context, id derive.ChannelID) ([]rpc.FrameTx, error) {
+	frames, err := l.state.ChannelFrames(id)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]rpc.FrameTx, 0, len(frames))
+	for _, frame := range frames {
+		txs = append(txs, rpc.FrameTx{
+			TxIndex:     uint64(frame.FrameNumber),
+			InboxAddr:   l.RollupConfig.BatchInboxAddress,
+			ChainId:     l.RollupConfig.L1ChainID.Uint64(),
+			Sender:      l.Txmgr.From(),
+			ValidSender: true,
+			Frames:      []derive.Frame{frame},
+			ValidFrames: true,
+		})
+	}
+	return txs, nil
+}
+
 func (l *BatchSubmitter) StopBatchSubmittingIfRunning(ctx context.Context) error {
 	err := l.StopBatchSubmitting(ctx)
 	if errors.Is(err, ErrBatcherNotRunning) {
@@ -244,6 +330,10 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 func (l *BatchSubmitter) loop() {
 	defer l.wg.Done()
 
+	if l.journal != nil {
+		l.restoreJournal(l.shutdownCtx)
+	}
+
 	ticker := time.NewTicker(l.Config.PollInterval)
 	defer ticker.Stop()
 
@@ -260,9 +350,12 @@ func (l *BatchSubmitter) loop() {
 				}
 				l.publishStateToL1(queue, receiptsCh, true)
 				l.state.Clear()
+				l.persistJournal()
 				continue
 			}
 			l.publishStateToL1(queue, receiptsCh, false)
+		case <-l.flushSignal:
+			l.publishStateToL1(queue, receiptsCh, false)
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
 		case <-l.shutdownCtx.Done():
@@ -313,12 +406,14 @@ func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh
 // publishTxToL1 submits a single state tx to the L1
 func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) error {
 	// send all available transactions
-	l1tip, err := l.l1Tip(ctx)
+	l1Info, err := l.l1Tip(ctx)
 	if err != nil {
 		l.Log.Error("Failed to query L1 tip", "error", err)
 		return err
 	}
+	l1tip := eth.InfoToL1BlockRef(l1Info)
 	l.recordL1Tip(l1tip)
+	l.applySubmissionPolicy(l1Info)
 
 	// Collect next transaction data
 	txdata, err := l.state.TxData(l1tip.ID())
@@ -329,29 +424,106 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 		l.Log.Error("unable to get tx data", "err", err)
 		return err
 	}
+	l.persistJournal()
 
-	l.sendTransaction(txdata, queue, receiptsCh)
-	return nil
+	return l.sendTransaction(ctx, txdata, l1tip, queue, receiptsCh)
 }
 
-// sendTransaction creates & submits a transaction to the batch inbox address with the given `data`.
+// sendTransaction creates & submits a transaction to the batch inbox address with the given `txData`.
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
 // This is a blocking method. It should not be called concurrently.
-func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
-	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
-	data := txdata.Bytes()
-	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+// It only returns an error if the frame data could not be stored on the DA server in alt-DA mode,
+// in which case the tx is failed, to retry its frames once the DA server is reachable again.
+func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, l1Head eth.L1BlockRef, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) error {
+	var (
+		candidate *txmgr.TxCandidate
+		err       error
+	)
+	if l.blobsActive(l1Head) {
+		if candidate, err = l.blobTxCandidate(txdata); err != nil {
+			// Falling back to calldata would spend more on fees than the batcher is tuned for,
+			// and indicates a serious bug or misconfiguration, so we fail the tx instead.
+			l.recordFailedTx(txdata.ID(), fmt.Errorf("could not create blob tx candidate: %w", err))
+			return nil
+		}
+	} else if l.altDAActive(l1Head) {
+		if candidate, err = l.altDATxCandidate(ctx, txdata); err != nil {
+			err = fmt.Errorf("could not store frame data on DA server: %w", err)
+			l.recordFailedTx(txdata.ID(), err)
+			return err
+		}
+	} else {
+		if candidate, err = l.calldataTxCandidate(txdata.Bytes()); err != nil {
+			l.Log.Error("Failed to calculate intrinsic gas", "error", err)
+			return nil
+		}
+	}
+	if l.journal != nil {
+		id := txdata.ID()
+		candidate.OnPublish = func(tx *types.Transaction) {
+			if err := l.journal.TxPublishing(l.state, id, tx); err != nil {
+				l.Log.Error("Failed to journal tx", "id", id, "tx", tx.Hash(), "err", err)
+			}
+		}
+	}
+	queue.Send(txdata, *candidate, receiptsCh)
+	return nil
+}
+
+// blobTxCandidate creates a blob tx candidate that carries the frame data in a single blob.
+func (l *BatchSubmitter) blobTxCandidate(txdata txData) (*txmgr.TxCandidate, error) {
+	var b eth.Blob
+	if err := b.FromData(txdata.Bytes()); err != nil {
+		return nil, fmt.Errorf("data could not be converted to blob: %w", err)
+	}
+	l.Log.Debug("building blob tx candidate", "size", txdata.Len())
+	return &txmgr.TxCandidate{
+		To:       &l.RollupConfig.BatchInboxAddress,
+		Blobs:    []*eth.Blob{&b},
+		GasLimit: params.TxGas, // the tx itself carries no calldata
+	}, nil
+}
+
+// blobsActive returns whether frames are submitted in blob txs, given the current L1 head.
+// Derivation only reads blobs of L1 blocks at or after the blobs time, so calldata is posted
+// before. Txs are included after the L1 head, so once the L1 head reached the blobs time, blobs
+// are read. Calldata frames are still read after activation.
+func (l *BatchSubmitter) blobsActive(l1Head eth.L1BlockRef) bool {
+	return l.ChannelConfig.UseBlobs && l.RollupConfig.IsBlobs(l1Head.Time)
+}
+
+// altDAActive returns whether frame data is stored on the DA server, given the current L1 head.
+// Derivation only resolves commitments in L1 blocks at or after the alt-DA time, so plain frames
+// are posted before. Txs are included after the L1 head, so once the L1 head reached the alt-DA
+// time, commitments are resolved. Plain frames that get included after activation are still read.
+func (l *BatchSubmitter) altDAActive(l1Head eth.L1BlockRef) bool {
+	return l.AltDA != nil && l.RollupConfig.IsAltDA(l1Head.Time)
+}
+
+// altDATxCandidate stores the frame data on the DA server, and creates a calldata
+// tx candidate that only carries the commitment to the frame data.
+func (l *BatchSubmitter) altDATxCandidate(ctx context.Context, txdata txData) (*txmgr.TxCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	comm, err := l.AltDA.SetInput(ctx, txdata.Bytes())
 	if err != nil {
-		l.Log.Error("Failed to calculate intrinsic gas", "error", err)
-		return
+		return nil, err
 	}
+	l.Log.Debug("stored frame data on DA server", "commitment", comm, "size", txdata.Len())
+	return l.calldataTxCandidate(comm.TxData())
+}
 
-	candidate := txmgr.TxCandidate{
+// calldataTxCandidate creates a calldata tx candidate, doing the gas estimation offline.
+func (l *BatchSubmitter) calldataTxCandidate(data []byte) (*txmgr.TxCandidate, error) {
+	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return &txmgr.TxCandidate{
 		To:       &l.RollupConfig.BatchInboxAddress,
 		TxData:   data,
 		GasLimit: intrinsicGas,
-	}
-	queue.Send(txdata, candidate, receiptsCh)
+	}, nil
 }
 
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
@@ -363,6 +535,7 @@ func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
 		l.Log.Info("tx successfully published", "tx_hash", r.Receipt.TxHash, "data_size", r.ID.Len())
 		l.recordConfirmedTx(r.ID.ID(), r.Receipt)
 	}
+	l.persistJournal()
 }
 
 func (l *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
@@ -384,14 +557,191 @@ func (l *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	l.state.TxConfirmed(id, l1block)
 }
 
-// l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
-// to be a lifetime context, so it is internally wrapped with a network timeout.
-func (l *BatchSubmitter) l1Tip(ctx context.Context) (eth.L1BlockRef, error) {
+// applySubmissionPolicy decides, based on the base fee of the L1 tip, whether
+// pending channel data is held back, and applies the decision to the channel
+// manager. It does nothing if the submission policy is disabled.
+func (l *BatchSubmitter) applySubmissionPolicy(l1tip eth.BlockInfo) {
+	if !l.policy.Enabled() {
+		return
+	}
+	baseFee := l1tip.BaseFee()
+	decision := l.policy.Decide(baseFee)
+	if baseFee != nil {
+		l.Metr.RecordL1BaseFee(baseFee)
+	}
+	if decision.Hold {
+		l.Metr.RecordSubmissionDecision(metrics.SubmissionHold)
+	} else {
+		l.Metr.RecordSubmissionDecision(metrics.SubmissionSubmit)
+	}
+	if decision.Hold != l.lastDecision.Hold {
+		if decision.Hold {
+			l.Log.Info("L1 base fee above threshold, holding back channel data", "base_fee", baseFee, "threshold", l.policy.baseFeeThreshold)
+		} else {
+			l.Log.Info("L1 base fee below threshold, submitting channel data", "base_fee", baseFee, "threshold", l.policy.baseFeeThreshold)
+		}
+	}
+	l.lastDecision = decision
+	l.state.SetSubmissionDecision(decision)
+}
+
+// l1Tip gets the current L1 tip. The passed context is assumed to be a lifetime
+// context, so it is internally wrapped with a network timeout.
+func (l *BatchSubmitter) l1Tip(ctx context.Context) (eth.BlockInfo, error) {
 	tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
 	defer cancel()
 	head, err := l.L1Client.HeaderByNumber(tctx, nil)
 	if err != nil {
-		return eth.L1BlockRef{}, fmt.Errorf("getting latest L1 block: %w", err)
+		return nil, fmt.Errorf("getting latest L1 block: %w", err)
+	}
+	return eth.HeaderBlockInfo(head), nil
+}
+
+// persistJournal writes the channel state to the journal, if journaling is enabled.
+func (l *BatchSubmitter) persistJournal() {
+	if l.journal == nil {
+		return
+	}
+	if err := l.journal.Persist(l.state); err != nil {
+		l.Log.Error("Failed to persist channel journal", "err", err)
+	}
+}
+
+// restoreJournal restores the journaled channels after a restart, so that their
+// frames aren't resubmitted in new channels. It then waits for the journaled txs
+// that may still be in flight, so that their nonces aren't reused by new txs.
+//
+// If the channels can't be restored, the batcher starts at the safe head.
+// The restore is bounded by journalTxTimeout, and aborted if ctx is canceled.
+func (l *BatchSubmitter) restoreJournal(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, journalTxTimeout)
+	defer cancel()
+	state, err := l.journal.Load()
+	if err != nil {
+		l.Log.Warn("Failed to load channel journal, starting at safe head", "err", err)
+		return
+	}
+	restored := true
+	if err := l.restoreChannels(ctx, state); err != nil {
+		l.Log.Warn("Failed to restore channels from journal, starting at safe head", "err", err)
+		l.state.Clear()
+		l.lastStoredBlock = eth.BlockID{}
+		restored = false
+	}
+	l.awaitJournaledTxs(ctx, state.Txs, restored)
+	l.persistJournal()
+}
+
+// restoreChannels fetches the blocks of the journaled channels, and rebuilds the channels.
+func (l *BatchSubmitter) restoreChannels(ctx context.Context, state *journalState) error {
+	var blocks []*types.Block
+	for _, ch := range state.Channels {
+		for _, id := range ch.Blocks {
+			tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+			block, err := l.L2Client.BlockByNumber(tctx, new(big.Int).SetUint64(id.Number))
+			cancel()
+			if err != nil {
+				return fmt.Errorf("getting L2 block %d: %w", id.Number, err)
+			}
+			blocks = append(blocks, block)
+		}
+	}
+	if err := l.state.restore(state, blocks); err != nil {
+		return err
+	}
+	if len(blocks) > 0 {
+		l.lastStoredBlock = eth.ToBlockID(blocks[len(blocks)-1])
+	}
+	l.Log.Info("Restored channels from journal", "channels", len(state.Channels), "txs", len(state.Txs), "last_block", l.lastStoredBlock)
+	return nil
+}
+
+// awaitJournaledTxs waits until each journaled tx got either included, or can't be
+// included anymore because its nonce got used by another tx, or ctx expired.
+// Included txs are confirmed, the frames of all others are resubmitted. If ctx is
+// canceled, the txs in flight stay pending. If the channels weren't restored, the
+// txs are only awaited.
+func (l *BatchSubmitter) awaitJournaledTxs(ctx context.Context, txs []journalTx, restored bool) {
+	ticker := time.NewTicker(l.Config.PollInterval)
+	defer ticker.Stop()
+	for {
+		nonce, nonceErr := l.accountNonce(ctx)
+		var inFlight []journalTx
+		for _, tx := range txs {
+			id := tx.ID()
+			if tx.Nonce == nil {
+				// never published
+				l.journaledTxFailed(id, errors.New("journaled tx was not published"), restored)
+				continue
+			}
+			receipt, err := l.journaledTxReceipt(ctx, tx)
+			switch {
+			case receipt != nil:
+				l.Log.Info("Journaled tx was included", "id", id, "tx_hash", receipt.TxHash)
+				if restored {
+					l.recordConfirmedTx(id, receipt)
+				}
+			case err == nil && nonceErr == nil && nonce > *tx.Nonce:
+				l.journaledTxFailed(id, fmt.Errorf("nonce %d of journaled tx was used by another tx", *tx.Nonce), restored)
+			default:
+				if err != nil {
+					l.Log.Warn("Failed to get receipt of journaled tx", "id", id, "err", err)
+				}
+				inFlight = append(inFlight, tx)
+			}
+		}
+		if len(inFlight) == 0 {
+			return
+		}
+		if nonceErr != nil {
+			l.Log.Warn("Failed to get batcher account nonce", "err", nonceErr)
+		}
+		l.Log.Info("Waiting for journaled txs in flight", "txs", len(inFlight), "nonce", nonce)
+		txs = inFlight
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				l.Log.Info("Stopped waiting for journaled txs in flight", "txs", len(inFlight))
+				return
+			}
+			for _, tx := range inFlight {
+				l.journaledTxFailed(tx.ID(), errors.New("journaled tx was not included in time"), restored)
+			}
+			return
+		}
+	}
+}
+
+func (l *BatchSubmitter) journaledTxFailed(id txID, err error, restored bool) {
+	if restored {
+		l.recordFailedTx(id, err)
+	} else {
+		l.Log.Warn("Journaled tx failed", "id", id, "err", err)
 	}
-	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head)), nil
+}
+
+// journaledTxReceipt returns the receipt of any of the hashes of the journaled tx,
+// or nil if none of them got included.
+func (l *BatchSubmitter) journaledTxReceipt(ctx context.Context, tx journalTx) (*types.Receipt, error) {
+	for _, hash := range tx.Hashes {
+		tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		receipt, err := l.L1Client.TransactionReceipt(tctx, hash)
+		cancel()
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+// accountNonce returns the nonce of the batcher account at the latest L1 block.
+func (l *BatchSubmitter) accountNonce(ctx context.Context) (uint64, error) {
+	tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	return l.L1Client.NonceAt(tctx, l.Txmgr.From(), nil)
 }
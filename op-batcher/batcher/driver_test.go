@@ -0,0 +1,177 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+func TestBatchSubmitter_AltDATxCandidate(t *testing.T) {
+	ctx := context.Background()
+	store, err := altda.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	server := altda.NewDAServer("127.0.0.1", 0, store, testlog.Logger(t, log.LvlCrit))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(ctx))
+	}()
+	client := altda.NewDAClient(server.Endpoint())
+
+	l := NewBatchSubmitter(DriverSetup{
+		Log:          testlog.Logger(t, log.LvlCrit),
+		RollupConfig: &defaultTestRollupConfig,
+		Config:       BatcherConfig{NetworkTimeout: time.Second},
+		AltDA:        client,
+	})
+	txdata := singleFrameTxData(frameData{
+		id:   frameID{chID: derive.ChannelID{1}},
+		data: []byte("frame data"),
+	})
+	candidate, err := l.altDATxCandidate(ctx, txdata)
+	require.NoError(t, err)
+	require.Equal(t, defaultTestRollupConfig.BatchInboxAddress, *candidate.To)
+
+	// only the commitment is posted on L1, the frame data is served by the DA server
+	comm, err := altda.DecodeTxData(candidate.TxData)
+	require.NoError(t, err)
+	input, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, txdata.Bytes(), input)
+
+	require.NoError(t, server.Stop(ctx))
+	_, err = l.altDATxCandidate(ctx, txdata)
+	require.Error(t, err, "DA server is down")
+}
+
+func TestBatchSubmitter_AltDAActive(t *testing.T) {
+	rcfg := defaultTestRollupConfig
+	rcfg.AltDATime = new(uint64)
+	*rcfg.AltDATime = 1000
+	setup := DriverSetup{
+		Log:          testlog.Logger(t, log.LvlCrit),
+		RollupConfig: &rcfg,
+		AltDA:        altda.NewDAClient("http://127.0.0.1:0"),
+	}
+	l := NewBatchSubmitter(setup)
+	require.False(t, l.altDAActive(eth.L1BlockRef{Time: 999}), "plain frames before activation")
+	require.True(t, l.altDAActive(eth.L1BlockRef{Time: 1000}))
+
+	setup.AltDA = nil
+	l = NewBatchSubmitter(setup)
+	require.False(t, l.altDAActive(eth.L1BlockRef{Time: 1000}), "alt-DA disabled")
+}
+
+func TestBatchSubmitter_BlobsActive(t *testing.T) {
+	const activation = 1000
+	rcfg := defaultTestRollupConfig
+	rcfg.BlobsTime = new(uint64)
+	*rcfg.BlobsTime = activation
+	cfg := defaultTestChannelConfig
+	cfg.UseBlobs = true
+
+	txMgr := mocks.NewTxManager(t)
+	candidates := make(chan txmgr.TxCandidate, 2)
+	txMgr.On("Send", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { candidates <- args.Get(1).(txmgr.TxCandidate) }).
+		Return(&types.Receipt{}, nil)
+	l := NewBatchSubmitter(DriverSetup{
+		Log:           testlog.Logger(t, log.LvlCrit),
+		RollupConfig:  &rcfg,
+		ChannelConfig: cfg,
+		Txmgr:         txMgr,
+	})
+	require.False(t, l.blobsActive(eth.L1BlockRef{Time: activation - 1}), "calldata before activation")
+	require.True(t, l.blobsActive(eth.L1BlockRef{Time: activation}))
+
+	ctx := context.Background()
+	queue := txmgr.NewQueue[txData](ctx, txMgr, 0)
+	receiptsCh := make(chan txmgr.TxReceipt[txData], 2)
+	txdata := singleFrameTxData(frameData{
+		id:   frameID{chID: derive.ChannelID{1}},
+		data: []byte("frame data"),
+	})
+
+	require.NoError(t, l.sendTransaction(ctx, txdata, eth.L1BlockRef{Time: activation - 1}, queue, receiptsCh))
+	candidate := <-candidates
+	require.Empty(t, candidate.Blobs)
+	require.Equal(t, txdata.Bytes(), candidate.TxData)
+
+	require.NoError(t, l.sendTransaction(ctx, txdata, eth.L1BlockRef{Time: activation}, queue, receiptsCh))
+	candidate = <-candidates
+	require.Len(t, candidate.Blobs, 1)
+	require.Empty(t, candidate.TxData)
+
+	cfg.UseBlobs = false
+	l = NewBatchSubmitter(DriverSetup{Log: testlog.Logger(t, log.LvlCrit), RollupConfig: &rcfg, ChannelConfig: cfg})
+	require.False(t, l.blobsActive(eth.L1BlockRef{Time: activation}), "calldata configured")
+}
+
+// pendingL1Client is an L1 client on which no batcher tx gets included.
+type pendingL1Client struct{}
+
+func (pendingL1Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1)}, nil
+}
+
+func (pendingL1Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+func (pendingL1Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return nil, ethereum.NotFound
+}
+
+func TestBatchSubmitter_AwaitJournaledTxs(t *testing.T) {
+	newSubmitter := func(t *testing.T) (*BatchSubmitter, *testlog.CapturingHandler) {
+		txMgr := mocks.NewTxManager(t)
+		txMgr.On("From").Return(common.Address{0xba}).Maybe()
+		logger := testlog.Logger(t, log.LvlInfo)
+		logs := testlog.Capture(logger)
+		l := NewBatchSubmitter(DriverSetup{
+			Log:          logger,
+			RollupConfig: &defaultTestRollupConfig,
+			Config:       BatcherConfig{NetworkTimeout: time.Second, PollInterval: time.Hour},
+			Txmgr:        txMgr,
+			L1Client:     pendingL1Client{},
+		})
+		return l, logs
+	}
+	nonce := uint64(5)
+	txs := []journalTx{{
+		Frames: []journalFrameID{{Channel: derive.ChannelID{1}}},
+		Nonce:  &nonce,
+		Hashes: []common.Hash{{0x01}},
+	}}
+
+	t.Run("shutdown", func(t *testing.T) {
+		l, logs := newSubmitter(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		l.awaitJournaledTxs(ctx, txs, false)
+		require.NotNil(t, logs.FindLog(log.LvlInfo, "Stopped waiting for journaled txs in flight"))
+		require.Nil(t, logs.FindLog(log.LvlWarn, "Journaled tx failed"), "txs in flight stay pending")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		l, logs := newSubmitter(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		l.awaitJournaledTxs(ctx, txs, false)
+		require.NotNil(t, logs.FindLog(log.LvlWarn, "Journaled tx failed"))
+	})
+}
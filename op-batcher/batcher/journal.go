@@ -0,0 +1,372 @@
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// channelJournal persists the channels of the channel manager and its pending
+// txs to a file, so that a restarted batcher can resume its channels instead of
+// resubmitting all blocks since the safe head in new channels.
+//
+// Only the block ids and frame hashes of a channel are journaled. The channels
+// are rebuilt from their blocks on restore, and the rebuilt frames are checked
+// against the journaled frame hashes.
+type channelJournal struct {
+	mu   sync.Mutex
+	file string
+
+	// published pending txs by tx id, with their nonce and hashes
+	published map[string]*journalTx
+}
+
+func newChannelJournal(file string) *channelJournal {
+	return &channelJournal{
+		file:      file,
+		published: make(map[string]*journalTx),
+	}
+}
+
+// journalState is the journaled state of the channel manager.
+type journalState struct {
+	Channels []journalChannel `json:"channels"`
+	// Txs are the pending txs of the channel manager.
+	Txs []journalTx `json:"txs"`
+}
+
+// journalChannel is a channel of the channel manager.
+type journalChannel struct {
+	ID derive.ChannelID `json:"id"`
+	// Blocks are the L2 blocks that got added to the channel, in order.
+	Blocks []eth.BlockID `json:"blocks"`
+	// Full indicates that the channel was full, so all its frames got created.
+	Full       bool   `json:"full"`
+	FullReason string `json:"fullReason,omitempty"`
+	// Timeout is the L1 block number at which the channel times out, if not full yet.
+	Timeout       uint64 `json:"timeout,omitempty"`
+	TimeoutReason string `json:"timeoutReason,omitempty"`
+	// Frames are the hashes of all frames that got created, by frame number.
+	Frames []common.Hash `json:"frames"`
+	// Queued are the numbers of the frames that are not submitted yet, in queue order.
+	Queued []uint16 `json:"queued"`
+	// Confirmed are the inclusion blocks of the confirmed txs of the channel, by tx id.
+	Confirmed map[string]eth.BlockID `json:"confirmed"`
+}
+
+// journalTx is a pending tx of the channel manager.
+type journalTx struct {
+	Frames []journalFrameID `json:"frames"`
+	// Nonce and Hashes are only set once the tx got published. Hashes contains
+	// the hashes of all fee bumped versions of the tx.
+	Nonce  *uint64       `json:"nonce,omitempty"`
+	Hashes []common.Hash `json:"hashes,omitempty"`
+}
+
+type journalFrameID struct {
+	Channel derive.ChannelID `json:"channel"`
+	Number  uint16           `json:"number"`
+}
+
+// ID returns the id of the tx.
+func (tx *journalTx) ID() txID {
+	id := make(txID, 0, len(tx.Frames))
+	for _, f := range tx.Frames {
+		id = append(id, frameID{chID: f.Channel, frameNumber: f.Number})
+	}
+	return id
+}
+
+// Load reads the journaled state. It returns an empty state if there's no journal yet.
+func (j *channelJournal) Load() (*journalState, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	data, err := os.ReadFile(j.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return &journalState{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read channel journal (%v): %w", j.file, err)
+	}
+	var state journalState
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&state); err != nil {
+		return nil, fmt.Errorf("invalid channel journal (%v): %w", j.file, err)
+	}
+	// The published txs may still be in flight, so keep track of them.
+	j.published = make(map[string]*journalTx)
+	for i := range state.Txs {
+		if tx := state.Txs[i]; tx.Nonce != nil {
+			j.published[tx.ID().String()] = &tx
+		}
+	}
+	return &state, nil
+}
+
+// TxPublishing records the nonce and hash of the given pending tx of the channel
+// manager, and persists the journal. It must be called before the tx gets published.
+func (j *channelJournal) TxPublishing(m *channelManager, id txID, tx *types.Transaction) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	p, ok := j.published[id.String()]
+	if !ok {
+		p = new(journalTx)
+		j.published[id.String()] = p
+	}
+	nonce := tx.Nonce()
+	p.Nonce = &nonce
+	if !slices.Contains(p.Hashes, tx.Hash()) {
+		p.Hashes = append(p.Hashes, tx.Hash())
+	}
+	return j.persist(m)
+}
+
+// Persist writes the current state of the channel manager to the journal.
+func (j *channelJournal) Persist(m *channelManager) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.persist(m)
+}
+
+// persist writes the journal to the file as safely as possible. It initially
+// writes to a temp file and syncs it, before renaming it into place.
+func (j *channelJournal) persist(m *channelManager) error {
+	state := m.journalState()
+	published := make(map[string]*journalTx)
+	for i := range state.Txs {
+		tx := &state.Txs[i]
+		id := tx.ID().String()
+		if p, ok := j.published[id]; ok {
+			tx.Nonce, tx.Hashes = p.Nonce, p.Hashes
+			published[id] = p
+		}
+	}
+	// forget about txs that aren't pending anymore
+	j.published = published
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal channel journal: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(j.file), 0755); err != nil {
+		return fmt.Errorf("create channel journal dir (%v): %w", j.file, err)
+	}
+	tmpFile := j.file + ".tmp"
+	file, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open file (%v) for writing: %w", tmpFile, err)
+	}
+	defer file.Close() // Ensure file is closed even if write or sync fails
+	if _, err = file.Write(data); err != nil {
+		return fmt.Errorf("write channel journal to temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync channel journal temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close channel journal temp file (%v): %w", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, j.file); err != nil {
+		return fmt.Errorf("rename temp channel journal to final destination: %w", err)
+	}
+	return nil
+}
+
+// journalState returns the state of the channels and pending txs, to be journaled.
+func (s *channelManager) journalState() journalState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := journalState{
+		Channels: make([]journalChannel, 0, len(s.channelQueue)),
+		Txs:      make([]journalTx, 0, len(s.txChannels)),
+	}
+	for _, ch := range s.channelQueue {
+		state.Channels = append(state.Channels, ch.journal())
+	}
+
+	ids := make([]string, 0, len(s.txChannels))
+	for id := range s.txChannels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		var tx journalTx
+		for _, ch := range s.txChannels[id] {
+			data := ch.pendingTransactions[id]
+			for _, f := range data.Frames() {
+				tx.Frames = append(tx.Frames, journalFrameID{Channel: f.id.chID, Number: f.id.frameNumber})
+			}
+		}
+		state.Txs = append(state.Txs, tx)
+	}
+	return state
+}
+
+// journal returns the journaled state of the channel.
+func (s *channel) journal() journalChannel {
+	cb := s.channelBuilder
+	jc := journalChannel{
+		ID:        s.ID(),
+		Blocks:    make([]eth.BlockID, 0, len(cb.Blocks())),
+		Full:      cb.IsFull(),
+		Timeout:   cb.timeout,
+		Frames:    slices.Clone(cb.FrameHashes()),
+		Queued:    make([]uint16, 0, cb.PendingFrames()),
+		Confirmed: make(map[string]eth.BlockID, len(s.confirmedTransactions)),
+	}
+	if cb.timeoutReason != nil {
+		jc.TimeoutReason = cb.timeoutReason.Error()
+	}
+	if err := cb.FullErr(); err != nil {
+		jc.FullReason = errors.Unwrap(err).Error()
+	}
+	for _, block := range cb.Blocks() {
+		jc.Blocks = append(jc.Blocks, eth.ToBlockID(block))
+	}
+	for _, frame := range cb.frames {
+		jc.Queued = append(jc.Queued, frame.id.frameNumber)
+	}
+	for id, inclusionBlock := range s.confirmedTransactions {
+		jc.Confirmed[id] = inclusionBlock
+	}
+	return jc
+}
+
+// restore rebuilds the channels and pending txs of the journaled state. The
+// given blocks must be the blocks of the journaled channels, in order.
+// The channel manager must be cleared before.
+func (s *channelManager) restore(state *journalState, blocks []*types.Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	frames := make(map[frameID]frameData)
+	for i := range state.Channels {
+		jc := &state.Channels[i]
+		if s.currentChannel != nil && !s.currentChannel.IsFull() {
+			return fmt.Errorf("channel %s follows the open channel %s", jc.ID, s.currentChannel.ID())
+		}
+		if len(blocks) < len(jc.Blocks) {
+			return fmt.Errorf("missing blocks of channel %s", jc.ID)
+		}
+		ch, chFrames, err := rebuildChannel(s.log, s.metr, s.cfg, s.rcfg, jc, blocks[:len(jc.Blocks)])
+		if err != nil {
+			return fmt.Errorf("rebuilding channel %s: %w", jc.ID, err)
+		}
+		blocks = blocks[len(jc.Blocks):]
+		for _, frame := range chFrames {
+			frames[frame.id] = frame
+		}
+		s.currentChannel = ch
+		s.channelQueue = append(s.channelQueue, ch)
+		if n := len(ch.channelBuilder.Blocks()); n > 0 {
+			s.tip = ch.channelBuilder.Blocks()[n-1].Hash()
+		}
+		s.log.Info("Restored channel", "id", ch.ID(), "blocks", len(jc.Blocks), "full", ch.IsFull(),
+			"num_frames", ch.TotalFrames(), "queued_frames", ch.PendingFrames())
+	}
+
+	for _, jtx := range state.Txs {
+		var tx txData
+		for _, id := range jtx.Frames {
+			frame, ok := frames[frameID{chID: id.Channel, frameNumber: id.Number}]
+			if !ok {
+				return fmt.Errorf("unknown frame %d of channel %s", id.Number, id.Channel)
+			}
+			tx.frames = append(tx.frames, frame)
+		}
+		if err := s.txPending(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildChannel rebuilds the journaled channel from its blocks, and checks that
+// the rebuilt frames match the journaled frames. It returns the channel, with
+// only the not yet submitted frames queued, and all frames of the channel.
+func rebuildChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *rollup.Config, jc *journalChannel, blocks []*types.Block) (*channel, []frameData, error) {
+	cfg = channelFrameConfig(cfg, rcfg, blocks)
+	cfg.CompressorConfig.CompressionAlgo = channelCompressionAlgo(cfg, rcfg, blocks)
+	cb, err := rebuildChannelBuilder(cfg, rcfg, jc.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if jc.Timeout != 0 {
+		cb.updateTimeout(jc.Timeout, journaledReason(jc.TimeoutReason))
+	}
+	for i, block := range blocks {
+		if block.Hash() != jc.Blocks[i].Hash {
+			return nil, nil, fmt.Errorf("block %v doesn't match journaled block %v", eth.ToBlockID(block), jc.Blocks[i])
+		}
+		if _, err := cb.AddBlock(block); err != nil {
+			return nil, nil, fmt.Errorf("adding block %v: %w", jc.Blocks[i], err)
+		}
+	}
+	if cb.IsFull() && !jc.Full {
+		return nil, nil, fmt.Errorf("rebuilt channel is full: %w", cb.FullErr())
+	} else if jc.Full && !cb.IsFull() {
+		// the channel got closed by a timeout or a block that didn't fit anymore
+		cb.setFullErr(journaledReason(jc.FullReason))
+	}
+	if err := cb.OutputFrames(); err != nil {
+		return nil, nil, err
+	}
+
+	if hashes := cb.FrameHashes(); !slices.Equal(hashes, jc.Frames) {
+		return nil, nil, fmt.Errorf("rebuilt %d frames don't match %d journaled frames", len(hashes), len(jc.Frames))
+	}
+	all := cb.frames
+	cb.frames = make([]frameData, 0, len(jc.Queued))
+	for _, fn := range jc.Queued {
+		if int(fn) >= len(all) {
+			return nil, nil, fmt.Errorf("unknown queued frame %d", fn)
+		}
+		cb.frames = append(cb.frames, all[fn])
+	}
+
+	ch := &channel{
+		log:                   log,
+		metr:                  metr,
+		cfg:                   cfg,
+		channelBuilder:        cb,
+		pendingTransactions:   make(map[string]txData),
+		confirmedTransactions: make(map[string]eth.BlockID),
+	}
+	for id, inclusionBlock := range jc.Confirmed {
+		ch.confirmedTransactions[id] = inclusionBlock
+		cb.FramePublished(inclusionBlock.Number)
+	}
+	return ch, all, nil
+}
+
+// journaledReason returns the channel full or timeout reason with the given
+// journaled error message. Unknown reasons are treated as explicit termination.
+func journaledReason(reason string) error {
+	for _, err := range []error{
+		derive.CompressorFullErr,
+		ErrMaxFrameIndex,
+		ErrMaxDurationReached,
+		ErrChannelTimeoutClose,
+		ErrSeqWindowClose,
+		ErrTerminated,
+	} {
+		if err.Error() == reason {
+			return err
+		}
+	}
+	return ErrTerminated
+}
@@ -0,0 +1,183 @@
+package batcher
+
+import (
+	"math/big"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func journalTestChannelConfig(batchType uint) ChannelConfig {
+	return ChannelConfig{
+		SeqWindowSize:  1000,
+		ChannelTimeout: 100,
+		MaxFrameSize:   1000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  1000,
+			TargetNumFrames:  3,
+			ApproxComprRatio: 1.0,
+		},
+		BatchType: batchType,
+	}
+}
+
+// newJournalTestManager returns a channel manager with a full channel, of which
+// the first tx got confirmed and the second tx is pending, and an open channel.
+func newJournalTestManager(t *testing.T, cfg ChannelConfig) (*channelManager, []txData) {
+	rng := rand.New(rand.NewSource(4321))
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear()
+	for i := 0; i < 10; i++ {
+		m.blocks = append(m.blocks, derivetest.RandomL2BlockWithChainId(rng, 1, defaultTestRollupConfig.L2ChainID))
+	}
+
+	var txs []txData
+	for len(txs) < 2 {
+		tx, err := m.TxData(eth.BlockID{Number: 1})
+		require.NoError(t, err)
+		txs = append(txs, tx)
+	}
+	require.True(t, m.channelQueue[0].IsFull())
+	m.TxConfirmed(txs[0].ID(), eth.BlockID{Number: 2})
+
+	// force the next block into an open channel
+	m.blocks = m.blocks[:1]
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{Number: 2}))
+	require.NoError(t, m.processBlocks())
+	require.NoError(t, m.outputFrames())
+	require.Len(t, m.channelQueue, 2)
+	require.False(t, m.currentChannel.IsFull())
+	return m, txs
+}
+
+func journaledBlocks(m *channelManager) []*types.Block {
+	var blocks []*types.Block
+	for _, ch := range m.channelQueue {
+		blocks = append(blocks, ch.channelBuilder.Blocks()...)
+	}
+	return blocks
+}
+
+func TestChannelJournalBatchType(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(t *testing.T, batchType uint)
+	}{
+		{"ChannelJournal_Restore", ChannelJournal_Restore},
+		{"ChannelJournal_ConfigChanged", ChannelJournal_ConfigChanged},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name+"_SingularBatch", func(t *testing.T) {
+			test.f(t, derive.SingularBatchType)
+		})
+		t.Run(test.name+"_SpanBatch", func(t *testing.T) {
+			test.f(t, derive.SpanBatchType)
+		})
+	}
+}
+
+// ChannelJournal_Restore ensures that the channels and pending txs of a channel
+// manager are restored from the journal.
+func ChannelJournal_Restore(t *testing.T, batchType uint) {
+	require := require.New(t)
+	cfg := journalTestChannelConfig(batchType)
+	m, txs := newJournalTestManager(t, cfg)
+
+	file := filepath.Join(t.TempDir(), "journal.json")
+	j := newChannelJournal(file)
+	published := types.NewTx(&types.DynamicFeeTx{Nonce: 5})
+	require.NoError(j.TxPublishing(m, txs[1].ID(), published))
+
+	state, err := newChannelJournal(file).Load()
+	require.NoError(err)
+	require.Len(state.Txs, 1)
+	require.Equal(txs[1].ID(), state.Txs[0].ID())
+	require.Equal(uint64(5), *state.Txs[0].Nonce)
+	require.Equal([]common.Hash{published.Hash()}, state.Txs[0].Hashes)
+
+	r := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	r.Clear()
+	require.NoError(r.restore(state, journaledBlocks(m)))
+
+	require.Len(r.channelQueue, len(m.channelQueue))
+	for i, ch := range m.channelQueue {
+		rch := r.channelQueue[i]
+		require.Equal(ch.ID(), rch.ID())
+		require.Equal(ch.IsFull(), rch.IsFull())
+		require.Equal(append([]frameData{}, ch.channelBuilder.frames...), append([]frameData{}, rch.channelBuilder.frames...))
+		require.Equal(ch.channelBuilder.FrameHashes(), rch.channelBuilder.FrameHashes())
+		require.Equal(ch.pendingTransactions, rch.pendingTransactions)
+		require.Equal(ch.confirmedTransactions, rch.confirmedTransactions)
+	}
+	require.Equal(r.channelQueue[1], r.currentChannel)
+	blocks := journaledBlocks(m)
+	require.Equal(blocks[len(blocks)-1].Hash(), r.tip)
+	require.Len(r.txChannels, 1)
+
+	// The restored channels continue where the journaled channels stopped.
+	r.TxConfirmed(txs[1].ID(), eth.BlockID{Number: 3})
+	m.TxConfirmed(txs[1].ID(), eth.BlockID{Number: 3})
+	require.Len(r.channelQueue, len(m.channelQueue))
+	block := derivetest.RandomL2BlockWithChainId(rand.New(rand.NewSource(1)), 1, defaultTestRollupConfig.L2ChainID)
+	m.blocks = append(m.blocks, block)
+	r.blocks = append(r.blocks, block)
+	require.NoError(m.Close())
+	require.NoError(r.Close())
+	for {
+		mtx, merr := m.TxData(eth.BlockID{Number: 3})
+		rtx, rerr := r.TxData(eth.BlockID{Number: 3})
+		require.Equal(merr, rerr)
+		if merr != nil {
+			break
+		}
+		require.Equal(mtx.Bytes(), rtx.Bytes())
+	}
+}
+
+// ChannelJournal_ConfigChanged ensures that channels aren't restored if the
+// rebuilt frames don't match the journaled frames.
+func ChannelJournal_ConfigChanged(t *testing.T, batchType uint) {
+	cfg := journalTestChannelConfig(batchType)
+	m, _ := newJournalTestManager(t, cfg)
+	state := m.journalState()
+
+	cfg.CompressorConfig.CompressionAlgo = derive.Brotli
+	rcfg := defaultTestRollupConfig
+	rcfg.ChannelCompressionTime = new(uint64)
+	r := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &rcfg)
+	r.Clear()
+	require.ErrorContains(t, r.restore(&state, journaledBlocks(m)), "don't match")
+}
+
+func TestChannelJournal_RestoreReorgedBlock(t *testing.T) {
+	cfg := journalTestChannelConfig(derive.SingularBatchType)
+	m, _ := newJournalTestManager(t, cfg)
+	state := m.journalState()
+
+	blocks := journaledBlocks(m)
+	blocks[1] = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	r := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	r.Clear()
+	require.ErrorContains(t, r.restore(&state, blocks), "doesn't match journaled block")
+}
+
+func TestChannelJournal_LoadEmpty(t *testing.T) {
+	j := newChannelJournal(filepath.Join(t.TempDir(), "journal.json"))
+	state, err := j.Load()
+	require.NoError(t, err)
+	require.Empty(t, state.Channels)
+	require.Empty(t, state.Txs)
+}
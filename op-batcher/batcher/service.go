@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net"
 	_ "net/http/pprof"
 	"strconv"
@@ -13,10 +14,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -31,6 +36,17 @@ type BatcherConfig struct {
 	NetworkTimeout         time.Duration
 	PollInterval           time.Duration
 	MaxPendingTransactions uint64
+
+	// ChannelJournal is the file to journal the channel state to, so that channels
+	// are resumed after a restart. Journaling is disabled if empty.
+	ChannelJournal string
+
+	// L1BaseFeeThreshold is the L1 base fee (in wei) above which pending channel
+	// data is held back. The submission policy is disabled if nil.
+	L1BaseFeeThreshold *big.Int
+	// MaxChannelDurationExtension is the number of L1 blocks by which the max
+	// channel duration is extended while the L1 base fee is above the threshold.
+	MaxChannelDurationExtension uint64
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -50,6 +66,9 @@ type BatcherService struct {
 	// Channel builder parameters
 	ChannelConfig ChannelConfig
 
+	// AltDA is the DA server client in alt-DA mode, nil if alt-DA is disabled
+	AltDA *altda.DAClient
+
 	driver *BatchSubmitter
 
 	Version string
@@ -86,6 +105,11 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.PollInterval = cfg.PollInterval
 	bs.MaxPendingTransactions = cfg.MaxPendingTransactions
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
+	bs.ChannelJournal = cfg.ChannelJournal
+	if cfg.L1BaseFeeThreshold > 0 {
+		bs.L1BaseFeeThreshold, _ = new(big.Float).Mul(big.NewFloat(cfg.L1BaseFeeThreshold), big.NewFloat(params.GWei)).Int(nil)
+	}
+	bs.MaxChannelDurationExtension = cfg.MaxChannelDurationExtension
 
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
@@ -96,6 +120,9 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	if err := bs.initChannelConfig(cfg); err != nil {
 		return fmt.Errorf("failed to init channel config: %w", err)
 	}
+	if err := bs.initAltDA(cfg); err != nil {
+		return fmt.Errorf("failed to init alt-DA: %w", err)
+	}
 	if err := bs.initTxManager(cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
@@ -174,13 +201,48 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		MaxFrameSize:       cfg.MaxL1TxSize - 1, // subtract 1 byte for version
 		CompressorConfig:   cfg.CompressorConfig.Config(),
 		BatchType:          cfg.BatchType,
+		MultiFrameTxs:      cfg.MultiFrameTxs,
+	}
+
+	switch cfg.DataAvailabilityType {
+	case flags.BlobsType:
+		if bs.RollupConfig.BlobsTime == nil {
+			return errors.New("cannot use blobs data availability type: blobs are not scheduled in the rollup config")
+		}
+		// Frames are only sized to fill a blob by channels that start after the blobs time.
+		bs.ChannelConfig.UseBlobs = true
+	case flags.CalldataType:
+	default:
+		return fmt.Errorf("unknown data availability type: %v", cfg.DataAvailabilityType)
+	}
+
+	if algo := bs.ChannelConfig.CompressorConfig.CompressionAlgo; algo != "" && algo != derive.Zlib && bs.RollupConfig.ChannelCompressionTime == nil {
+		return fmt.Errorf("cannot use %s channel compression: versioned channels are not scheduled in the rollup config", algo)
 	}
+
 	if err := bs.ChannelConfig.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
 	}
 	return nil
 }
 
+func (bs *BatcherService) initAltDA(cfg *CLIConfig) error {
+	if !cfg.AltDA.Enabled {
+		return nil
+	}
+	// The service can be created without checking the CLI config first.
+	if cfg.DataAvailabilityType == flags.BlobsType {
+		return ErrAltDAWithBlobs
+	}
+	if bs.RollupConfig.AltDATime == nil {
+		return errors.New("cannot use alt-DA mode: alt-DA is not scheduled in the rollup config")
+	}
+	bs.AltDA = cfg.AltDA.NewDAClient()
+	bs.Log.Info("Storing frame data on DA server, posting commitments only once alt-DA is active",
+		"url", cfg.AltDA.DAServerURL, "alt_da_time", *bs.RollupConfig.AltDATime)
+	return nil
+}
+
 func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
 	txManager, err := txmgr.NewSimpleTxManager("batcher", bs.Log, bs.Metrics, cfg.TxMgrConfig)
 	if err != nil {
@@ -224,7 +286,7 @@ func (bs *BatcherService) initMetricsServer(cfg *CLIConfig) error {
 }
 
 func (bs *BatcherService) initDriver() {
-	bs.driver = NewBatchSubmitter(DriverSetup{
+	setup := DriverSetup{
 		Log:           bs.Log,
 		Metr:          bs.Metrics,
 		RollupConfig:  bs.RollupConfig,
@@ -234,7 +296,12 @@ func (bs *BatcherService) initDriver() {
 		L2Client:      bs.L2Client,
 		RollupClient:  bs.RollupNode,
 		ChannelConfig: bs.ChannelConfig,
-	})
+	}
+	// don't pass a typed nil pointer if alt-DA is disabled
+	if bs.AltDA != nil {
+		setup.AltDA = bs.AltDA
+	}
+	bs.driver = NewBatchSubmitter(setup)
 }
 
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
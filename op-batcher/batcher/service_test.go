@@ -0,0 +1,32 @@
+package batcher
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestBatcherService_InitAltDA(t *testing.T) {
+	rcfg := defaultTestRollupConfig
+	rcfg.AltDATime = new(uint64)
+	rcfg.BlobsTime = new(uint64)
+	newConfig := func(daType flags.DataAvailabilityType) *CLIConfig {
+		return &CLIConfig{
+			DataAvailabilityType: daType,
+			AltDA:                altda.CLIConfig{Enabled: true, DAServerURL: "http://127.0.0.1:0"},
+		}
+	}
+
+	bs := &BatcherService{Log: testlog.Logger(t, log.LvlCrit), RollupConfig: &rcfg}
+	require.NoError(t, bs.initAltDA(newConfig(flags.CalldataType)))
+	require.NotNil(t, bs.AltDA)
+
+	bs = &BatcherService{Log: testlog.Logger(t, log.LvlCrit), RollupConfig: &rcfg}
+	require.ErrorIs(t, bs.initAltDA(newConfig(flags.BlobsType)), ErrAltDAWithBlobs)
+	require.Nil(t, bs.AltDA)
+}
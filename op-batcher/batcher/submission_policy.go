@@ -0,0 +1,50 @@
+package batcher
+
+import (
+	"math/big"
+)
+
+// submissionDecision is the decision of the submission policy at an L1 head.
+type submissionDecision struct {
+	// Hold indicates that pending channel data should be held back. Channels
+	// close to their channel timeout or sequencing window are still submitted.
+	Hold bool
+	// DurationExtension is the number of L1 blocks by which the max channel
+	// duration of the current channel is extended.
+	DurationExtension uint64
+}
+
+// submissionPolicy decides whether channel data should be submitted, depending
+// on the L1 base fee. While the base fee is above the threshold, channel data is
+// held back and channels are kept open for longer to improve their compression.
+type submissionPolicy struct {
+	// baseFeeThreshold is the L1 base fee (in wei) above which channel data is
+	// held back. The policy is disabled if nil or zero.
+	baseFeeThreshold *big.Int
+	// maxDurationExtension is the number of L1 blocks by which the max channel
+	// duration is extended while the base fee is above the threshold.
+	maxDurationExtension uint64
+}
+
+func newSubmissionPolicy(baseFeeThreshold *big.Int, maxDurationExtension uint64) submissionPolicy {
+	return submissionPolicy{
+		baseFeeThreshold:     baseFeeThreshold,
+		maxDurationExtension: maxDurationExtension,
+	}
+}
+
+// Enabled returns whether a base fee threshold is configured.
+func (p submissionPolicy) Enabled() bool {
+	return p.baseFeeThreshold != nil && p.baseFeeThreshold.Sign() > 0
+}
+
+// Decide returns the submission decision for an L1 head with the given base fee.
+func (p submissionPolicy) Decide(baseFee *big.Int) submissionDecision {
+	if !p.Enabled() || baseFee == nil || baseFee.Cmp(p.baseFeeThreshold) <= 0 {
+		return submissionDecision{}
+	}
+	return submissionDecision{
+		Hold:              true,
+		DurationExtension: p.maxDurationExtension,
+	}
+}
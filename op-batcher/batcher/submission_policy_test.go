@@ -0,0 +1,159 @@
+package batcher
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestSubmissionPolicy_Decide(t *testing.T) {
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.GWei)) }
+
+	disabled := newSubmissionPolicy(nil, 5)
+	require.False(t, disabled.Enabled())
+	require.Equal(t, submissionDecision{}, disabled.Decide(gwei(1000)))
+
+	p := newSubmissionPolicy(gwei(50), 5)
+	require.True(t, p.Enabled())
+	require.Equal(t, submissionDecision{}, p.Decide(gwei(10)))
+	require.Equal(t, submissionDecision{}, p.Decide(gwei(50)))
+	require.Equal(t, submissionDecision{Hold: true, DurationExtension: 5}, p.Decide(gwei(51)))
+	require.Equal(t, submissionDecision{}, p.Decide(nil))
+}
+
+// TestChannelManager_SubmissionPolicySimulatedFees simulates the L1 base fee of
+// consecutive L1 blocks and asserts at which L1 blocks batch txs are submitted.
+func TestChannelManager_SubmissionPolicySimulatedFees(t *testing.T) {
+	const (
+		startL1 = 100 // L1 origin of the mini L2 blocks
+		high    = 100 // gwei
+		low     = 10  // gwei
+	)
+	fees := func(fee uint64, n int) []uint64 {
+		fs := make([]uint64, n)
+		for i := range fs {
+			fs[i] = fee
+		}
+		return fs
+	}
+
+	tests := []struct {
+		name string
+		// L1 base fee (in gwei) of the L1 blocks, starting at L1 block startL1
+		fees []uint64
+		// L1 blocks at which batch txs are submitted
+		submissions []uint64
+		// multiFrameTxs packs the frames of multiple channels into a tx
+		multiFrameTxs bool
+		// maxFrameSize overrides the max frame size of 1000 bytes, to fit more frames into a tx
+		maxFrameSize uint64
+		// advanceOrigin sets the L1 origin of each L2 block to the current L1 block,
+		// instead of startL1, so that channels reach the end of their sequencing
+		// window one after the other
+		advanceOrigin bool
+	}{
+		{
+			// channels are closed after the max channel duration of 3 blocks
+			name:        "low-fees",
+			fees:        fees(low, 12),
+			submissions: []uint64{103, 107, 111},
+		},
+		{
+			// the first channel is closed at 107 because its duration is extended by 4 blocks
+			// while fees are high, and held until fees drop at 110
+			name:        "fee-spike",
+			fees:        append(fees(high, 10), fees(low, 5)...),
+			submissions: []uint64{110, 111},
+		},
+		{
+			// all 4 held channels are submitted at 125, when the sequencing window
+			// of 30 blocks minus the safety margin of 5 blocks is reached
+			name:        "high-fees",
+			fees:        fees(high, 26),
+			submissions: []uint64{125, 125, 125, 125},
+		},
+		{
+			// the frames of the 2 held channels that have frames fit into a single tx,
+			// the other 2 channels are closed and submitted in a second tx at 125
+			name:          "high-fees-multi-frame",
+			fees:          fees(high, 26),
+			submissions:   []uint64{125, 125},
+			multiFrameTxs: true,
+		},
+		{
+			// each held channel is submitted when its own sequencing window is about
+			// to close, every 8 blocks, without the frames of the later held channels
+			name:          "high-fees-multi-frame-advancing-origin",
+			fees:          fees(high, 40),
+			submissions:   []uint64{125, 133},
+			multiFrameTxs: true,
+			maxFrameSize:  5000,
+			advanceOrigin: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ChannelConfig{
+				SeqWindowSize:      30,
+				ChannelTimeout:     20,
+				MaxChannelDuration: 3,
+				SubSafetyMargin:    5,
+				MaxFrameSize:       1000,
+				CompressorConfig: compressor.Config{
+					TargetFrameSize:  1000,
+					TargetNumFrames:  100,
+					ApproxComprRatio: 1.0,
+				},
+				BatchType:     derive.SingularBatchType,
+				MultiFrameTxs: tt.multiFrameTxs,
+			}
+			if tt.maxFrameSize != 0 {
+				cfg.MaxFrameSize = tt.maxFrameSize
+			}
+			policy := newSubmissionPolicy(new(big.Int).Mul(big.NewInt(50), big.NewInt(params.GWei)), 4)
+			m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+			m.Clear()
+
+			var (
+				submissions []uint64
+				parent      common.Hash
+			)
+			for i, fee := range tt.fees {
+				l1Head := eth.BlockID{Number: startL1 + uint64(i)}
+				origin := uint64(startL1)
+				if tt.advanceOrigin {
+					origin = l1Head.Number
+				}
+				block := newMiniL2BlockWithNumberParentOrigin(0, big.NewInt(int64(i)), parent, origin)
+				parent = block.Hash()
+				require.NoError(t, m.AddL2Block(block))
+
+				baseFee := new(big.Int).Mul(new(big.Int).SetUint64(fee), big.NewInt(params.GWei))
+				m.SetSubmissionDecision(policy.Decide(baseFee))
+				for {
+					tx, err := m.TxData(l1Head)
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					require.NoError(t, err)
+					submissions = append(submissions, l1Head.Number)
+					m.TxConfirmed(tx.ID(), l1Head)
+				}
+			}
+			require.Equal(t, tt.submissions, submissions)
+		})
+	}
+}
@@ -2,57 +2,86 @@ package batcher
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
 
 // txData represents the data for a single transaction.
 //
-// Note: The batcher currently sends exactly one frame per transaction. This
-// might change in the future to allow for multiple frames from possibly
-// different channels.
+// The frames of a transaction may belong to different channels. They are
+// submitted in order, so frames of the same channel stay in order.
 type txData struct {
-	frame frameData
+	frames []frameData
 }
 
-// ID returns the id for this transaction data. It can be used as a map key.
+func singleFrameTxData(frame frameData) txData {
+	return txData{frames: []frameData{frame}}
+}
+
+// ID returns the id for this transaction data. Its String() can be used as a map key.
 func (td *txData) ID() txID {
-	return td.frame.id
+	id := make(txID, 0, len(td.frames))
+	for _, f := range td.frames {
+		id = append(id, f.id)
+	}
+	return id
 }
 
 // Bytes returns the transaction data. It's a version byte (0) followed by the
 // concatenated frames for this transaction.
 func (td *txData) Bytes() []byte {
-	return append([]byte{derive.DerivationVersion0}, td.frame.data...)
+	data := make([]byte, 1, td.Len())
+	data[0] = derive.DerivationVersion0
+	for _, f := range td.frames {
+		data = append(data, f.data...)
+	}
+	return data
 }
 
 func (td *txData) Len() int {
-	return 1 + len(td.frame.data)
+	l := 1
+	for _, f := range td.frames {
+		l += len(f.data)
+	}
+	return l
 }
 
-// Frame returns the single frame of this tx data.
-//
-// Note: when the batcher is changed to possibly send multiple frames per tx,
-// this should be changed to a func Frames() []frameData.
-func (td *txData) Frame() frameData {
-	return td.frame
+// Frames returns the frames of this tx data, in submission order.
+func (td *txData) Frames() []frameData {
+	return td.frames
 }
 
 // txID is an opaque identifier for a transaction.
 // It's internal fields should not be inspected after creation & are subject to change.
-// This ID must be trivially comparable & work as a map key.
-//
-// Note: transactions currently only hold a single frame, so it can be
-// identified by the frame. This needs to be changed once the batcher is changed
-// to send multiple frames per tx.
-type txID = frameID
+// The ID is not comparable, its String() must be used as map key.
+type txID []frameID
 
 func (id txID) String() string {
-	return fmt.Sprintf("%s:%d", id.chID.String(), id.frameNumber)
+	return id.string(func(chID derive.ChannelID) string { return chID.String() })
 }
 
 // TerminalString implements log.TerminalStringer, formatting a string for console
 // output during logging.
 func (id txID) TerminalString() string {
-	return fmt.Sprintf("%s:%d", id.chID.TerminalString(), id.frameNumber)
+	return id.string(func(chID derive.ChannelID) string { return chID.TerminalString() })
+}
+
+func (id txID) string(chIDStringer func(derive.ChannelID) string) string {
+	var (
+		sb      strings.Builder
+		curChID derive.ChannelID
+	)
+	for _, f := range id {
+		if f.chID == curChID && sb.Len() > 0 {
+			sb.WriteString(fmt.Sprintf("+%d", f.frameNumber))
+		} else {
+			if sb.Len() > 0 {
+				sb.WriteByte('|')
+			}
+			curChID = f.chID
+			sb.WriteString(fmt.Sprintf("%s:%d", chIDStringer(f.chID), f.frameNumber))
+		}
+	}
+	return sb.String()
 }
@@ -1,8 +1,10 @@
 package compressor
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/urfave/cli/v2"
 )
@@ -12,6 +14,7 @@ const (
 	TargetNumFramesFlagName     = "target-num-frames"
 	ApproxComprRatioFlagName    = "approx-compr-ratio"
 	KindFlagName                = "compressor"
+	CompressionAlgoFlagName     = "compression-algo"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -40,6 +43,13 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSOR"),
 			Value:   RatioKind,
 		},
+		&cli.StringFlag{
+			Name: CompressionAlgoFlagName,
+			Usage: "The compression algorithm of channels. Valid options: " + compressionAlgoOptions() +
+				". Algorithms other than zlib require the channel compression fork, channels fall back to zlib until it activates.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSION_ALGO"),
+			Value:   derive.Zlib.String(),
+		},
 	}
 }
 
@@ -57,6 +67,16 @@ type CLIConfig struct {
 	ApproxComprRatio float64
 	// Type of compressor to use. Must be one of KindKeys.
 	Kind string
+	// CompressionAlgo of channels. Must be one of derive.CompressionAlgos,
+	// or unset to compress channels with zlib.
+	CompressionAlgo derive.CompressionAlgo
+}
+
+func (c *CLIConfig) Check() error {
+	if c.CompressionAlgo != "" && !derive.ValidCompressionAlgo(c.CompressionAlgo) {
+		return fmt.Errorf("invalid compression algo: %q", c.CompressionAlgo)
+	}
+	return nil
 }
 
 func (c *CLIConfig) Config() Config {
@@ -65,6 +85,7 @@ func (c *CLIConfig) Config() Config {
 		TargetNumFrames:  c.TargetNumFrames,
 		ApproxComprRatio: c.ApproxComprRatio,
 		Kind:             c.Kind,
+		CompressionAlgo:  c.CompressionAlgo,
 	}
 }
 
@@ -74,5 +95,14 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		TargetL1TxSizeBytes: ctx.Uint64(TargetL1TxSizeBytesFlagName),
 		TargetNumFrames:     ctx.Int(TargetNumFramesFlagName),
 		ApproxComprRatio:    ctx.Float64(ApproxComprRatioFlagName),
+		CompressionAlgo:     derive.CompressionAlgo(ctx.String(CompressionAlgoFlagName)),
+	}
+}
+
+func compressionAlgoOptions() string {
+	opts := make([]string, 0, len(derive.CompressionAlgos))
+	for _, algo := range derive.CompressionAlgos {
+		opts = append(opts, algo.String())
 	}
+	return strings.Join(opts, ", ")
 }
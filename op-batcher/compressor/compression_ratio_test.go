@@ -0,0 +1,116 @@
+package compressor_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	dtest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+)
+
+// blocksDir is a directory of recorded L2 blocks, one RLP encoded block per file,
+// as returned by the debug_getRawBlock RPC of the L2 execution engine.
+var blocksDir = flag.String("compressor.blocks", "", "directory of recorded raw L2 blocks to benchmark the compression ratio on")
+
+// loadBatches returns the encoded batches of the recorded L2 blocks, or of random
+// L2 blocks if no recorded blocks are given.
+func loadBatches(b *testing.B) [][]byte {
+	var blocks []*types.Block
+	if *blocksDir != "" {
+		files, err := filepath.Glob(filepath.Join(*blocksDir, "*"))
+		require.NoError(b, err)
+		sort.Strings(files)
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			require.NoError(b, err)
+			var block types.Block
+			require.NoError(b, rlp.DecodeBytes(data, &block), "invalid block %s", file)
+			blocks = append(blocks, &block)
+		}
+		require.NotEmpty(b, blocks, "no blocks in %s", *blocksDir)
+	} else {
+		rng := rand.New(rand.NewSource(1234))
+		for i := 0; i < 100; i++ {
+			blocks = append(blocks, dtest.RandomL2BlockWithChainId(rng, 20, big.NewInt(10)))
+		}
+	}
+
+	batches := make([][]byte, 0, len(blocks))
+	for _, block := range blocks {
+		batch, _, err := derive.BlockToSingularBatch(block)
+		require.NoError(b, err)
+		var buf bytes.Buffer
+		require.NoError(b, rlp.Encode(&buf, derive.NewBatchData(batch)))
+		batches = append(batches, buf.Bytes())
+	}
+	return batches
+}
+
+// BenchmarkCompressionRatio compresses the batches of L2 blocks into channels with every
+// compression algorithm, and reports the realized compression ratio of each.
+//
+// Pass -compressor.blocks to benchmark on recorded blocks of a real chain.
+func BenchmarkCompressionRatio(b *testing.B) {
+	batches := loadBatches(b)
+	var inputBytes int
+	for _, batch := range batches {
+		inputBytes += len(batch)
+	}
+
+	for _, algo := range derive.CompressionAlgos {
+		for _, kind := range []string{compressor.RatioKind, compressor.ShadowKind} {
+			algo, kind := algo, kind
+			b.Run(algo.String()+"/"+kind, func(b *testing.B) {
+				b.SetBytes(int64(inputBytes))
+				b.ReportAllocs()
+				var outputBytes int
+				for i := 0; i < b.N; i++ {
+					outputBytes = compressBatches(b, compressor.Config{
+						TargetFrameSize:  120_000,
+						TargetNumFrames:  1,
+						ApproxComprRatio: 0.4,
+						Kind:             kind,
+						CompressionAlgo:  algo,
+					}, batches)
+				}
+				b.ReportMetric(float64(outputBytes)/float64(inputBytes), "ratio")
+			})
+		}
+	}
+}
+
+// compressBatches writes the batches into as many channels as needed, and returns the total
+// size of the channels.
+func compressBatches(b *testing.B, cfg compressor.Config, batches [][]byte) int {
+	c, err := cfg.NewCompressor()
+	require.NoError(b, err)
+	var size int
+	closeChannel := func() {
+		require.NoError(b, c.Close())
+		size += c.Len()
+		c.Reset()
+	}
+	for _, batch := range batches {
+		if _, err := c.Write(batch); errors.Is(err, derive.CompressorFullErr) {
+			closeChannel()
+			_, err = c.Write(batch)
+			require.NoError(b, err)
+		} else {
+			require.NoError(b, err)
+		}
+	}
+	closeChannel()
+	return size
+}
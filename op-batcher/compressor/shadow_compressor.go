@@ -1,21 +1,18 @@
 package compressor
 
 import (
-	"bytes"
-	"compress/zlib"
-
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
 
 type ShadowCompressor struct {
 	config Config
 
-	buf      bytes.Buffer
-	compress *zlib.Writer
-
-	shadowBuf      bytes.Buffer
-	shadowCompress *zlib.Writer
+	compress       derive.ChannelCompressor
+	shadowCompress derive.ChannelCompressor
 
+	// written is set once data was written to the compressor, which can't be told
+	// from its length, as versioned channels start with the channel version byte.
+	written bool
 	fullErr error
 }
 
@@ -32,11 +29,11 @@ func NewShadowCompressor(config Config) (derive.Compressor, error) {
 	}
 
 	var err error
-	c.compress, err = zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	c.compress, err = derive.NewChannelCompressor(config.CompressionAlgo)
 	if err != nil {
 		return nil, err
 	}
-	c.shadowCompress, err = zlib.NewWriterLevel(&c.shadowBuf, zlib.BestCompression)
+	c.shadowCompress, err = derive.NewChannelCompressor(config.CompressionAlgo)
 	if err != nil {
 		return nil, err
 	}
@@ -53,14 +50,15 @@ func (t *ShadowCompressor) Write(p []byte) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	if uint64(t.shadowBuf.Len()) > t.config.TargetFrameSize*uint64(t.config.TargetNumFrames) {
+	if uint64(t.shadowCompress.Len()) > t.config.TargetFrameSize*uint64(t.config.TargetNumFrames) {
 		t.fullErr = derive.CompressorFullErr
-		if t.Len() > 0 {
+		if t.written {
 			// only return an error if we've already written data to this compressor before
 			// (otherwise individual blocks over the target would never be written)
 			return 0, t.fullErr
 		}
 	}
+	t.written = true
 	return t.compress.Write(p)
 }
 
@@ -69,19 +67,18 @@ func (t *ShadowCompressor) Close() error {
 }
 
 func (t *ShadowCompressor) Read(p []byte) (int, error) {
-	return t.buf.Read(p)
+	return t.compress.Read(p)
 }
 
 func (t *ShadowCompressor) Reset() {
-	t.buf.Reset()
-	t.compress.Reset(&t.buf)
-	t.shadowBuf.Reset()
-	t.shadowCompress.Reset(&t.shadowBuf)
+	t.compress.Reset()
+	t.shadowCompress.Reset()
+	t.written = false
 	t.fullErr = nil
 }
 
 func (t *ShadowCompressor) Len() int {
-	return t.buf.Len()
+	return t.compress.Len()
 }
 
 func (t *ShadowCompressor) Flush() error {
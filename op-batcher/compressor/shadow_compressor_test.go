@@ -2,7 +2,6 @@ package compressor_test
 
 import (
 	"bytes"
-	"compress/zlib"
 	"crypto/rand"
 	"io"
 	"testing"
@@ -58,55 +57,58 @@ func TestShadowCompressor(t *testing.T) {
 		errs:            []error{nil, nil, derive.CompressorFullErr},
 		fullErr:         derive.CompressorFullErr,
 	}}
-	for _, test := range tests {
-		test := test
-		t.Run(test.name, func(t *testing.T) {
-			t.Parallel()
-			require.Equal(t, len(test.errs), len(test.data), "invalid test case: len(data) != len(errs)")
+	for _, algo := range derive.CompressionAlgos {
+		for _, test := range tests {
+			algo, test := algo, test
+			t.Run(algo.String()+"/"+test.name, func(t *testing.T) {
+				t.Parallel()
+				require.Equal(t, len(test.errs), len(test.data), "invalid test case: len(data) != len(errs)")
 
-			sc, err := compressor.NewShadowCompressor(compressor.Config{
-				TargetFrameSize: test.targetFrameSize,
-				TargetNumFrames: test.targetNumFrames,
-			})
-			require.NoError(t, err)
+				sc, err := compressor.NewShadowCompressor(compressor.Config{
+					TargetFrameSize: test.targetFrameSize,
+					TargetNumFrames: test.targetNumFrames,
+					CompressionAlgo: algo,
+				})
+				require.NoError(t, err)
 
-			for i, d := range test.data {
-				_, err = sc.Write(d)
-				if test.errs[i] != nil {
-					require.ErrorIs(t, err, test.errs[i])
-					require.Equal(t, i, len(test.data)-1)
-				} else {
-					require.NoError(t, err)
+				for i, d := range test.data {
+					_, err = sc.Write(d)
+					if test.errs[i] != nil {
+						require.ErrorIs(t, err, test.errs[i])
+						require.Equal(t, i, len(test.data)-1)
+					} else {
+						require.NoError(t, err)
+					}
 				}
-			}
 
-			if test.fullErr != nil {
-				require.ErrorIs(t, sc.FullErr(), test.fullErr)
-			} else {
-				require.NoError(t, sc.FullErr())
-			}
+				if test.fullErr != nil {
+					require.ErrorIs(t, sc.FullErr(), test.fullErr)
+				} else {
+					require.NoError(t, sc.FullErr())
+				}
 
-			err = sc.Close()
-			require.NoError(t, err)
+				err = sc.Close()
+				require.NoError(t, err)
 
-			buf, err := io.ReadAll(sc)
-			require.NoError(t, err)
+				buf, err := io.ReadAll(sc)
+				require.NoError(t, err)
 
-			r, err := zlib.NewReader(bytes.NewBuffer(buf))
-			require.NoError(t, err)
+				r, err := derive.NewChannelDecompressor(bytes.NewBuffer(buf), true)
+				require.NoError(t, err)
 
-			uncompressed, err := io.ReadAll(r)
-			require.NoError(t, err)
+				uncompressed, err := io.ReadAll(r)
+				require.NoError(t, err)
 
-			concat := make([]byte, 0)
-			for i, d := range test.data {
-				if test.errs[i] != nil {
-					break
+				concat := make([]byte, 0)
+				for i, d := range test.data {
+					if test.errs[i] != nil {
+						break
+					}
+					concat = append(concat, d...)
 				}
-				concat = append(concat, d...)
-			}
 
-			require.Equal(t, concat, uncompressed)
-		})
+				require.Equal(t, concat, uncompressed)
+			})
+		}
 	}
 }
@@ -6,8 +6,10 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -71,17 +73,51 @@ var (
 		Value:   120_000,
 		EnvVars: prefixEnvVars("MAX_L1_TX_SIZE_BYTES"),
 	}
+	MultiFrameTxsFlag = &cli.BoolFlag{
+		Name: "multi-frame-txs",
+		Usage: "Pack multiple frames, possibly of different channels, into a single batch tx " +
+			"up to the max-l1-tx-size-bytes, instead of sending a tx per frame.",
+		EnvVars: prefixEnvVars("MULTI_FRAME_TXS"),
+	}
 	StoppedFlag = &cli.BoolFlag{
 		Name:    "stopped",
 		Usage:   "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
 		EnvVars: prefixEnvVars("STOPPED"),
 	}
+	ChannelJournalFlag = &cli.StringFlag{
+		Name: "channel-journal",
+		Usage: "File to journal the open channels and in-flight batch txs to, so that a restarted batcher " +
+			"resumes them instead of resubmitting the blocks since the safe head. Disabled if empty.",
+		EnvVars: prefixEnvVars("CHANNEL_JOURNAL"),
+	}
+	L1BaseFeeThresholdFlag = &cli.Float64Flag{
+		Name: "l1-base-fee-threshold",
+		Usage: "The L1 base fee (in gwei) above which pending channel data is held back, unless channels get " +
+			"close to their channel timeout or sequencing window. 0 to always submit.",
+		EnvVars: prefixEnvVars("L1_BASE_FEE_THRESHOLD"),
+	}
+	MaxChannelDurationExtensionFlag = &cli.Uint64Flag{
+		Name: "max-channel-duration-extension",
+		Usage: "The number of L1-blocks by which the max channel duration is extended while the L1 base fee " +
+			"is above the l1-base-fee-threshold, to improve compression.",
+		EnvVars: prefixEnvVars("MAX_CHANNEL_DURATION_EXTENSION"),
+	}
 	BatchTypeFlag = &cli.UintFlag{
 		Name:    "batch-type",
 		Usage:   "The batch type. 0 for SingularBatch and 1 for SpanBatch.",
 		Value:   0,
 		EnvVars: prefixEnvVars("BATCH_TYPE"),
 	}
+	DataAvailabilityTypeFlag = &cli.GenericFlag{
+		Name: "data-availability-type",
+		Usage: "The data availability type to use for submitting batches to the L1. Valid options: " +
+			openum.EnumString(DataAvailabilityTypes),
+		Value: func() *DataAvailabilityType {
+			out := CalldataType
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("DATA_AVAILABILITY_TYPE"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -98,9 +134,14 @@ var optionalFlags = []cli.Flag{
 	MaxPendingTransactionsFlag,
 	MaxChannelDurationFlag,
 	MaxL1TxSizeBytesFlag,
+	MultiFrameTxsFlag,
 	StoppedFlag,
+	ChannelJournalFlag,
+	L1BaseFeeThresholdFlag,
+	MaxChannelDurationExtensionFlag,
 	SequencerHDPathFlag,
 	BatchTypeFlag,
+	DataAvailabilityTypeFlag,
 }
 
 func init() {
@@ -110,6 +151,7 @@ func init() {
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, compressor.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
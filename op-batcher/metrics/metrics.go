@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -46,6 +48,9 @@ type Metricer interface {
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordL1BaseFee(baseFee *big.Int)
+	RecordSubmissionDecision(decision string)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -79,6 +84,10 @@ type Metrics struct {
 	channelOutputBytesTotal prometheus.Counter
 
 	batcherTxEvs opmetrics.EventVec
+
+	l1BaseFee prometheus.Gauge
+	// label by submit, hold, forced
+	submissionDecisionEvs opmetrics.EventVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -183,6 +192,13 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		l1BaseFee: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "l1_base_fee_gwei",
+			Help:      "L1 base fee in gwei, as seen by the submission policy.",
+		}),
+		submissionDecisionEvs: opmetrics.NewEventVec(factory, ns, "", "submission_decision", "Submission policy decision", []string{"decision"}),
 	}
 }
 
@@ -221,6 +237,10 @@ const (
 	TxStageSubmitted = "submitted"
 	TxStageSuccess   = "success"
 	TxStageFailed    = "failed"
+
+	SubmissionSubmit = "submit"
+	SubmissionHold   = "hold"
+	SubmissionForced = "forced"
 )
 
 func (m *Metrics) RecordLatestL1Block(l1ref eth.L1BlockRef) {
@@ -304,6 +324,17 @@ func (m *Metrics) RecordBatchTxFailed() {
 	m.batcherTxEvs.Record(TxStageFailed)
 }
 
+func (m *Metrics) RecordL1BaseFee(baseFee *big.Int) {
+	m.l1BaseFee.Set(float64(baseFee.Uint64()) / params.GWei)
+}
+
+// RecordSubmissionDecision records a decision of the submission policy, which is
+// either to submit or hold back pending channel data, or to force its submission
+// while it is held back.
+func (m *Metrics) RecordSubmissionDecision(decision string) {
+	m.submissionDecisionEvs.Record(decision)
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
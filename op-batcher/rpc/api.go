@@ -2,10 +2,16 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +19,53 @@ import (
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	PendingChannels(ctx context.Context) ([]ChannelInfo, error)
+	FlushChannel(ctx context.Context) (derive.ChannelID, error)
+	SetMaxChannelDuration(ctx context.Context, duration uint64, validFor time.Duration) error
+	DumpChannel(ctx context.Context, id derive.ChannelID) ([]FrameTx, error)
+}
+
+// ChannelInfo describes a channel that is pending submission.
+type ChannelInfo struct {
+	ID derive.ChannelID `json:"id"`
+	// FirstBlock and LastBlock are the first and last L2 block in the channel.
+	FirstBlock eth.BlockID `json:"first_block"`
+	LastBlock  eth.BlockID `json:"last_block"`
+	NumBlocks  int         `json:"num_blocks"`
+	// InputBytes is the size of the batches added to the channel.
+	InputBytes int `json:"input_bytes"`
+	// OutputBytes is the compressed size of all frames created yet.
+	OutputBytes int    `json:"output_bytes"`
+	Full        bool   `json:"full"`
+	FullReason  string `json:"full_reason,omitempty"`
+	// Timeout is the L1 block at which the channel is closed, 0 if not set yet.
+	Timeout      uint64 `json:"timeout"`
+	TotalFrames  int    `json:"total_frames"`
+	QueuedFrames int    `json:"queued_frames"`
+	// PendingTxs are the txs in flight with frames of the channel.
+	PendingTxs []PendingTxInfo `json:"pending_txs"`
+	// ConfirmedTxs maps the confirmed txs with frames of the channel to their inclusion block.
+	ConfirmedTxs map[string]eth.BlockID `json:"confirmed_txs"`
+}
+
+// PendingTxInfo describes a tx in flight.
+type PendingTxInfo struct {
+	ID     string   `json:"id"`
+	Frames []uint16 `json:"frames"`
+}
+
+// FrameTx is a batcher tx with a single frame of a pending channel. Its JSON encoding
+// is that of the txs written by the batch_decoder fetch command, so that the frames
+// of a channel can be reassembled with batch_decoder. It isn't an actual L1 tx,
+// so it doesn't have an inclusion block.
+type FrameTx struct {
+	TxIndex     uint64         `json:"tx_index"`
+	InboxAddr   common.Address `json:"inbox_address"`
+	ChainId     uint64         `json:"chain_id"`
+	Sender      common.Address `json:"sender"`
+	ValidSender bool           `json:"valid_sender"`
+	Frames      []derive.Frame `json:"frames"`
+	ValidFrames bool           `json:"valid_data"`
 }
 
 type adminAPI struct {
@@ -41,3 +94,38 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+// PendingChannels returns the channels that are pending submission, in order.
+func (a *adminAPI) PendingChannels(ctx context.Context) ([]ChannelInfo, error) {
+	return a.b.PendingChannels(ctx)
+}
+
+// FlushChannel closes the current channel and submits all its frames immediately.
+// It returns the id of the flushed channel.
+func (a *adminAPI) FlushChannel(ctx context.Context) (derive.ChannelID, error) {
+	return a.b.FlushChannel(ctx)
+}
+
+// SetMaxChannelDuration overrides the max channel duration (in #L1-blocks) for the
+// given time, e.g. "1h30m". The override is kept until the batcher is restarted if
+// validFor is empty. A duration of 0 disables duration checks.
+func (a *adminAPI) SetMaxChannelDuration(ctx context.Context, duration hexutil.Uint64, validFor string) error {
+	var d time.Duration
+	if validFor != "" {
+		var err error
+		if d, err = time.ParseDuration(validFor); err != nil {
+			return fmt.Errorf("invalid validity duration: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("validity duration must be positive, got %v", d)
+		}
+	}
+	return a.b.SetMaxChannelDuration(ctx, uint64(duration), d)
+}
+
+// DumpChannel returns all frames of the channel with the given id, in the format
+// of the batch_decoder fetch command. Each returned tx holds a single frame, and
+// can be written to its own file to reassemble the channel with batch_decoder.
+func (a *adminAPI) DumpChannel(ctx context.Context, id derive.ChannelID) ([]FrameTx, error) {
+	return a.b.DumpChannel(ctx, id)
+}
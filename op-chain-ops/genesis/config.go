@@ -90,6 +90,9 @@ type DeployConfig struct {
 	CliqueSignerAddress common.Address `json:"cliqueSignerAddress"`
 	// L1UseClique represents whether or not to use the clique consensus engine.
 	L1UseClique bool `json:"l1UseClique"`
+	// L1CancunTimeOffset is the number of seconds after the L1 genesis block that the Cancun
+	// hard fork activates on the L1 dev chain. Nil to disable Cancun. Ignored when using clique.
+	L1CancunTimeOffset *hexutil.Uint64 `json:"l1CancunTimeOffset,omitempty"`
 
 	L1BlockTime                 uint64         `json:"l1BlockTime"`
 	L1GenesisBlockTimestamp     hexutil.Uint64 `json:"l1GenesisBlockTimestamp"`
@@ -121,6 +124,15 @@ type DeployConfig struct {
 	// L2GenesisSpanBatchTimeOffset is the number of seconds after genesis block that Span Batch hard fork activates.
 	// Set it to 0 to activate at genesis. Nil to disable SpanBatch.
 	L2GenesisSpanBatchTimeOffset *hexutil.Uint64 `json:"l2GenesisSpanBatchTimeOffset,omitempty"`
+	// L2GenesisBlobsTimeOffset is the number of seconds after genesis block that batcher data
+	// is also read from L1 blobs. Set it to 0 to activate at genesis. Nil to disable blobs.
+	L2GenesisBlobsTimeOffset *hexutil.Uint64 `json:"l2GenesisBlobsTimeOffset,omitempty"`
+	// L2GenesisChannelCompressionTimeOffset is the number of seconds after genesis block that batcher channels
+	// may be compressed with brotli or zstd. Set it to 0 to activate at genesis. Nil to disable versioned channels.
+	L2GenesisChannelCompressionTimeOffset *hexutil.Uint64 `json:"l2GenesisChannelCompressionTimeOffset,omitempty"`
+	// L2GenesisAltDATimeOffset is the number of seconds after genesis block that alt-DA commitments
+	// in batcher data are resolved with a DA server. Set it to 0 to activate at genesis. Nil to disable alt-DA.
+	L2GenesisAltDATimeOffset *hexutil.Uint64 `json:"l2GenesisAltDATimeOffset,omitempty"`
 	// L2GenesisBlockExtraData is configurable extradata. Will default to []byte("BEDROCK") if left unspecified.
 	L2GenesisBlockExtraData []byte `json:"l2GenesisBlockExtraData"`
 	// ProxyAdminOwner represents the owner of the ProxyAdmin predeploy on L2.
@@ -219,7 +231,13 @@ type DeployConfig struct {
 	// nodes are recommended to adopt, to stay in sync with the network.
 	RecommendedProtocolVersion params.ProtocolVersion `json:"recommendedProtocolVersion"`
 
-	EnableShutter           bool           `json:"enableShutter,omitempty"`
+	// EnableShutter deploys the shutter predeploys and schedules
+	// the shutter activation in the rollup config.
+	EnableShutter bool `json:"enableShutter,omitempty"`
+	// L2GenesisShutterTimeOffset is the number of seconds after genesis block that shutter activates.
+	// Set it to 0 or leave it nil to activate shutter at genesis. Requires EnableShutter.
+	L2GenesisShutterTimeOffset *hexutil.Uint64 `json:"l2GenesisShutterTimeOffset,omitempty"`
+
 	ShutterBlockGasLimit    uint64         `json:"shutterBlockGasLimit,omitempty"`
 	ShutterDaoAddress       common.Address `json:"shutterDaoAddress,omitempty"`
 	ShutterSequencerAddress common.Address `json:"shutterSequencerAddress,omitempty"`
@@ -367,6 +385,9 @@ func (d *DeployConfig) Check() error {
 
 	// Check shutter config, if enablecd
 	if !d.EnableShutter {
+		if d.L2GenesisShutterTimeOffset != nil {
+			return fmt.Errorf("%w: L2GenesisShutterTimeOffset is set, but shutter is not enabled", ErrInvalidDeployConfig)
+		}
 		return nil
 	}
 
@@ -492,6 +513,54 @@ func (d *DeployConfig) SpanBatchTime(genesisTime uint64) *uint64 {
 	return &v
 }
 
+func (d *DeployConfig) BlobsTime(genesisTime uint64) *uint64 {
+	if d.L2GenesisBlobsTimeOffset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if offset := *d.L2GenesisBlobsTimeOffset; offset > 0 {
+		v = genesisTime + uint64(offset)
+	}
+	return &v
+}
+
+func (d *DeployConfig) ChannelCompressionTime(genesisTime uint64) *uint64 {
+	if d.L2GenesisChannelCompressionTimeOffset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if offset := *d.L2GenesisChannelCompressionTimeOffset; offset > 0 {
+		v = genesisTime + uint64(offset)
+	}
+	return &v
+}
+
+func (d *DeployConfig) AltDATime(genesisTime uint64) *uint64 {
+	if d.L2GenesisAltDATimeOffset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if offset := *d.L2GenesisAltDATimeOffset; offset > 0 {
+		v = genesisTime + uint64(offset)
+	}
+	return &v
+}
+
+// ShutterTime returns the shutter activation time, or nil
+// if shutter is not enabled.
+func (d *DeployConfig) ShutterTime(genesisTime uint64) *uint64 {
+	if !d.EnableShutter {
+		return nil
+	}
+	v := uint64(0)
+	if d.L2GenesisShutterTimeOffset != nil {
+		if offset := *d.L2GenesisShutterTimeOffset; offset > 0 {
+			v = genesisTime + uint64(offset)
+		}
+	}
+	return &v
+}
+
 // RollupConfig converts a DeployConfig to a rollup.Config
 func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHash common.Hash, l2GenesisBlockNumber uint64) (*rollup.Config, error) {
 	if d.OptimismPortalProxy == (common.Address{}) {
@@ -501,7 +570,7 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		return nil, errors.New("SystemConfigProxy cannot be address(0)")
 	}
 
-	return &rollup.Config{
+	cfg := &rollup.Config{
 		Genesis: rollup.Genesis{
 			L1: eth.BlockID{
 				Hash:   l1StartBlock.Hash(),
@@ -531,7 +600,17 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		RegolithTime:           d.RegolithTime(l1StartBlock.Time()),
 		CanyonTime:             d.CanyonTime(l1StartBlock.Time()),
 		SpanBatchTime:          d.SpanBatchTime(l1StartBlock.Time()),
-	}, nil
+		BlobsTime:              d.BlobsTime(l1StartBlock.Time()),
+		ChannelCompressionTime: d.ChannelCompressionTime(l1StartBlock.Time()),
+		AltDATime:              d.AltDATime(l1StartBlock.Time()),
+		ShutterTime:            d.ShutterTime(l1StartBlock.Time()),
+	}
+	if d.EnableShutter {
+		cfg.ShutterKeyperSetManagerAddress = shpredeploys.KeyperSetManagerAddr
+		cfg.ShutterKeyBroadcastContractAddress = shpredeploys.KeyBroadcastContractAddr
+		cfg.ShutterInboxAddress = shpredeploys.InboxAddr
+	}
+	return cfg, nil
 }
 
 // NewDeployConfig reads a config file given a path on the filesystem.
@@ -61,6 +61,21 @@ func TestCanyonTimeOffset(t *testing.T) {
 	require.Equal(t, uint64(1234+1500), *config.CanyonTime(1234))
 }
 
+func TestShutterTime(t *testing.T) {
+	config := &DeployConfig{}
+	require.Nil(t, config.ShutterTime(1234))
+
+	config.EnableShutter = true
+	require.Equal(t, uint64(0), *config.ShutterTime(1234))
+
+	shutterOffset := hexutil.Uint64(1500)
+	config.L2GenesisShutterTimeOffset = &shutterOffset
+	require.Equal(t, uint64(1234+1500), *config.ShutterTime(1234))
+
+	config.EnableShutter = false
+	require.Nil(t, config.ShutterTime(1234))
+}
+
 // TestCopy will copy a DeployConfig and ensure that the copy is equal to the original.
 func TestCopy(t *testing.T) {
 	b, err := os.ReadFile("testdata/test-deploy-config-full.json")
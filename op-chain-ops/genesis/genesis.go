@@ -178,6 +178,10 @@ func NewL1Genesis(config *DeployConfig) (*core.Genesis, error) {
 	if timestamp == 0 {
 		timestamp = hexutil.Uint64(time.Now().Unix())
 	}
+	if !config.L1UseClique && config.L1CancunTimeOffset != nil {
+		cancunTime := uint64(timestamp) + uint64(*config.L1CancunTimeOffset)
+		chainConfig.CancunTime = &cancunTime
+	}
 
 	return &core.Genesis{
 		Config:     &chainConfig,
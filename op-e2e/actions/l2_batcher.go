@@ -40,10 +40,16 @@ type BatcherCfg struct {
 	// Limit the size of txs
 	MinL1TxSize uint64
 	MaxL1TxSize uint64
+	// MaxFrameSize limits the size of frames of multi-frame txs,
+	// MaxL1TxSize-1 if unset.
+	MaxFrameSize uint64
 
 	BatcherKey *ecdsa.PrivateKey
 
 	GarbageCfg *GarbageChannelCfg
+
+	// CompressionAlgo of channels, zlib if unset.
+	CompressionAlgo derive.CompressionAlgo
 }
 
 type L2BlockRefs interface {
@@ -68,7 +74,8 @@ type L2Batcher struct {
 	l1Signer types.Signer
 
 	l2ChannelOut     ChannelOutIface
-	l2Submitting     bool // when the channel out is being submitted, and not safe to write to without resetting
+	l2Submitting     bool     // when the channel out is being submitted, and not safe to write to without resetting
+	l2PendingFrames  [][]byte // frames of closed channels, waiting to be packed into multi-frame txs
 	l2BufferedBlock  eth.L2BlockRef
 	l2SubmittedBlock eth.L2BlockRef
 	l2BatcherCfg     *BatcherCfg
@@ -152,6 +159,7 @@ func (s *L2Batcher) Buffer(t Testing) error {
 				TargetFrameSize:  s.l2BatcherCfg.MaxL1TxSize,
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1,
+				CompressionAlgo:  s.l2BatcherCfg.CompressionAlgo,
 			})
 			require.NoError(t, e, "failed to create compressor")
 
@@ -203,6 +211,63 @@ func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.Dynamic
 		t.Fatalf("failed to output channel data to frame: %v", err)
 	}
 
+	s.sendBatchTx(t, data.Bytes(), txOpts...)
+}
+
+// ActL2ChannelOutputFrames outputs all frames of the closed channel to the pending frames,
+// which are submitted with ActL2BatchSubmitMultiFrame. Afterwards the next channel can be buffered,
+// so that frames of multiple channels are packed into the same batch tx.
+func (s *L2Batcher) ActL2ChannelOutputFrames(t Testing) {
+	// Don't run this action if there's no data to submit
+	if s.l2ChannelOut == nil {
+		t.InvalidAction("need to buffer data first, cannot output frames of empty buffer")
+		return
+	}
+	maxFrameSize := s.l2BatcherCfg.MaxFrameSize
+	if maxFrameSize == 0 {
+		maxFrameSize = s.l2BatcherCfg.MaxL1TxSize - 1
+	}
+	for {
+		frame := new(bytes.Buffer)
+		_, err := s.l2ChannelOut.OutputFrame(frame, maxFrameSize)
+		s.l2PendingFrames = append(s.l2PendingFrames, frame.Bytes())
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "failed to output channel data to frame")
+	}
+	s.l2ChannelOut = nil
+	s.l2Submitting = false
+}
+
+// PendingFrames returns the number of frames waiting to be submitted with ActL2BatchSubmitMultiFrame.
+func (s *L2Batcher) PendingFrames() int {
+	return len(s.l2PendingFrames)
+}
+
+// ActL2BatchSubmitMultiFrame packs as many pending frames, possibly of multiple channels, as fit into
+// a single batch tx of at most MaxL1TxSize bytes, and submits it to L1.
+func (s *L2Batcher) ActL2BatchSubmitMultiFrame(t Testing, txOpts ...func(tx *types.DynamicFeeTx)) {
+	if len(s.l2PendingFrames) == 0 {
+		t.InvalidAction("need to output frames first, cannot batch submit without frames")
+		return
+	}
+	data := new(bytes.Buffer)
+	data.WriteByte(derive.DerivationVersion0)
+	var n int
+	for _, frame := range s.l2PendingFrames {
+		if n > 0 && uint64(data.Len()+len(frame)) > s.l2BatcherCfg.MaxL1TxSize {
+			break
+		}
+		data.Write(frame)
+		n++
+	}
+	s.l2PendingFrames = s.l2PendingFrames[n:]
+	s.sendBatchTx(t, data.Bytes(), txOpts...)
+}
+
+// sendBatchTx signs a batch tx with the given data, and sends it to L1.
+func (s *L2Batcher) sendBatchTx(t Testing, data []byte, txOpts ...func(tx *types.DynamicFeeTx)) {
 	nonce, err := s.l1.PendingNonceAt(t.Ctx(), s.batcherAddr)
 	require.NoError(t, err, "need batcher nonce")
 
@@ -217,7 +282,7 @@ func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.Dynamic
 		To:        &s.rollupCfg.BatchInboxAddress,
 		GasTipCap: gasTipCap,
 		GasFeeCap: gasFeeCap,
-		Data:      data.Bytes(),
+		Data:      data,
 	}
 	for _, opt := range txOpts {
 		opt(rawTx)
@@ -33,6 +33,7 @@ func TestL2BatcherBatchType(t *testing.T) {
 		{"GarbageBatch", GarbageBatch},
 		{"ExtendedTimeWithoutL1Batches", ExtendedTimeWithoutL1Batches},
 		{"BigL2Txs", BigL2Txs},
+		{"MultiFrameTxs", MultiFrameTxs},
 	}
 	for _, test := range tests {
 		test := test
@@ -550,3 +551,83 @@ func BigL2Txs(gt *testing.T, spanBatchTimeOffset *hexutil.Uint64) {
 	verifier.ActL2PipelineFull(t)
 	require.Equal(t, sequencer.SyncStatus().UnsafeL2, verifier.SyncStatus().SafeL2, "verifier synced sequencer data even though of huge tx in block")
 }
+
+// MultiFrameTxs tests that the verifier derives the same chain as the sequencer when
+// the batcher packs frames of multiple channels into single batch txs.
+func MultiFrameTxs(gt *testing.T, spanBatchTimeOffset *hexutil.Uint64) {
+	t := NewDefaultTesting(gt)
+	p := &e2eutils.TestParams{
+		MaxSequencerDrift:   20, // larger than L1 block time we simulate in this test (12)
+		SequencerWindowSize: 24,
+		ChannelTimeout:      20,
+		L1BlockTime:         12,
+	}
+	dp := e2eutils.MakeDeployParams(t, p)
+	dp.DeployConfig.L2GenesisSpanBatchTimeOffset = spanBatchTimeOffset
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlError)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	_, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), &sync.Config{})
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize:  0,
+		MaxL1TxSize:  2_000,
+		MaxFrameSize: 300,
+		BatcherKey:   dp.Secrets.Batcher,
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	// Make a few L2 blocks with a big tx each, and put every block into its own channel,
+	// which is split into several frames.
+	cl := seqEngine.EthClient()
+	signer := types.LatestSigner(sd.L2Cfg.Config)
+	const numChannels = 4
+	for i := 0; i < numChannels; i++ {
+		n, err := cl.PendingNonceAt(t.Ctx(), dp.Addresses.Alice)
+		require.NoError(t, err)
+		data := make([]byte, 1000)
+		_, err = rand.Read(data) // fill with random bytes, to make compression ineffective
+		require.NoError(t, err)
+		gas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+		require.NoError(t, err)
+		tx := types.MustSignNewTx(dp.Secrets.Alice, signer, &types.DynamicFeeTx{
+			ChainID:   sd.L2Cfg.Config.ChainID,
+			Nonce:     n,
+			GasTipCap: big.NewInt(2 * params.GWei),
+			GasFeeCap: new(big.Int).Add(miner.l1Chain.CurrentBlock().BaseFee, big.NewInt(2*params.GWei)),
+			Gas:       gas,
+			To:        &dp.Addresses.Bob,
+			Value:     big.NewInt(0),
+			Data:      data,
+		})
+		require.NoError(t, cl.SendTransaction(t.Ctx(), tx))
+
+		sequencer.ActL2StartBlock(t)
+		seqEngine.ActL2IncludeTx(dp.Addresses.Alice)(t)
+		sequencer.ActL2EndBlock(t)
+
+		batcher.ActL2BatchBuffer(t)
+		batcher.ActL2ChannelClose(t)
+		batcher.ActL2ChannelOutputFrames(t)
+	}
+	numFrames := batcher.PendingFrames()
+	require.Greater(t, numFrames, numChannels, "channels must be split into multiple frames")
+
+	// Pack all frames into as few batch txs as possible, and include them in a single L1 block.
+	miner.ActL1StartBlock(12)(t)
+	var numTxs int
+	for batcher.PendingFrames() > 0 {
+		batcher.ActL2BatchSubmitMultiFrame(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		numTxs++
+	}
+	miner.ActL1EndBlock(t)
+	require.Less(t, numTxs, numChannels, "txs must contain frames of multiple channels")
+
+	// The verifier derives the same chain from the multi-frame txs.
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier must derive the sequencer chain")
+}
@@ -37,7 +37,7 @@ func TestL2EngineAPI(gt *testing.T) {
 	tdb := trie.NewDatabase(db, &trie.Config{HashDB: hashdb.Defaults})
 	sd.L2Cfg.MustCommit(db, tdb)
 
-	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg, jwtPath)
 
 	l2Cl, err := sources.NewEngineClient(engine.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
 	require.NoError(t, err)
@@ -100,7 +100,7 @@ func TestL2EngineAPIBlockBuilding(gt *testing.T) {
 	tdb := trie.NewDatabase(db, &trie.Config{HashDB: hashdb.Defaults})
 	sd.L2Cfg.MustCommit(db, tdb)
 
-	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg, jwtPath)
 	t.Cleanup(func() {
 		_ = engine.Close()
 	})
@@ -189,7 +189,7 @@ func TestL2EngineAPIFail(gt *testing.T) {
 	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
 	sd := e2eutils.Setup(t, dp, defaultAlloc)
 	log := testlog.Logger(t, log.LvlDebug)
-	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg, jwtPath)
 	// mock an RPC failure
 	engine.ActL2RPCFail(t)
 	// check RPC failure
@@ -213,3 +213,15 @@ func TestEngineAPITests(t *testing.T) {
 		return apiBackend
 	})
 }
+
+func TestShutterEngineAPITests(t *testing.T) {
+	test.RunShutterEngineAPITests(t, func(t *testing.T) engineapi.EngineBackend {
+		jwtPath := e2eutils.WriteDefaultJWT(t)
+		dp := shutterDeployParams(t, 0)
+		sd := e2eutils.Setup(t, dp, defaultAlloc)
+		n, _, apiBackend := newBackend(t, sd.L2Cfg, jwtPath, nil)
+		err := n.Start()
+		require.NoError(t, err)
+		return apiBackend
+	})
+}
@@ -0,0 +1,111 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	shpredeploys "github.com/shutter-network/shop-contracts/predeploy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// shutterDeployParams returns deploy parameters that deploy the shutter predeploys,
+// and schedule shutter from the given L2 block on.
+func shutterDeployParams(t require.TestingT, shutterBlock uint64) *e2eutils.DeployParams {
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	offset := hexutil.Uint64(shutterBlock * dp.DeployConfig.L2BlockTime)
+	dp.DeployConfig.EnableShutter = true
+	dp.DeployConfig.L2GenesisShutterTimeOffset = &offset
+	dp.DeployConfig.ShutterBlockGasLimit = uint64(dp.DeployConfig.L2GenesisBlockGasLimit) / 2
+	return dp
+}
+
+// TestShutterDerivation tests that a block that reveals a shutter decryption key is batched,
+// and derived by a verifier with the same reveal transaction, once shutter is active.
+func TestShutterDerivation(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := shutterDeployParams(t, 2)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	verifEngine, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), &sync.Config{})
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	// The first block is built before the shutter time, without a decryption key.
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	head := sequencer.L2Unsafe()
+	require.False(t, sd.RollupCfg.IsShutter(head.Time))
+	require.True(t, sd.RollupCfg.IsShutter(head.Time+sd.RollupCfg.BlockTime))
+
+	// Shutter is active once the keyper set manager predeploy is not paused.
+	paused, err := seqEngine.EthClient().CallContract(t.Ctx(), ethereum.CallMsg{
+		To:   &shpredeploys.KeyperSetManagerAddr,
+		Data: crypto.Keccak256([]byte("paused()"))[:4],
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 32), paused, "keyper set manager should not be paused")
+
+	// The sequencer of the action tests has no shutter-node, so the block with the decryption key
+	// is built through the engine API, and gossiped to the sequencer.
+	engCl := seqEngine.EngineClient(t, sd.RollupCfg)
+	fc := &eth.ForkchoiceState{
+		HeadBlockHash:      head.Hash,
+		SafeBlockHash:      sequencer.L2Safe().Hash,
+		FinalizedBlockHash: sequencer.L2Finalized().Hash,
+	}
+	attrs, err := derive.NewFetchingAttributesBuilder(sd.RollupCfg, miner.L1Client(t, sd.RollupCfg), engCl).
+		PreparePayloadAttributes(t.Ctx(), head, head.L1Origin)
+	require.NoError(t, err)
+	attrs.NoTxPool = true
+	_, err = engCl.ForkchoiceUpdate(t.Ctx(), fc, attrs)
+	require.ErrorContains(t, err, "invalid shutter state", "attributes without a key are rejected once shutter is active")
+
+	key := hexutil.Bytes{0x01, 0x02, 0x03}
+	attrs.DecryptionKey = &key
+	res, err := engCl.ForkchoiceUpdate(t.Ctx(), fc, attrs)
+	require.NoError(t, err)
+	require.NotNil(t, res.PayloadID)
+	payload, err := engCl.GetPayload(t.Ctx(), *res.PayloadID)
+	require.NoError(t, err)
+	sequencer.ActL2UnsafeGossipReceive(payload)(t)
+	sequencer.ActL2PipelineFull(t)
+	require.Equal(t, payload.BlockHash, sequencer.L2Unsafe().Hash)
+
+	// batch submit to L1
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	miner.ActL1EndBlock(t)
+
+	// the verifier derives the block, and reveals the same key in its first transaction
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, payload.BlockHash, verifier.L2Safe().Hash, "verifier should derive the shutter block")
+	block, err := verifEngine.EthClient().BlockByHash(t.Ctx(), payload.BlockHash)
+	require.NoError(t, err)
+	revealTx := block.Transactions()[0]
+	require.Equal(t, uint8(types.RevealTxType), revealTx.Type())
+	require.Equal(t, []byte(key), revealTx.Data())
+
+	// the sequencer consolidates its unsafe block with the derived attributes
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActL2PipelineFull(t)
+	require.Equal(t, payload.BlockHash, sequencer.L2Safe().Hash)
+}
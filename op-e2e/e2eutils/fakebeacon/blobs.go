@@ -0,0 +1,165 @@
+package fakebeacon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FakeBeacon presents a beacon-node in testing, without leading any chain-building.
+// This merely serves a fake beacon API, and holds on to blocks,
+// to complement the actual block-building to happen in testing (e.g. through the fake consensus geth module).
+type FakeBeacon struct {
+	log log.Logger
+
+	// in-memory blob store, keyed by beacon slot
+	blobsLock sync.Mutex
+	blobs     map[uint64][]*eth.BlobSidecar
+
+	beaconSrv         *http.Server
+	beaconAPIListener net.Listener
+
+	genesisTime uint64
+	blockTime   uint64
+}
+
+func NewBeacon(log log.Logger, genesisTime uint64, blockTime uint64) *FakeBeacon {
+	return &FakeBeacon{
+		log:         log,
+		blobs:       make(map[uint64][]*eth.BlobSidecar),
+		genesisTime: genesisTime,
+		blockTime:   blockTime,
+	}
+}
+
+func (f *FakeBeacon) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to open tcp listener for http beacon api server: %w", err)
+	}
+	f.beaconAPIListener = listener
+
+	mux := new(http.ServeMux)
+	mux.HandleFunc("/eth/v1/node/version", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, map[string]any{"data": map[string]string{"version": "fakebeacon/v0.0.1"}})
+	})
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, &eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: eth.Uint64String(f.genesisTime)}})
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, &eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: eth.Uint64String(f.blockTime)}})
+	})
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/", func(w http.ResponseWriter, r *http.Request) {
+		slot, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/blob_sidecars/"), 10, 64)
+		if err != nil {
+			f.log.Error("could not parse beacon slot", "path", r.URL.Path, "err", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sidecars, ok := f.loadBlobs(slot)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query()
+		if rawIndices := query.Get("indices"); rawIndices != "" {
+			filtered := make([]*eth.BlobSidecar, 0, len(sidecars))
+			for _, rawIndex := range strings.Split(rawIndices, ",") {
+				ix, err := strconv.ParseUint(rawIndex, 10, 64)
+				if err != nil {
+					f.log.Error("could not parse blob index", "index", rawIndex, "err", err)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if ix >= uint64(len(sidecars)) {
+					f.log.Error("requested blob index does not exist", "slot", slot, "index", ix)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				filtered = append(filtered, sidecars[ix])
+			}
+			sidecars = filtered
+		}
+		f.writeJSON(w, &eth.APIGetBlobSidecarsResponse{Data: sidecars})
+	})
+	f.beaconSrv = &http.Server{
+		Handler:           mux,
+		ReadTimeout:       time.Second * 20,
+		ReadHeaderTimeout: time.Second * 20,
+		WriteTimeout:      time.Second * 20,
+		IdleTimeout:       time.Second * 20,
+	}
+	go func() {
+		if err := f.beaconSrv.Serve(f.beaconAPIListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			f.log.Error("failed to start fake-pos beacon server for blobs testing", "err", err)
+		}
+	}()
+	return nil
+}
+
+func (f *FakeBeacon) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.log.Error("failed to encode beacon API response", "err", err)
+	}
+}
+
+// StoreBlobsBundle stores the blobs of the L1 block at the given slot,
+// so they can be served by the beacon API.
+func (f *FakeBeacon) StoreBlobsBundle(slot uint64, bundle *engine.BlobsBundleV1) error {
+	if len(bundle.Blobs) != len(bundle.Commitments) || len(bundle.Blobs) != len(bundle.Proofs) {
+		return fmt.Errorf("inconsistent blobs bundle: %d blobs, %d commitments, %d proofs",
+			len(bundle.Blobs), len(bundle.Commitments), len(bundle.Proofs))
+	}
+	sidecars := make([]*eth.BlobSidecar, len(bundle.Blobs))
+	for i := range bundle.Blobs {
+		sidecar := &eth.BlobSidecar{
+			Slot:  eth.Uint64String(slot),
+			Index: eth.Uint64String(i),
+		}
+		if copy(sidecar.Blob[:], bundle.Blobs[i]) != len(sidecar.Blob) {
+			return fmt.Errorf("blob %d has invalid size %d", i, len(bundle.Blobs[i]))
+		}
+		if copy(sidecar.KZGCommitment[:], bundle.Commitments[i]) != len(sidecar.KZGCommitment) {
+			return fmt.Errorf("commitment %d has invalid size %d", i, len(bundle.Commitments[i]))
+		}
+		if copy(sidecar.KZGProof[:], bundle.Proofs[i]) != len(sidecar.KZGProof) {
+			return fmt.Errorf("proof %d has invalid size %d", i, len(bundle.Proofs[i]))
+		}
+		sidecars[i] = sidecar
+	}
+	f.blobsLock.Lock()
+	defer f.blobsLock.Unlock()
+	f.blobs[slot] = sidecars
+	return nil
+}
+
+func (f *FakeBeacon) loadBlobs(slot uint64) ([]*eth.BlobSidecar, bool) {
+	f.blobsLock.Lock()
+	defer f.blobsLock.Unlock()
+	sidecars, ok := f.blobs[slot]
+	return sidecars, ok
+}
+
+func (f *FakeBeacon) Close() error {
+	if f.beaconSrv != nil {
+		return f.beaconSrv.Close()
+	}
+	return nil
+}
+
+// BeaconAddr returns the HTTP address of the fake beacon API.
+func (f *FakeBeacon) BeaconAddr() string {
+	return "http://" + f.beaconAPIListener.Addr().String()
+}
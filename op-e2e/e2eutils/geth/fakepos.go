@@ -1,6 +1,7 @@
 package geth
 
 import (
+	"math/big"
 	"math/rand"
 	"time"
 
@@ -8,15 +9,23 @@ import (
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	opeth "github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
 
+// Beacon is the subset of the beacon-node functionality that the fake proof-of-stake sidecar
+// needs, to make the blobs of built L1 blocks available.
+type Beacon interface {
+	StoreBlobsBundle(slot uint64, bundle *engine.BlobsBundleV1) error
+}
+
 // fakePoS is a testing-only utility to attach to Geth,
 // to build a fake proof-of-stake L1 chain with fixed block time and basic lagging safe/finalized blocks.
 type fakePoS struct {
@@ -32,6 +41,9 @@ type fakePoS struct {
 
 	engineAPI *catalyst.ConsensusAPI
 	sub       ethereum.Subscription
+
+	// beacon, if set, receives the blobs of every built L1 block after Cancun.
+	beacon Beacon
 }
 
 func (f *fakePoS) Start() error {
@@ -81,16 +93,32 @@ func (f *fakePoS) Start() error {
 						Amount: uint64(withdrawalsRNG.Intn(50_000_000_000) + 1),
 					}
 				}
-				res, err := f.engineAPI.ForkchoiceUpdatedV2(engine.ForkchoiceStateV1{
-					HeadBlockHash:      head.Hash(),
-					SafeBlockHash:      safe.Hash(),
-					FinalizedBlockHash: finalized.Hash(),
-				}, &engine.PayloadAttributes{
+				attrs := &engine.PayloadAttributes{
 					Timestamp:             newBlockTime,
 					Random:                common.Hash{},
 					SuggestedFeeRecipient: head.Coinbase,
 					Withdrawals:           withdrawals,
-				})
+				}
+				isCancun := f.eth.BlockChain().Config().IsCancun(new(big.Int).SetUint64(head.Number.Uint64()+1), newBlockTime)
+				if isCancun {
+					// The fake beacon chain has no real beacon blocks, so we use a deterministic mock root.
+					parentBeaconBlockRoot := head.Root
+					attrs.BeaconRoot = &parentBeaconBlockRoot
+				}
+				fcState := engine.ForkchoiceStateV1{
+					HeadBlockHash:      head.Hash(),
+					SafeBlockHash:      safe.Hash(),
+					FinalizedBlockHash: finalized.Hash(),
+				}
+				var (
+					res engine.ForkChoiceResponse
+					err error
+				)
+				if isCancun {
+					res, err = f.engineAPI.ForkchoiceUpdatedV3(fcState, attrs)
+				} else {
+					res, err = f.engineAPI.ForkchoiceUpdatedV2(fcState, attrs)
+				}
 				if err != nil {
 					f.log.Error("failed to start building L1 block", "err", err)
 					continue
@@ -109,20 +137,49 @@ func (f *fakePoS) Start() error {
 					tim.Stop()
 					return nil
 				}
-				envelope, err := f.engineAPI.GetPayloadV2(*res.PayloadID)
+				var envelope *engine.ExecutionPayloadEnvelope
+				if isCancun {
+					envelope, err = f.engineAPI.GetPayloadV3(*res.PayloadID)
+				} else {
+					envelope, err = f.engineAPI.GetPayloadV2(*res.PayloadID)
+				}
 				if err != nil {
 					f.log.Error("failed to finish building L1 block", "err", err)
 					continue
 				}
-				if _, err := f.engineAPI.NewPayloadV2(*envelope.ExecutionPayload); err != nil {
-					f.log.Error("failed to insert built L1 block", "err", err)
-					continue
+				if isCancun {
+					blobHashes := make([]common.Hash, 0)
+					if envelope.BlobsBundle != nil {
+						for _, commitment := range envelope.BlobsBundle.Commitments {
+							var c kzg4844.Commitment
+							copy(c[:], commitment)
+							blobHashes = append(blobHashes, opeth.KZGToVersionedHash(c))
+						}
+					}
+					if _, err := f.engineAPI.NewPayloadV3(*envelope.ExecutionPayload, blobHashes, attrs.BeaconRoot); err != nil {
+						f.log.Error("failed to insert built L1 block", "err", err)
+						continue
+					}
+					if f.beacon != nil && envelope.BlobsBundle != nil && len(envelope.BlobsBundle.Blobs) > 0 {
+						slot := (envelope.ExecutionPayload.Timestamp - f.eth.BlockChain().Genesis().Time()) / f.blockTime
+						if err := f.beacon.StoreBlobsBundle(slot, envelope.BlobsBundle); err != nil {
+							f.log.Error("failed to persist blobs-bundle of block, not making block canonical now", "err", err)
+							continue
+						}
+					}
+				} else {
+					if _, err := f.engineAPI.NewPayloadV2(*envelope.ExecutionPayload); err != nil {
+						f.log.Error("failed to insert built L1 block", "err", err)
+						continue
+					}
 				}
-				if _, err := f.engineAPI.ForkchoiceUpdatedV2(engine.ForkchoiceStateV1{
-					HeadBlockHash:      envelope.ExecutionPayload.BlockHash,
-					SafeBlockHash:      safe.Hash(),
-					FinalizedBlockHash: finalized.Hash(),
-				}, nil); err != nil {
+				fcState.HeadBlockHash = envelope.ExecutionPayload.BlockHash
+				if isCancun {
+					_, err = f.engineAPI.ForkchoiceUpdatedV3(fcState, nil)
+				} else {
+					_, err = f.engineAPI.ForkchoiceUpdatedV2(fcState, nil)
+				}
+				if err != nil {
 					f.log.Error("failed to make built L1 block canonical", "err", err)
 					continue
 				}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 
+	shpredeploys "github.com/shutter-network/shop-contracts/predeploy"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
@@ -159,6 +160,15 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		RegolithTime:           deployConf.RegolithTime(uint64(deployConf.L1GenesisBlockTimestamp)),
 		CanyonTime:             deployConf.CanyonTime(uint64(deployConf.L1GenesisBlockTimestamp)),
 		SpanBatchTime:          deployConf.SpanBatchTime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		BlobsTime:              deployConf.BlobsTime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		ChannelCompressionTime: deployConf.ChannelCompressionTime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		AltDATime:              deployConf.AltDATime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		ShutterTime:            deployConf.ShutterTime(uint64(deployConf.L1GenesisBlockTimestamp)),
+	}
+	if deployConf.EnableShutter {
+		rollupCfg.ShutterKeyperSetManagerAddress = shpredeploys.KeyperSetManagerAddr
+		rollupCfg.ShutterKeyBroadcastContractAddress = shpredeploys.KeyBroadcastContractAddr
+		rollupCfg.ShutterInboxAddress = shpredeploys.InboxAddr
 	}
 
 	require.NoError(t, rollupCfg.Check())
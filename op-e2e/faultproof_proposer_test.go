@@ -0,0 +1,185 @@
+package op_e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	proposermetrics "github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	l2os "github.com/ethereum-optimism/optimism/op-proposer/proposer"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// outputGameType is the game type that the proposer creates output-root games for in these tests.
+const outputGameType uint8 = 7
+
+// faultGameType is the game type of the FaultDisputeGame, as registered by the devnet deployment.
+const faultGameType uint8 = 0
+
+func TestOutputProposerCreatesDisputeGames(t *testing.T) {
+	InitParallel(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	sys, l1Client := startFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	factoryAddr, factory := deployOutputGameFactory(t, ctx, sys, l1Client)
+
+	proposer, err := l2os.NewL2OutputSubmitterFromCLIConfig(l2os.CLIConfig{
+		L1EthRpc:          sys.EthInstances["l1"].WSEndpoint(),
+		RollupRpcs:        []string{sys.RollupNodes["sequencer"].HTTPEndpoint()},
+		DGFAddress:        factoryAddr.Hex(),
+		ProposalInterval:  time.Second,
+		DisputeGameType:   uint(outputGameType),
+		PollInterval:      50 * time.Millisecond,
+		TxMgrConfig:       newTxMgrConfig(sys.EthInstances["l1"].WSEndpoint(), sys.cfg.Secrets.Bob),
+		AllowNonFinalized: true,
+		LogConfig: oplog.CLIConfig{
+			Level:  log.LvlInfo,
+			Format: oplog.FormatText,
+		},
+	}, testlog.Logger(t, log.LvlInfo).New("role", "game-proposer"), proposermetrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, proposer.Start())
+	t.Cleanup(proposer.Stop)
+
+	rollupRPCClient, err := rpc.DialContext(ctx, sys.RollupNodes["sequencer"].HTTPEndpoint())
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client.NewBaseRPCClient(rollupRPCClient))
+
+	require.NoError(t, wait.For(ctx, time.Second, func() (bool, error) {
+		count, err := factory.GameCount(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return false, err
+		}
+		return count.Uint64() >= 2, nil
+	}), "proposer did not create dispute games")
+
+	// Stop the safe head from advancing, so that the proposer runs out of new outputs to propose.
+	require.NoError(t, sys.BatchSubmitter.Driver().StopBatchSubmitting(ctx))
+	l1Head, err := l1Client.BlockNumber(ctx)
+	require.NoError(t, err)
+	var safeHead uint64
+	require.NoError(t, wait.For(ctx, time.Second, func() (bool, error) {
+		status, err := rollupClient.SyncStatus(ctx)
+		if err != nil {
+			return false, err
+		}
+		safeHead = status.SafeL2.Number
+		if status.CurrentL1.Number < l1Head {
+			return false, nil
+		}
+		return outputGameExists(ctx, rollupClient, factory, safeHead)
+	}), "proposer did not create a dispute game for the latest safe output")
+
+	// No more games are created for outputs that already have one.
+	proposerAddr := crypto.PubkeyToAddress(sys.cfg.Secrets.Bob.PublicKey)
+	nonce, err := l1Client.NonceAt(ctx, proposerAddr, nil)
+	require.NoError(t, err)
+	count, err := factory.GameCount(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	time.Sleep(3 * time.Second)
+	newNonce, err := l1Client.NonceAt(ctx, proposerAddr, nil)
+	require.NoError(t, err)
+	require.Equal(t, nonce, newNonce, "proposer sent a tx for an existing dispute game")
+
+	// Every game commits to the output of the L2 block in its extra data.
+	found := 0
+	for n := uint64(1); n <= safeHead; n++ {
+		exists, err := outputGameExists(ctx, rollupClient, factory, n)
+		require.NoError(t, err)
+		if exists {
+			found++
+		}
+	}
+	require.EqualValues(t, count.Uint64(), found, "dispute games with unexpected root claims")
+}
+
+// TestOutputProposerRejectsFaultDisputeGames ensures that the proposer refuses to create games of a type that is
+// implemented by the FaultDisputeGame, which disputes L2OutputOracle outputs and rejects output root claims.
+func TestOutputProposerRejectsFaultDisputeGames(t *testing.T) {
+	InitParallel(t)
+
+	sys, _ := startFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	_, err := l2os.NewL2OutputSubmitterFromCLIConfig(l2os.CLIConfig{
+		L1EthRpc:          sys.EthInstances["l1"].WSEndpoint(),
+		RollupRpcs:        []string{sys.RollupNodes["sequencer"].HTTPEndpoint()},
+		DGFAddress:        sys.cfg.L1Deployments.DisputeGameFactoryProxy.Hex(),
+		ProposalInterval:  time.Second,
+		DisputeGameType:   uint(faultGameType),
+		PollInterval:      50 * time.Millisecond,
+		TxMgrConfig:       newTxMgrConfig(sys.EthInstances["l1"].WSEndpoint(), sys.cfg.Secrets.Bob),
+		AllowNonFinalized: true,
+		LogConfig: oplog.CLIConfig{
+			Level:  log.LvlInfo,
+			Format: oplog.FormatText,
+		},
+	}, testlog.Logger(t, log.LvlInfo).New("role", "game-proposer"), proposermetrics.NoopMetrics)
+	require.ErrorContains(t, err, "FaultDisputeGame")
+}
+
+// deployOutputGameFactory deploys a DisputeGameFactory behind a proxy, owned by Alice, with an implementation
+// registered for outputGameType. The in-tree FaultDisputeGame only accepts root claims that dispute outputs
+// of the L2OutputOracle, and the proposer refuses to create it (see TestOutputProposerRejectsFaultDisputeGames).
+// There is no output-root game implementation in the tree, so an account without code stands in for it.
+func deployOutputGameFactory(t *testing.T, ctx context.Context, sys *System, l1Client *ethclient.Client) (common.Address, *bindings.DisputeGameFactory) {
+	opts, err := bind.NewKeyedTransactorWithChainID(sys.cfg.Secrets.Alice, sys.cfg.L1ChainIDBig())
+	require.NoError(t, err)
+
+	implAddr, tx, _, err := bindings.DeployDisputeGameFactory(opts, l1Client)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(ctx, l1Client, tx.Hash())
+	require.NoError(t, err)
+
+	proxyAddr, tx, proxy, err := bindings.DeployProxy(opts, l1Client, opts.From)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(ctx, l1Client, tx.Hash())
+	require.NoError(t, err)
+
+	factoryABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	initData, err := factoryABI.Pack("initialize", opts.From)
+	require.NoError(t, err)
+	tx, err = proxy.UpgradeToAndCall(opts, implAddr, initData)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(ctx, l1Client, tx.Hash())
+	require.NoError(t, err)
+
+	factory, err := bindings.NewDisputeGameFactory(proxyAddr, l1Client)
+	require.NoError(t, err)
+	tx, err = factory.SetImplementation(opts, outputGameType, common.Address{0xde, 0xad})
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(ctx, l1Client, tx.Hash())
+	require.NoError(t, err)
+	return proxyAddr, factory
+}
+
+func outputGameExists(ctx context.Context, rollupClient *sources.RollupClient, factory *bindings.DisputeGameFactory, l2BlockNumber uint64) (bool, error) {
+	output, err := rollupClient.OutputAtBlock(ctx, l2BlockNumber)
+	if err != nil {
+		return false, err
+	}
+	extraData := common.BigToHash(new(big.Int).SetUint64(l2BlockNumber)).Bytes()
+	game, err := factory.Games(&bind.CallOpts{Context: ctx}, outputGameType, output.OutputRoot, extraData)
+	if err != nil {
+		return false, err
+	}
+	return game.Proxy != (common.Address{}), nil
+}
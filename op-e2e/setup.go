@@ -38,10 +38,12 @@ import (
 
 	bss "github.com/ethereum-optimism/optimism/op-batcher/batcher"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/fakebeacon"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
@@ -205,6 +207,9 @@ type SystemConfig struct {
 	// Max L1 tx size for the batcher transactions
 	BatcherMaxL1TxSizeBytes uint64
 
+	// Data availability type of the batcher transactions. Defaults to calldata.
+	BatcherDataAvailabilityType batcherFlags.DataAvailabilityType
+
 	// SupportL1TimeTravel determines if the L1 node supports quickly skipping forward in time
 	SupportL1TimeTravel bool
 }
@@ -260,6 +265,10 @@ type System struct {
 	BatchSubmitter    *bss.BatcherService
 	Mocknet           mocknet.Mocknet
 
+	// L1BeaconAPIAddr is the address of the fake beacon API, if L1 Cancun is scheduled in the deploy config.
+	L1BeaconAPIAddr string
+	l1Beacon        *fakebeacon.FakeBeacon
+
 	// TimeTravelClock is nil unless SystemConfig.SupportL1TimeTravel was set to true
 	// It provides access to the clock instance used by the L1 node. Calling TimeTravelClock.AdvanceBy
 	// allows tests to quickly time travel L1 into the future.
@@ -289,6 +298,9 @@ func (sys *System) Close() {
 	for _, ei := range sys.EthInstances {
 		ei.Close()
 	}
+	if sys.l1Beacon != nil {
+		_ = sys.l1Beacon.Close()
+	}
 	sys.Mocknet.Close()
 }
 
@@ -347,6 +359,9 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			for _, ei := range sys.EthInstances {
 				ei.Close()
 			}
+			if sys.l1Beacon != nil {
+				_ = sys.l1Beacon.Close()
+			}
 		}
 	}()
 
@@ -429,6 +444,9 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			RegolithTime:            cfg.DeployConfig.RegolithTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 			CanyonTime:              cfg.DeployConfig.CanyonTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 			SpanBatchTime:           cfg.DeployConfig.SpanBatchTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			BlobsTime:               cfg.DeployConfig.BlobsTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			ChannelCompressionTime:  cfg.DeployConfig.ChannelCompressionTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			AltDATime:               cfg.DeployConfig.AltDATime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 			ProtocolVersionsAddress: cfg.L1Deployments.ProtocolVersionsProxy,
 		}
 	}
@@ -438,8 +456,20 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	}
 	sys.RollupConfig = &defaultConfig
 
+	// Blobs are only available on L1 after Cancun, and are served by a fake beacon API.
+	var l1Beacon geth.Beacon
+	if cfg.DeployConfig.L1CancunTimeOffset != nil {
+		bcn := fakebeacon.NewBeacon(testlog.Logger(t, log.LvlInfo).New("role", "l1_cl"), l1Genesis.Timestamp, cfg.DeployConfig.L1BlockTime)
+		if err := bcn.Start("127.0.0.1:0"); err != nil {
+			return nil, fmt.Errorf("failed to start fake beacon API: %w", err)
+		}
+		sys.l1Beacon = bcn
+		sys.L1BeaconAPIAddr = bcn.BeaconAddr()
+		l1Beacon = bcn
+	}
+
 	// Initialize nodes
-	l1Node, l1Backend, err := geth.InitL1(cfg.DeployConfig.L1ChainID, cfg.DeployConfig.L1BlockTime, l1Genesis, c, cfg.GethOptions["l1"]...)
+	l1Node, l1Backend, err := geth.InitL1(cfg.DeployConfig.L1ChainID, cfg.DeployConfig.L1BlockTime, l1Genesis, c, l1Beacon, cfg.GethOptions["l1"]...)
 	if err != nil {
 		return nil, err
 	}
@@ -489,6 +519,9 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	// of only websockets (which are required for external eth client tests).
 	for name, rollupCfg := range cfg.Nodes {
 		configureL1(rollupCfg, sys.EthInstances["l1"])
+		if sys.L1BeaconAPIAddr != "" {
+			rollupCfg.Beacon = &rollupNode.L1BeaconEndpointConfig{BeaconAddr: sys.L1BeaconAPIAddr}
+		}
 		configureL2(rollupCfg, sys.EthInstances[name], cfg.JWTSecret)
 
 		rollupCfg.L2Sync = &rollupNode.PreparedL2SyncEndpoint{
@@ -664,7 +697,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	// L2Output Submitter
 	sys.L2OutputSubmitter, err = l2os.NewL2OutputSubmitterFromCLIConfig(l2os.CLIConfig{
 		L1EthRpc:          sys.EthInstances["l1"].WSEndpoint(),
-		RollupRpc:         sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		RollupRpcs:        []string{sys.RollupNodes["sequencer"].HTTPEndpoint()},
 		L2OOAddress:       config.L1Deployments.L2OutputOracleProxy.Hex(),
 		PollInterval:      50 * time.Millisecond,
 		TxMgrConfig:       newTxMgrConfig(sys.EthInstances["l1"].WSEndpoint(), cfg.Secrets.Proposer),
@@ -690,6 +723,10 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	if batcherMaxL1TxSizeBytes == 0 {
 		batcherMaxL1TxSizeBytes = 240_000
 	}
+	batcherDAType := cfg.BatcherDataAvailabilityType
+	if batcherDAType == "" {
+		batcherDAType = batcherFlags.CalldataType
+	}
 	batcherCLIConfig := &bss.CLIConfig{
 		L1EthRpc:               sys.EthInstances["l1"].WSEndpoint(),
 		L2EthRpc:               sys.EthInstances["sequencer"].WSEndpoint(),
@@ -709,8 +746,9 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			Level:  log.LvlInfo,
 			Format: oplog.FormatText,
 		},
-		Stopped:   sys.cfg.DisableBatcher, // Batch submitter may be enabled later
-		BatchType: batchType,
+		Stopped:              sys.cfg.DisableBatcher, // Batch submitter may be enabled later
+		BatchType:            batchType,
+		DataAvailabilityType: batcherDAType,
 	}
 	// Batch Submitter
 	batcher, err := bss.BatcherServiceFromCLIConfig(context.Background(), "0.0.1", batcherCLIConfig, sys.cfg.Loggers["batcher"])
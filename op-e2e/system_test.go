@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
 
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
@@ -1373,6 +1374,66 @@ func TestBatcherMultiTx(t *testing.T) {
 	t.Fatal("Expected at least 10 transactions from the batcher")
 }
 
+// TestBatcherBlobs tests that the batcher submits its batches as blob transactions once
+// Cancun is active on L1, and that the verifier derives the safe chain from these blobs.
+func TestBatcherBlobs(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	genesisActivation := hexutil.Uint64(0)
+	cfg.DeployConfig.L1CancunTimeOffset = &genesisActivation
+	cfg.DeployConfig.L2GenesisBlobsTimeOffset = &genesisActivation
+	cfg.BatcherDataAvailabilityType = batcherFlags.BlobsType
+
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l1Client := sys.Clients["l1"]
+
+	rollupRPCClient, err := rpc.DialContext(context.Background(), sys.RollupNodes["verifier"].HTTPEndpoint())
+	require.Nil(t, err)
+	verifierClient := sources.NewRollupClient(client.NewBaseRPCClient(rollupRPCClient))
+
+	// Wait for the verifier to derive safe blocks from the batcher blobs
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Duration(cfg.DeployConfig.L1BlockTime*20)*time.Second)
+	defer waitCancel()
+	err = wait.For(waitCtx, time.Second, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		status, err := verifierClient.SyncStatus(ctx)
+		if err != nil {
+			return false, err
+		}
+		return status.SafeL2.Number >= 5, nil
+	})
+	require.Nil(t, err, "Verifier safe head did not advance")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	l1Number, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+
+	// All batcher transactions must be blob transactions, carrying a single frame each
+	batcherTxCount := 0
+	for i := uint64(0); i <= l1Number; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		cancel()
+		require.Nil(t, err)
+		for _, tx := range block.Transactions() {
+			if tx.To() == nil || *tx.To() != cfg.DeployConfig.BatchInboxAddress {
+				continue
+			}
+			require.Equal(t, uint8(types.BlobTxType), tx.Type(), "batcher tx must be a blob tx")
+			require.Len(t, tx.BlobHashes(), 1, "batcher tx must carry a single blob")
+			require.Empty(t, tx.Data(), "batcher blob tx must not carry calldata")
+			batcherTxCount++
+		}
+	}
+	require.Greater(t, batcherTxCount, 0, "expected batcher blob transactions on L1")
+}
+
 func latestBlock(t *testing.T, client *ethclient.Client) uint64 {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
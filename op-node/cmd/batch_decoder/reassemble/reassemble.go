@@ -107,7 +107,7 @@ func processFrames(cfg *rollup.Config, id derive.ChannelID, frames []FrameWithMe
 	var batches []derive.BatchData
 	invalidBatches := false
 	if ch.IsReady() {
-		br, err := derive.BatchReader(ch.Reader())
+		br, err := derive.BatchReader(ch.Reader(), true)
 		if err == nil {
 			for batch, err := br(); err != io.EOF; batch, err = br() {
 				if err != nil {
@@ -137,9 +137,14 @@ func processFrames(cfg *rollup.Config, id derive.ChannelID, frames []FrameWithMe
 func transactionsToFrames(txns []fetch.TransactionWithMetadata) []FrameWithMetadata {
 	var out []FrameWithMetadata
 	for _, tx := range txns {
+		// Frames dumped by the batcher aren't submitted in an actual tx.
+		var txHash common.Hash
+		if tx.Tx != nil {
+			txHash = tx.Tx.Hash()
+		}
 		for _, frame := range tx.Frames {
 			fm := FrameWithMetadata{
-				TxHash:         tx.Tx.Hash(),
+				TxHash:         txHash,
 				InclusionBlock: tx.BlockNumber,
 				BlockHash:      tx.BlockHash,
 				Timestamp:      tx.BlockTime,
@@ -0,0 +1,143 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	TraceFlag = &cli.PathFlag{
+		Name:     "trace",
+		Usage:    "Path of a derivation trace, as recorded with --derivation.trace-path",
+		Required: true,
+	}
+	OutFlag = &cli.PathFlag{
+		Name:  "out",
+		Usage: "Path to write the trace of the replayed derivation to. Not written if not set.",
+	}
+)
+
+var Subcommands = []*cli.Command{
+	{
+		Name:  "run",
+		Usage: "Re-derives a recorded derivation trace offline, and reports where the replay diverges from the recording",
+		Flags: []cli.Flag{
+			flags.RollupConfig,
+			flags.Network,
+			TraceFlag,
+			OutFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+
+			rollupCfg, err := opnode.NewRollupConfig(logger, ctx)
+			if err != nil {
+				return err
+			}
+			recorded, err := readTrace(ctx.Path(TraceFlag.Name))
+			if err != nil {
+				return err
+			}
+
+			// The replayed trace is kept in memory to compare it, and optionally written out too.
+			replayed := new(memoryTracer)
+			var tracer derive.Tracer = replayed
+			var out *derive.JSONTracer
+			if path := ctx.Path(OutFlag.Name); path != "" {
+				f, err := os.Create(path)
+				if err != nil {
+					return fmt.Errorf("failed to create replay trace file: %w", err)
+				}
+				out = derive.NewJSONTracer(f)
+				tracer = multiTracer{replayed, out}
+			}
+			err = derive.Replay(ctx.Context, logger, rollupCfg, metrics.NoopMetrics, recorded, tracer)
+			if out != nil {
+				if closeErr := out.Close(); closeErr != nil {
+					err = errors.Join(err, fmt.Errorf("failed to write replay trace: %w", closeErr))
+				}
+			}
+			// A failed replay still reports how far it matched the recording.
+			return errors.Join(err, report(recorded, replayed.entries))
+		},
+	},
+	{
+		Name:      "diff",
+		Usage:     "Reports the first divergence between two derivation traces",
+		ArgsUsage: "<trace-a> <trace-b>",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("expected the paths of two derivation traces")
+			}
+			a, err := readTrace(ctx.Args().Get(0))
+			if err != nil {
+				return err
+			}
+			b, err := readTrace(ctx.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			return report(a, b)
+		},
+	},
+}
+
+func readTrace(path string) ([]derive.TraceEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open derivation trace: %w", err)
+	}
+	defer f.Close()
+	return derive.ReadTrace(f)
+}
+
+// report prints the first divergence between the traces, and returns an error if there is one.
+func report(a, b []derive.TraceEntry) error {
+	div := derive.DiffTraces(a, b)
+	if div == nil {
+		fmt.Printf("Traces match (%d and %d entries)\n", len(a), len(b))
+		return nil
+	}
+	fmt.Printf("Traces diverge at %s entry %d\n", div.Stage, div.Index)
+	for _, side := range []struct {
+		name  string
+		entry *derive.TraceEntry
+	}{{"a", div.A}, {"b", div.B}} {
+		if side.entry == nil {
+			fmt.Printf("%s: <missing>\n", side.name)
+			continue
+		}
+		out, err := json.MarshalIndent(side.entry, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %s\n", side.name, out)
+	}
+	return fmt.Errorf("traces diverge at %s entry %d", div.Stage, div.Index)
+}
+
+type memoryTracer struct {
+	entries []derive.TraceEntry
+}
+
+func (t *memoryTracer) TraceEntry(entry *derive.TraceEntry) {
+	t.entries = append(t.entries, *entry)
+}
+
+type multiTracer []derive.Tracer
+
+func (m multiTracer) TraceEntry(entry *derive.TraceEntry) {
+	for _, t := range m {
+		t.TraceEntry(entry)
+	}
+}
@@ -0,0 +1,108 @@
+package election
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// Server is a member of the sequencer set.
+type Server struct {
+	ID   string
+	Addr string
+}
+
+// ParseServer parses a server in the "<id>=<host>:<port>" format.
+func ParseServer(s string) (Server, error) {
+	id, addr, ok := strings.Cut(s, "=")
+	if !ok || id == "" || addr == "" {
+		return Server{}, fmt.Errorf("invalid server %q, expected <id>=<host>:<port>", s)
+	}
+	return Server{ID: id, Addr: addr}, nil
+}
+
+type Config struct {
+	// Enabled runs the sequencer as part of a sequencer set, in which only the elected leader sequences.
+	Enabled bool
+
+	// ServerID identifies this sequencer in the sequencer set, and must never change.
+	ServerID string
+	// ListenAddr is the host:port to listen on for raft traffic, which is also advertised to the other sequencers.
+	ListenAddr string
+	// StorageDir is where the raft log and snapshots are persisted.
+	StorageDir string
+
+	// Bootstrap bootstraps a new sequencer set with the Servers. Only needs to be set on one sequencer,
+	// and is ignored if the sequencer set was bootstrapped already.
+	Bootstrap bool
+	// Servers are the members of the sequencer set to bootstrap with, including this sequencer.
+	Servers []Server
+
+	// HealthCheckInterval is the interval between health checks of the local node.
+	HealthCheckInterval time.Duration
+	// MaxUnsafeLag is how far behind the wall clock the unsafe head of a healthy node may be.
+	MaxUnsafeLag time.Duration
+	// HealthCheckThreshold is the number of consecutive failed health checks after which
+	// the leader hands over the leadership to another sequencer.
+	HealthCheckThreshold uint64
+}
+
+func (c *Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ServerID == "" {
+		return errors.New("sequencer election requires a server ID")
+	}
+	if c.ListenAddr == "" {
+		return errors.New("sequencer election requires a listen address")
+	}
+	if c.StorageDir == "" {
+		return errors.New("sequencer election requires a storage directory")
+	}
+	if c.HealthCheckInterval == 0 {
+		return errors.New("sequencer election health check interval must be set")
+	}
+	if c.HealthCheckThreshold == 0 {
+		return errors.New("sequencer election health check threshold must be at least 1")
+	}
+	if c.Bootstrap {
+		if err := c.checkServers(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) checkServers() error {
+	seen := make(map[string]struct{})
+	self := false
+	for _, s := range c.Servers {
+		if _, ok := seen[s.ID]; ok {
+			return fmt.Errorf("duplicate sequencer election server %q", s.ID)
+		}
+		seen[s.ID] = struct{}{}
+		if s.ID == c.ServerID {
+			self = true
+		}
+	}
+	if !self {
+		return fmt.Errorf("sequencer election servers must include this server %q to bootstrap", c.ServerID)
+	}
+	return nil
+}
+
+func (c *Config) configuration() raft.Configuration {
+	var conf raft.Configuration
+	for _, s := range c.Servers {
+		conf.Servers = append(conf.Servers, raft.Server{
+			Suffrage: raft.Voter,
+			ID:       raft.ServerID(s.ID),
+			Address:  raft.ServerAddress(s.Addr),
+		})
+	}
+	return conf
+}
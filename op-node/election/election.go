@@ -0,0 +1,374 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrNotLeader = errors.New("sequencer is not the elected leader")
+
+const (
+	// commitTimeout is how long replicating a payload may take, if the context has no deadline.
+	commitTimeout = 5 * time.Second
+	// barrierTimeout is how long a new leader waits to apply all replicated state.
+	barrierTimeout = 10 * time.Second
+	// takeOverTimeout is how long a new leader may take to insert the replicated payload and start sequencing.
+	takeOverTimeout = 30 * time.Second
+	// rpcTimeout bounds calls to the local node.
+	rpcTimeout = 10 * time.Second
+)
+
+// Sequencer is the local node, which is started and stopped as the election leadership changes.
+type Sequencer interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error
+	StartSequencer(ctx context.Context, blockHash common.Hash) error
+	HandoffSequencer(ctx context.Context, blockHash common.Hash, shutterActive bool) error
+	StopSequencer(ctx context.Context) (common.Hash, error)
+	SequencerActive(ctx context.Context) (bool, error)
+}
+
+// Election elects a single sequencer out of the sequencer set with raft. Only the leader sequences, and
+// every sequenced payload is replicated to the sequencer set before it is published, so a new leader
+// can continue from the latest unsafe head after a failover.
+type Election struct {
+	log log.Logger
+	cfg *Config
+
+	fsm     *fsm
+	raft    *raft.Raft
+	notify  chan bool
+	closers []io.Closer
+
+	seq    Sequencer
+	leader atomic.Bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	timeNow func() time.Time
+}
+
+// New creates the election, with the raft state persisted in the configured storage directory.
+func New(logger log.Logger, cfg *Config) (*Election, error) {
+	if err := os.MkdirAll(cfg.StorageDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create election storage dir: %w", err)
+	}
+	logOutput := &logWriter{log: logger}
+	store, err := raftboltdb.NewBoltStore(filepath.Join(cfg.StorageDir, "raft.db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open raft store: %w", err)
+	}
+	snaps, err := raft.NewFileSnapshotStore(cfg.StorageDir, 2, logOutput)
+	if err != nil {
+		_ = store.Close()
+		return nil, fmt.Errorf("failed to open raft snapshot store: %w", err)
+	}
+	advertise, err := net.ResolveTCPAddr("tcp", cfg.ListenAddr)
+	if err != nil {
+		_ = store.Close()
+		return nil, fmt.Errorf("failed to resolve election listen address: %w", err)
+	}
+	trans, err := raft.NewTCPTransport(cfg.ListenAddr, advertise, 3, 10*time.Second, logOutput)
+	if err != nil {
+		_ = store.Close()
+		return nil, fmt.Errorf("failed to create raft transport: %w", err)
+	}
+	e, err := newElection(logger, cfg, raft.DefaultConfig(), store, store, snaps, trans, trans, store)
+	if err != nil {
+		_ = trans.Close()
+		_ = store.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+func newElection(logger log.Logger, cfg *Config, raftCfg *raft.Config, logs raft.LogStore, stable raft.StableStore,
+	snaps raft.SnapshotStore, trans raft.Transport, closers ...io.Closer) (*Election, error) {
+	notify := make(chan bool, 10)
+	raftCfg.LocalID = raft.ServerID(cfg.ServerID)
+	raftCfg.NotifyCh = notify
+	raftCfg.LogOutput = &logWriter{log: logger}
+
+	f := new(fsm)
+	r, err := raft.NewRaft(raftCfg, f, logs, stable, snaps, trans)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start raft: %w", err)
+	}
+	if cfg.Bootstrap {
+		err := r.BootstrapCluster(cfg.configuration()).Error()
+		if err != nil && !errors.Is(err, raft.ErrCantBootstrap) {
+			_ = r.Shutdown().Error()
+			return nil, fmt.Errorf("failed to bootstrap sequencer set: %w", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Election{
+		log:     logger,
+		cfg:     cfg,
+		fsm:     f,
+		raft:    r,
+		notify:  notify,
+		closers: closers,
+		ctx:     ctx,
+		cancel:  cancel,
+		timeNow: time.Now,
+	}, nil
+}
+
+// Start starts following the election, and starts or stops the sequencer when the leadership changes.
+func (e *Election) Start(seq Sequencer) {
+	e.seq = seq
+	e.wg.Add(1)
+	go e.loop()
+}
+
+// Leader returns whether this sequencer is the elected leader.
+func (e *Election) Leader() bool {
+	return e.raft.State() == raft.Leader
+}
+
+// State returns the latest replicated sequencer state.
+func (e *Election) State() SequencerState {
+	return e.fsm.State()
+}
+
+// CommitUnsafePayload replicates the payload to the sequencer set. The payload must not be published
+// before it is committed, so that a new leader never builds on an older head than was published.
+func (e *Election) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload, shutterActive bool) error {
+	if !e.Leader() {
+		return ErrNotLeader
+	}
+	data, err := json.Marshal(SequencerState{Payload: payload, ShutterActive: shutterActive})
+	if err != nil {
+		return fmt.Errorf("failed to encode sequencer state: %w", err)
+	}
+	timeout := commitTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	f := e.raft.Apply(data, timeout)
+	if err := f.Error(); err != nil {
+		return fmt.Errorf("failed to replicate payload %s: %w", payload.ID(), err)
+	}
+	if err, ok := f.Response().(error); ok && err != nil {
+		return err
+	}
+	return nil
+}
+
+func (e *Election) loop() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+
+	var failures uint64
+	for {
+		select {
+		case leader := <-e.notify:
+			e.leader.Store(leader)
+			failures = 0
+			if leader {
+				e.log.Info("Elected as sequencer leader")
+				e.takeOver()
+			} else {
+				e.log.Info("Lost sequencer leadership")
+				e.stepDown()
+			}
+		case <-ticker.C:
+			if !e.leader.Load() {
+				continue
+			}
+			if err := e.healthCheck(); err != nil {
+				failures++
+				e.log.Warn("Sequencer health check failed", "failures", failures, "err", err)
+				if failures >= e.cfg.HealthCheckThreshold {
+					e.handOver()
+					failures = 0
+				}
+			} else {
+				failures = 0
+			}
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+// takeOver continues sequencing from the latest replicated payload, after this sequencer was elected.
+func (e *Election) takeOver() {
+	ctx, cancel := context.WithTimeout(e.ctx, takeOverTimeout)
+	defer cancel()
+
+	// Apply all state replicated by the previous leader first.
+	if err := e.raft.Barrier(barrierTimeout).Error(); err != nil {
+		e.log.Error("Failed to apply replicated sequencer state", "err", err)
+		e.transfer()
+		return
+	}
+	if err := e.startSequencer(ctx, e.fsm.State()); err != nil {
+		e.log.Error("Failed to take over sequencing, handing over leadership", "err", err)
+		e.transfer()
+	}
+}
+
+func (e *Election) startSequencer(ctx context.Context, state SequencerState) error {
+	if active, err := e.seq.SequencerActive(ctx); err != nil {
+		return fmt.Errorf("failed to check sequencer status: %w", err)
+	} else if active {
+		return nil
+	}
+	head, err := e.awaitPayload(ctx, state.Payload)
+	if err != nil {
+		return err
+	}
+	if state.Payload != nil && head.Hash == state.Payload.BlockHash {
+		e.log.Info("Continuing sequencing from replicated payload", "head", head, "shutter_active", state.ShutterActive)
+		return e.seq.HandoffSequencer(ctx, head.Hash, state.ShutterActive)
+	}
+	e.log.Info("Starting sequencing from local unsafe head", "head", head)
+	return e.seq.StartSequencer(ctx, head.Hash)
+}
+
+// awaitPayload inserts the replicated payload, if the local node does not have it yet,
+// and waits for the local unsafe head to be updated to it.
+func (e *Election) awaitPayload(ctx context.Context, payload *eth.ExecutionPayload) (eth.L2BlockRef, error) {
+	inserted := false
+	for {
+		status, err := e.seq.SyncStatus(ctx)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to get sync status: %w", err)
+		}
+		head := status.UnsafeL2
+		if payload == nil || head.Hash == payload.BlockHash {
+			return head, nil
+		}
+		if head.Number > uint64(payload.BlockNumber) {
+			// Only blocks that were never published can be ahead of the replicated state.
+			e.log.Warn("Local unsafe head is ahead of replicated payload", "head", head, "payload", payload.ID())
+			return head, nil
+		}
+		if !inserted {
+			if err := e.seq.OnUnsafeL2Payload(ctx, payload); err != nil {
+				return eth.L2BlockRef{}, fmt.Errorf("failed to insert replicated payload %s: %w", payload.ID(), err)
+			}
+			inserted = true
+		}
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			return eth.L2BlockRef{}, fmt.Errorf("replicated payload %s was not inserted (head %s): %w", payload.ID(), head, ctx.Err())
+		}
+	}
+}
+
+// stepDown stops the sequencer, after the leadership was lost.
+func (e *Election) stepDown() {
+	ctx, cancel := context.WithTimeout(e.ctx, rpcTimeout)
+	defer cancel()
+	if err := e.stopSequencer(ctx); err != nil {
+		e.log.Error("Failed to stop sequencer after losing leadership", "err", err)
+	}
+}
+
+func (e *Election) stopSequencer(ctx context.Context) error {
+	active, err := e.seq.SequencerActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check sequencer status: %w", err)
+	}
+	if !active {
+		return nil
+	}
+	hash, err := e.seq.StopSequencer(ctx)
+	if err != nil {
+		return err
+	}
+	e.log.Info("Stopped sequencer", "head", hash)
+	return nil
+}
+
+// handOver stops sequencing, and transfers the leadership to another sequencer.
+// The sequencer is stopped first, so that no payloads are built after the last replicated payload.
+func (e *Election) handOver() {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	if err := e.stopSequencer(ctx); err != nil {
+		e.log.Error("Failed to stop sequencer before handing over leadership", "err", err)
+	}
+	e.transfer()
+}
+
+func (e *Election) transfer() {
+	if err := e.raft.Barrier(barrierTimeout).Error(); err != nil {
+		e.log.Warn("Failed to await replicated state before leadership transfer", "err", err)
+	}
+	if err := e.raft.LeadershipTransfer().Error(); err != nil {
+		e.log.Error("Failed to transfer sequencer leadership", "err", err)
+		return
+	}
+	e.log.Info("Transferred sequencer leadership")
+}
+
+// healthCheck checks whether the local node is still sequencing up to date blocks.
+func (e *Election) healthCheck() error {
+	ctx, cancel := context.WithTimeout(e.ctx, rpcTimeout)
+	defer cancel()
+	status, err := e.seq.SyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status: %w", err)
+	}
+	if e.cfg.MaxUnsafeLag > 0 {
+		lag := e.timeNow().Sub(time.Unix(int64(status.UnsafeL2.Time), 0))
+		if lag > e.cfg.MaxUnsafeLag {
+			return fmt.Errorf("unsafe head %s lags %s behind", status.UnsafeL2, lag)
+		}
+	}
+	return nil
+}
+
+// Close hands over the leadership if this sequencer is the leader, and leaves the election.
+func (e *Election) Close() error {
+	e.cancel()
+	e.wg.Wait()
+	if e.seq != nil && e.Leader() {
+		e.handOver()
+	}
+	var result error
+	if err := e.raft.Shutdown().Error(); err != nil {
+		result = errors.Join(result, fmt.Errorf("failed to shut down raft: %w", err))
+	}
+	for _, c := range e.closers {
+		if err := c.Close(); err != nil {
+			result = errors.Join(result, err)
+		}
+	}
+	return result
+}
+
+// logWriter writes the raft logs to the node logger.
+type logWriter struct {
+	log log.Logger
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.log.Debug(strings.TrimSpace(string(p)), "module", "raft")
+	return len(p), nil
+}
@@ -0,0 +1,231 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeSequencer struct {
+	mu        sync.Mutex
+	head      eth.L2BlockRef
+	active    bool
+	shutter   *bool
+	unhealthy bool
+}
+
+func (s *fakeSequencer) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.unhealthy {
+		return nil, errors.New("unhealthy")
+	}
+	return &eth.SyncStatus{UnsafeL2: s.head}, nil
+}
+
+func (s *fakeSequencer) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.head = eth.L2BlockRef{Hash: payload.BlockHash, Number: uint64(payload.BlockNumber), ParentHash: payload.ParentHash, Time: uint64(payload.Timestamp)}
+	return nil
+}
+
+func (s *fakeSequencer) StartSequencer(ctx context.Context, blockHash common.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active {
+		return errors.New("already active")
+	}
+	if blockHash != s.head.Hash {
+		return fmt.Errorf("block hash %s does not match head %s", blockHash, s.head.Hash)
+	}
+	s.active = true
+	return nil
+}
+
+func (s *fakeSequencer) HandoffSequencer(ctx context.Context, blockHash common.Hash, shutterActive bool) error {
+	if err := s.StartSequencer(ctx, blockHash); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shutter = &shutterActive
+	return nil
+}
+
+func (s *fakeSequencer) StopSequencer(ctx context.Context) (common.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.active {
+		return common.Hash{}, errors.New("not active")
+	}
+	s.active = false
+	return s.head.Hash, nil
+}
+
+func (s *fakeSequencer) SequencerActive(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active, nil
+}
+
+func (s *fakeSequencer) Active() bool {
+	active, _ := s.SequencerActive(context.Background())
+	return active
+}
+
+// sequence builds the next block on top of the head, and commits it to the election.
+func (s *fakeSequencer) sequence(t *testing.T, e *Election, shutterActive bool) *eth.ExecutionPayload {
+	s.mu.Lock()
+	head := s.head
+	s.mu.Unlock()
+	payload := &eth.ExecutionPayload{
+		ParentHash:  head.Hash,
+		BlockNumber: eth.Uint64Quantity(head.Number + 1),
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(head.Number + 1)),
+		Timestamp:   eth.Uint64Quantity(time.Now().Unix()),
+	}
+	require.NoError(t, e.CommitUnsafePayload(context.Background(), payload, shutterActive))
+	require.NoError(t, s.OnUnsafeL2Payload(context.Background(), payload))
+	return payload
+}
+
+type testNode struct {
+	seq      *fakeSequencer
+	election *Election
+}
+
+func setupElection(t *testing.T, n int) []*testNode {
+	var servers []Server
+	var transports []*raft.InmemTransport
+	for i := 0; i < n; i++ {
+		addr, trans := raft.NewInmemTransport("")
+		transports = append(transports, trans)
+		servers = append(servers, Server{ID: fmt.Sprintf("seq-%d", i), Addr: string(addr)})
+	}
+	for _, a := range transports {
+		for _, b := range transports {
+			if a != b {
+				a.Connect(b.LocalAddr(), b)
+			}
+		}
+	}
+
+	var nodes []*testNode
+	for i := 0; i < n; i++ {
+		cfg := &Config{
+			Enabled:              true,
+			ServerID:             servers[i].ID,
+			ListenAddr:           servers[i].Addr,
+			StorageDir:           t.TempDir(),
+			Bootstrap:            i == 0,
+			Servers:              servers,
+			HealthCheckInterval:  50 * time.Millisecond,
+			HealthCheckThreshold: 2,
+		}
+		require.NoError(t, cfg.Check())
+		raftCfg := raft.DefaultConfig()
+		raftCfg.HeartbeatTimeout = 50 * time.Millisecond
+		raftCfg.ElectionTimeout = 50 * time.Millisecond
+		raftCfg.LeaderLeaseTimeout = 50 * time.Millisecond
+		raftCfg.CommitTimeout = 5 * time.Millisecond
+		store := raft.NewInmemStore()
+		logger := testlog.Logger(t, log.LvlError).New("server", cfg.ServerID)
+		e, err := newElection(logger, cfg, raftCfg, store, store, raft.NewInmemSnapshotStore(), transports[i], transports[i])
+		require.NoError(t, err)
+		node := &testNode{seq: new(fakeSequencer), election: e}
+		e.Start(node.seq)
+		t.Cleanup(func() { _ = e.Close() })
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// awaitLeader waits for a single leader that is sequencing, and returns it.
+func awaitLeader(t *testing.T, nodes []*testNode) *testNode {
+	var leader *testNode
+	require.Eventually(t, func() bool {
+		leader = nil
+		for _, n := range nodes {
+			if n.election.Leader() && n.seq.Active() {
+				if leader != nil {
+					return false
+				}
+				leader = n
+			} else if n.seq.Active() {
+				return false
+			}
+		}
+		return leader != nil
+	}, 10*time.Second, 20*time.Millisecond)
+	return leader
+}
+
+func without(nodes []*testNode, node *testNode) []*testNode {
+	var out []*testNode
+	for _, n := range nodes {
+		if n != node {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func TestElectionFailover(t *testing.T) {
+	nodes := setupElection(t, 3)
+	leader := awaitLeader(t, nodes)
+
+	for _, n := range nodes {
+		if n != leader {
+			require.ErrorIs(t, n.election.CommitUnsafePayload(context.Background(), &eth.ExecutionPayload{}, false), ErrNotLeader)
+		}
+	}
+
+	var last *eth.ExecutionPayload
+	for i := 0; i < 3; i++ {
+		last = leader.seq.sequence(t, leader.election, true)
+	}
+	for _, n := range nodes {
+		require.Eventually(t, func() bool {
+			return n.election.State().Payload != nil && n.election.State().Payload.BlockHash == last.BlockHash
+		}, 5*time.Second, 10*time.Millisecond, "payload must be replicated to all sequencers")
+	}
+
+	// The leader leaves, and the next leader continues from the last replicated payload.
+	require.NoError(t, leader.election.Close())
+	require.False(t, leader.seq.Active())
+	next := awaitLeader(t, without(nodes, leader))
+	status, err := next.seq.SyncStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, last.BlockHash, status.UnsafeL2.Hash)
+	require.NotNil(t, next.seq.shutter)
+	require.True(t, *next.seq.shutter)
+}
+
+func TestElectionUnhealthyLeader(t *testing.T) {
+	nodes := setupElection(t, 3)
+	leader := awaitLeader(t, nodes)
+	leader.seq.sequence(t, leader.election, false)
+
+	leader.seq.mu.Lock()
+	leader.seq.unhealthy = true
+	leader.seq.mu.Unlock()
+
+	next := awaitLeader(t, without(nodes, leader))
+	require.False(t, leader.election.Leader())
+	require.False(t, leader.seq.Active())
+	require.NotNil(t, next.seq.shutter)
+	require.False(t, *next.seq.shutter)
+}
@@ -0,0 +1,78 @@
+package election
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SequencerState is the state that is replicated to all sequencers of the sequencer set,
+// for the next leader to continue sequencing where the previous leader stopped.
+type SequencerState struct {
+	// Payload is the latest unsafe payload that was sequenced.
+	Payload *eth.ExecutionPayload `json:"payload"`
+	// ShutterActive is whether shutter is assumed active for the block after the payload.
+	ShutterActive bool `json:"shutterActive"`
+}
+
+// fsm is the raft state machine, which simply holds the latest replicated sequencer state.
+type fsm struct {
+	mu    sync.Mutex
+	state SequencerState
+}
+
+var _ raft.FSM = (*fsm)(nil)
+
+func (f *fsm) Apply(l *raft.Log) interface{} {
+	var state SequencerState
+	if err := json.Unmarshal(l.Data, &state); err != nil {
+		return fmt.Errorf("failed to decode sequencer state at index %d: %w", l.Index, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state = state
+	return nil
+}
+
+func (f *fsm) State() SequencerState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.state
+}
+
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	data, err := json.Marshal(f.State())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sequencer state: %w", err)
+	}
+	return fsmSnapshot(data), nil
+}
+
+func (f *fsm) Restore(snapshot io.ReadCloser) error {
+	defer snapshot.Close()
+	var state SequencerState
+	if err := json.NewDecoder(snapshot).Decode(&state); err != nil {
+		return fmt.Errorf("failed to decode sequencer state snapshot: %w", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state = state
+	return nil
+}
+
+type fsmSnapshot []byte
+
+func (s fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := sink.Write(s); err != nil {
+		_ = sink.Cancel()
+		return fmt.Errorf("failed to write sequencer state snapshot: %w", err)
+	}
+	return sink.Close()
+}
+
+func (s fsmSnapshot) Release() {}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -29,6 +30,11 @@ var (
 		Value:   "http://127.0.0.1:8545",
 		EnvVars: prefixEnvVars("L1_ETH_RPC"),
 	}
+	BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to use. Required once the blobs fork is scheduled in the rollup config.",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
 	L2EngineAddr = &cli.StringFlag{
 		Name:    "l2",
 		Usage:   "Address of L2 Engine JSON-RPC endpoints to use (engine and eth namespace required)",
@@ -47,7 +53,7 @@ var (
 	/* Optional Flags */
 	ShutterGRPCAddress = &cli.StringFlag{
 		Name:    "shutter.grpc-address",
-		Usage:   "Address of the shutter-node gRPC server",
+		Usage:   "Address of the shutter-node gRPC server. Required when sequencing and shutter is scheduled in the rollup config",
 		EnvVars: prefixEnvVars("SHUTTER"),
 	}
 	RPCListenAddr = &cli.StringFlag{
@@ -106,6 +112,18 @@ var (
 		EnvVars: prefixEnvVars("L1_RPC_MAX_BATCH_SIZE"),
 		Value:   20,
 	}
+	L1PrefetchDepth = &cli.Uint64Flag{
+		Name:    "l1.prefetch-depth",
+		Usage:   "Number of L1 blocks to prefetch the headers, transactions and receipts of, ahead of the derivation pipeline. Speeds up catching up with L1. Disabled if set to 0.",
+		EnvVars: prefixEnvVars("L1_PREFETCH_DEPTH"),
+		Value:   0,
+	}
+	L1PrefetchConcurrency = &cli.IntFlag{
+		Name:    "l1.prefetch-concurrency",
+		Usage:   "Maximum number of concurrent L1 block and receipts requests made for prefetching.",
+		EnvVars: prefixEnvVars("L1_PREFETCH_CONCURRENCY"),
+		Value:   4,
+	}
 	L1HTTPPollInterval = &cli.DurationFlag{
 		Name:    "l1.http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider. Ignored for other types of RPC endpoints.",
@@ -144,6 +162,11 @@ var (
 		Required: false,
 		Value:    0,
 	}
+	SequencerPipelinedFlag = &cli.BoolFlag{
+		Name:    "sequencer.pipelined",
+		Usage:   "Prepare the inputs of the next L2 block, like L1 deposits and the shutter decryption key, while the current block is being built.",
+		EnvVars: prefixEnvVars("SEQUENCER_PIPELINED"),
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -151,6 +174,54 @@ var (
 		Required: false,
 		Value:    4,
 	}
+	SequencerElectionEnabledFlag = &cli.BoolFlag{
+		Name:    "sequencer.election.enabled",
+		Usage:   "Run the sequencer as part of a sequencer set, in which only the elected leader sequences. Overrides the sequencer.stopped flag and persisted sequencer state.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_ENABLED"),
+	}
+	SequencerElectionServerIDFlag = &cli.StringFlag{
+		Name:    "sequencer.election.server-id",
+		Usage:   "Unique and stable ID of this sequencer in the sequencer set.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_SERVER_ID"),
+	}
+	SequencerElectionListenAddrFlag = &cli.StringFlag{
+		Name:    "sequencer.election.listen-addr",
+		Usage:   "host:port to listen on for leader election traffic, which is also advertised to the other sequencers.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_LISTEN_ADDR"),
+	}
+	SequencerElectionStorageDirFlag = &cli.StringFlag{
+		Name:    "sequencer.election.storage-dir",
+		Usage:   "Directory to persist the leader election log and snapshots in.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_STORAGE_DIR"),
+	}
+	SequencerElectionBootstrapFlag = &cli.BoolFlag{
+		Name:    "sequencer.election.bootstrap",
+		Usage:   "Bootstrap a new sequencer set with the sequencer.election.servers. Only needs to be set on one sequencer, and is ignored if the sequencer set exists already.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_BOOTSTRAP"),
+	}
+	SequencerElectionServersFlag = &cli.StringSliceFlag{
+		Name:    "sequencer.election.servers",
+		Usage:   "Members of the sequencer set to bootstrap with, including this sequencer, as comma-separated <id>=<host>:<port> entries.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_SERVERS"),
+	}
+	SequencerElectionHealthIntervalFlag = &cli.DurationFlag{
+		Name:    "sequencer.election.health-interval",
+		Usage:   "Interval between health checks of the sequencer, while it is the leader.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_HEALTH_INTERVAL"),
+		Value:   5 * time.Second,
+	}
+	SequencerElectionHealthThresholdFlag = &cli.Uint64Flag{
+		Name:    "sequencer.election.health-threshold",
+		Usage:   "Number of consecutive failed health checks after which the leader hands over the leadership.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_HEALTH_THRESHOLD"),
+		Value:   3,
+	}
+	SequencerElectionMaxUnsafeLagFlag = &cli.DurationFlag{
+		Name:    "sequencer.election.max-unsafe-lag",
+		Usage:   "Maximum time the unsafe head of a healthy leader may lag behind the wall clock. Disabled if 0.",
+		EnvVars: prefixEnvVars("SEQUENCER_ELECTION_MAX_UNSAFE_LAG"),
+		Value:   30 * time.Second,
+	}
 	L1EpochPollIntervalFlag = &cli.DurationFlag{
 		Name:     "l1.epoch-poll-interval",
 		Usage:    "Poll interval for retrieving new L1 epoch updates such as safe and finalized block changes. Disabled if 0 or negative.",
@@ -264,6 +335,22 @@ var (
 		Usage:   "Load protocol versions from the superchain L1 ProtocolVersions contract (if available), and report in logs and metrics",
 		EnvVars: prefixEnvVars("ROLLUP_LOAD_PROTOCOL_VERSIONS"),
 	}
+	SafeDBPath = &cli.StringFlag{
+		Name:    "safedb.path",
+		Usage:   "File path used to persist the safe head derived from each L1 block. Disabled if not set.",
+		EnvVars: prefixEnvVars("SAFEDB_PATH"),
+	}
+	SafeDBRetention = &cli.Uint64Flag{
+		Name:    "safedb.retention",
+		Usage:   "Number of L1 blocks to keep safe head records for in the safe head database. 0 keeps all records.",
+		EnvVars: prefixEnvVars("SAFEDB_RETENTION"),
+		Value:   0,
+	}
+	DerivationTracePath = &cli.StringFlag{
+		Name:    "derivation.trace-path",
+		Usage:   "File path to record a trace of the derivation pipeline stages to, for replay with the 'replay' subcommand. Disabled if not set.",
+		EnvVars: prefixEnvVars("DERIVATION_TRACE_PATH"),
+	}
 	CanyonOverrideFlag = &cli.Uint64Flag{
 		Name:    "override.canyon",
 		Usage:   "Manually specify the Canyon fork timestamp, overriding the bundled setting",
@@ -278,6 +365,7 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	BeaconAddr,
 	ShutterGRPCAddress,
 	RPCListenAddr,
 	RPCListenPort,
@@ -287,13 +375,25 @@ var optionalFlags = []cli.Flag{
 	L1RPCProviderKind,
 	L1RPCRateLimit,
 	L1RPCMaxBatchSize,
+	L1PrefetchDepth,
+	L1PrefetchConcurrency,
 	L1HTTPPollInterval,
 	L2EngineJWTSecret,
 	VerifierL1Confs,
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerPipelinedFlag,
 	SequencerL1Confs,
+	SequencerElectionEnabledFlag,
+	SequencerElectionServerIDFlag,
+	SequencerElectionListenAddrFlag,
+	SequencerElectionStorageDirFlag,
+	SequencerElectionBootstrapFlag,
+	SequencerElectionServersFlag,
+	SequencerElectionHealthIntervalFlag,
+	SequencerElectionHealthThresholdFlag,
+	SequencerElectionMaxUnsafeLagFlag,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
@@ -317,6 +417,9 @@ var optionalFlags = []cli.Flag{
 	RollupLoadProtocolVersions,
 	CanyonOverrideFlag,
 	L1RethDBPath,
+	SafeDBPath,
+	SafeDBRetention,
+	DerivationTracePath,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -325,6 +428,7 @@ var Flags []cli.Flag
 func init() {
 	optionalFlags = append(optionalFlags, P2PFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix)...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
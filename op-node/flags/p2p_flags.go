@@ -53,6 +53,7 @@ var (
 	GossipMeshDlazyName    = "p2p.gossip.mesh.dlazy"
 	GossipFloodPublishName = "p2p.gossip.mesh.floodpublish"
 	SyncReqRespName        = "p2p.sync.req-resp"
+	GossipShutterKeysName  = "p2p.gossip.shutter-keys"
 )
 
 // None of these flags are strictly required.
@@ -348,5 +349,11 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVars:  p2pEnv(envPrefix, "SYNC_REQ_RESP"),
 		},
+		&cli.BoolFlag{
+			Name:     GossipShutterKeysName,
+			Usage:    "Gossip the shutter decryption keys used by the sequencer, to use them before the batches are submitted to L1.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_SHUTTER_KEYS"),
+		},
 	}
 }
@@ -2,12 +2,15 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	lru "github.com/hashicorp/golang-lru/v2"
 
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -32,6 +35,11 @@ type driverClient interface {
 	SequencerActive(context.Context) (bool, error)
 }
 
+// SafeDBReader provides the L2 safe head that was derived from a given L1 block.
+type SafeDBReader interface {
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error)
+}
+
 type adminAPI struct {
 	*rpc.CommonAdminAPI
 	dr driverClient
@@ -72,15 +80,17 @@ type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
+	safeDB SafeDBReader
 	log    log.Logger
 	m      metrics.RPCMetricer
 }
 
-func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
+func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
 	return &nodeAPI{
 		config: config,
 		client: l2Client,
 		dr:     dr,
+		safeDB: safeDB,
 		log:    log,
 		m:      m,
 	}
@@ -109,6 +119,21 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 	}, nil
 }
 
+func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadAtL1Block")
+	defer recordDur()
+	l1Block, safeHead, err := n.safeDB.SafeHeadAtL1(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get safe head at l1 block %s: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
@@ -126,3 +151,24 @@ func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
+
+type shutterKeyAPI struct {
+	keys *lru.Cache[common.Hash, *eth.ShutterKey]
+	m    metrics.RPCMetricer
+}
+
+func NewShutterKeyAPI(keys *lru.Cache[common.Hash, *eth.ShutterKey], m metrics.RPCMetricer) *shutterKeyAPI {
+	return &shutterKeyAPI{
+		keys: keys,
+		m:    m,
+	}
+}
+
+// ShutterKeyByBlockHash returns the shutter decryption key the sequencer built the given block with,
+// or nil if no key was received for the block (yet).
+func (n *shutterKeyAPI) ShutterKeyByBlockHash(_ context.Context, hash common.Hash) (*eth.ShutterKey, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_shutterKeyByBlockHash")
+	defer recordDur()
+	key, _ := n.keys.Get(hash)
+	return key, nil
+}
@@ -36,6 +36,12 @@ type L1EndpointSetup interface {
 	Check() error
 }
 
+type L1BeaconEndpointSetup interface {
+	// Setup a HTTP client to a L1 beacon node to retrieve the blobs of the rollup input-data from.
+	Setup(ctx context.Context, log log.Logger) (cl client.HTTP, err error)
+	Check() error
+}
+
 type L2EndpointConfig struct {
 	L2EngineAddr string // Address of L2 Engine JSON-RPC endpoint to use (engine and eth namespace required)
 
@@ -151,6 +157,12 @@ type L1EndpointConfig struct {
 	// BatchSize specifies the maximum batch-size, which also applies as L1 rate-limit burst amount (if set).
 	BatchSize int
 
+	// PrefetchDepth specifies the number of L1 blocks to prefetch ahead of the derivation pipeline. 0 disables prefetching.
+	PrefetchDepth uint64
+
+	// PrefetchConcurrency specifies the maximum number of concurrent prefetching requests.
+	PrefetchConcurrency int
+
 	// HttpPollInterval specifies the interval between polling for the latest L1 block,
 	// when the RPC is detected to be an HTTP type.
 	// It is recommended to use websockets or IPC for efficient following of the changing block.
@@ -167,6 +179,9 @@ func (cfg *L1EndpointConfig) Check() error {
 	if cfg.RateLimit < 0 {
 		return fmt.Errorf("rate limit cannot be negative")
 	}
+	if cfg.PrefetchDepth > 0 && cfg.PrefetchConcurrency < 1 {
+		return fmt.Errorf("prefetch concurrency must be at least 1, got %d", cfg.PrefetchConcurrency)
+	}
 	return nil
 }
 
@@ -185,6 +200,8 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	}
 	rpcCfg := sources.L1ClientDefaultConfig(rollupCfg, cfg.L1TrustRPC, cfg.L1RPCKind)
 	rpcCfg.MaxRequestsPerBatch = cfg.BatchSize
+	rpcCfg.PrefetchDepth = cfg.PrefetchDepth
+	rpcCfg.PrefetchConcurrency = cfg.PrefetchConcurrency
 	return l1Node, rpcCfg, nil
 }
 
@@ -208,3 +225,20 @@ func (cfg *PreparedL1Endpoint) Check() error {
 
 	return nil
 }
+
+type L1BeaconEndpointConfig struct {
+	BeaconAddr string // Address of L1 Beacon-node HTTP endpoint to use (beacon namespace required)
+}
+
+var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
+
+func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (client.HTTP, error) {
+	return client.NewBasicHTTPClient(cfg.BeaconAddr, log), nil
+}
+
+func (cfg *L1BeaconEndpointConfig) Check() error {
+	if cfg.BeaconAddr == "" {
+		return errors.New("empty L1 Beacon endpoint address")
+	}
+	return nil
+}
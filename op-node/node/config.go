@@ -7,6 +7,8 @@ import (
 	"math"
 	"time"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/election"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -22,6 +24,12 @@ type Config struct {
 	L2     L2EndpointSetup
 	L2Sync L2SyncEndpointSetup
 
+	// Beacon is only required once the blobs fork is scheduled in the rollup config.
+	Beacon L1BeaconEndpointSetup
+
+	// AltDA is only required once the alt-DA fork is scheduled in the rollup config.
+	AltDA altda.CLIConfig
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -65,6 +73,17 @@ type Config struct {
 	// [OPTIONAL] The reth DB path to read receipts from
 	RethDBPath string
 
+	// [OPTIONAL] The path of the database recording the safe head derived from each L1 block. Disabled if empty.
+	SafeDBPath string
+	// SafeDBRetention is the number of L1 blocks to keep safe head records for. 0 keeps all records.
+	SafeDBRetention uint64
+
+	// [OPTIONAL] The file to record a trace of the derivation pipeline stages to. Disabled if empty.
+	DerivationTracePath string
+
+	// Election runs the sequencer as part of a sequencer set, in which only the elected leader sequences.
+	Election election.Config
+
 	Shutter shutter.Config
 }
 
@@ -106,6 +125,11 @@ func (cfg *Config) LoadPersisted(log log.Logger) error {
 	if !cfg.Driver.SequencerEnabled {
 		return nil
 	}
+	if cfg.Election.Enabled {
+		// The election starts the sequencer once elected as leader, regardless of the persisted state.
+		cfg.Driver.SequencerStopped = true
+		return nil
+	}
 	if state, err := cfg.ConfigPersistence.SequencerState(); err != nil {
 		return err
 	} else if state != StateUnset {
@@ -131,6 +155,22 @@ func (cfg *Config) Check() error {
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if cfg.Rollup.BlobsTime != nil {
+		if cfg.Beacon == nil {
+			return errors.New("the blobs fork is scheduled, but no L1 beacon endpoint is configured")
+		}
+		if err := cfg.Beacon.Check(); err != nil {
+			return fmt.Errorf("beacon endpoint config error: %w", err)
+		}
+	}
+	if cfg.Rollup.AltDATime != nil {
+		if !cfg.AltDA.Enabled {
+			return errors.New("the alt-DA fork is scheduled, but alt-DA is not enabled")
+		}
+		if err := cfg.AltDA.Check(); err != nil {
+			return fmt.Errorf("alt-DA config error: %w", err)
+		}
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
@@ -146,7 +186,13 @@ func (cfg *Config) Check() error {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
 
-	if err := cfg.Shutter.Check(); err != nil {
+	if cfg.Election.Enabled && !cfg.Driver.SequencerEnabled {
+		return errors.New("sequencer election requires the sequencer to be enabled")
+	}
+	if err := cfg.Election.Check(); err != nil {
+		return fmt.Errorf("sequencer election config error: %w", err)
+	}
+	if err := cfg.Shutter.Check(&cfg.Rollup, cfg.Driver.SequencerEnabled); err != nil {
 		return fmt.Errorf("shutter config error: %w", err)
 	}
 	return nil
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"os"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -12,15 +14,21 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 
 	"github.com/hashicorp/go-multierror"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/election"
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -31,6 +39,16 @@ import (
 	shclient "github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/client"
 )
 
+// shutterKeysCacheSize is the number of recent shutter keys that are kept to serve over RPC.
+const shutterKeysCacheSize = 1000
+
+// closableSafeDB is the safe head database, written to by the derivation pipeline and read by the RPC server.
+type closableSafeDB interface {
+	derive.SafeHeadListener
+	SafeDBReader
+	io.Closer
+}
+
 type OpNode struct {
 	log        log.Logger
 	appVersion string
@@ -40,16 +58,25 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	rpcSync   *sources.SyncClient   // Alt-sync RPC client, optional (may be nil)
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1Source  *sources.L1Client       // L1 Client to fetch data from
+	beacon    *sources.L1BeaconClient // L1 Beacon client to fetch blobs from, nil until the blobs fork is scheduled
+	altDA     *altda.DAClient         // DA server client to resolve alt-DA commitments, nil until the alt-DA fork is scheduled
+	l2Driver  *driver.Driver          // L2 Engine to Sync
+	l2Source  *sources.EngineClient   // L2 Execution Engine RPC bindings
+	rpcSync   *sources.SyncClient     // Alt-sync RPC client, optional (may be nil)
+	server    *rpcServer              // RPC server hosting the rollup-node API
+	p2pNode   *p2p.NodeP2P            // P2P node functionality
+	p2pSigner p2p.Signer              // p2p gogssip application messages will be signed with this signer
+	tracer    Tracer                  // tracer to get events for testing/debugging
+	runCfg    *RuntimeConfig          // runtime configurables
 	shutter   *shclient.Client
+	safeDB    closableSafeDB // Records the safe head derived from each L1 block, may be disabled
+
+	derivationTrace *derive.JSONTracer // Records the derivation pipeline stages, nil if disabled
+
+	election *election.Election // Elects the leader of the sequencer set, nil if disabled
+
+	shutterKeys *lru.Cache[common.Hash, *eth.ShutterKey] // Recent shutter keys by block hash, nil if shutter key gossip is disabled
 
 	rollupHalt string // when to halt the rollup, disabled if empty
 
@@ -112,6 +139,10 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
+	if err := n.initL1BeaconAPI(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init the L1 beacon API client: %w", err)
+	}
+	n.initAltDA(cfg)
 	if err := n.initShutter(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the shutter client: %w", err)
 	}
@@ -198,6 +229,35 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
+	if cfg.Rollup.BlobsTime == nil {
+		return nil
+	}
+	httpClient, err := cfg.Beacon.Setup(ctx, n.log)
+	if err != nil {
+		return fmt.Errorf("failed to setup L1 beacon client: %w", err)
+	}
+	n.beacon = sources.NewL1BeaconClient(httpClient)
+
+	// Try to fetch the version, as a sanity check that the beacon node is reachable.
+	// Only warn on failure, the beacon node may still be starting up.
+	version, err := n.beacon.GetVersion(ctx)
+	if err != nil {
+		n.log.Warn("failed to check L1 beacon API version", "err", err)
+	} else {
+		n.log.Info("connected to L1 beacon API", "version", version)
+	}
+	return nil
+}
+
+func (n *OpNode) initAltDA(cfg *Config) {
+	if cfg.Rollup.AltDATime == nil {
+		return
+	}
+	n.altDA = cfg.AltDA.NewDAClient()
+	n.log.Info("resolving alt-DA commitments with DA server", "url", cfg.AltDA.DAServerURL)
+}
+
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
 	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
@@ -309,12 +369,70 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync, n.shutter)
+	if cfg.SafeDBPath != "" {
+		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath, "retention", cfg.SafeDBRetention)
+		safeDB, err := safedb.NewSafeDB(n.log, cfg.SafeDBPath, cfg.SafeDBRetention)
+		if err != nil {
+			return fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
+		}
+		n.safeDB = safeDB
+	} else {
+		n.safeDB = safedb.Disabled
+	}
+
+	tracer := derive.NoopTracer
+	if cfg.DerivationTracePath != "" {
+		n.log.Warn("Derivation tracing enabled, the trace grows with all derived data", "path", cfg.DerivationTracePath)
+		f, err := os.OpenFile(cfg.DerivationTracePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open derivation trace file %v: %w", cfg.DerivationTracePath, err)
+		}
+		n.derivationTrace = derive.NewJSONTracer(f)
+		tracer = n.derivationTrace
+	}
+
+	var conductor driver.SequencerConductor = driver.NoopConductor{}
+	if cfg.Election.Enabled {
+		n.log.Info("Sequencer election enabled", "server_id", cfg.Election.ServerID, "listen_addr", cfg.Election.ListenAddr)
+		n.election, err = election.New(n.log.New("module", "election"), &cfg.Election)
+		if err != nil {
+			return fmt.Errorf("failed to create sequencer election: %w", err)
+		}
+		conductor = n.election
+	}
+
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.l1BlobsFetcher(), n.altDAFetcher(), n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, conductor, n.safeDB, tracer, &cfg.Sync, n.shutter)
 
 	return nil
 }
 
+// l1BlobsFetcher returns the beacon client as derivation blobs fetcher,
+// or nil if there is none, to not pass a typed nil pointer.
+func (n *OpNode) l1BlobsFetcher() derive.L1BlobsFetcher {
+	if n.beacon == nil {
+		return nil
+	}
+	return n.beacon
+}
+
+// altDAFetcher returns the DA server client as derivation alt-DA fetcher,
+// or nil if there is none, to not pass a typed nil pointer.
+func (n *OpNode) altDAFetcher() derive.AltDAInputFetcher {
+	if n.altDA == nil {
+		return nil
+	}
+	return n.altDA
+}
+
 func (n *OpNode) initShutter(ctx context.Context, cfg *Config) error {
+	if !cfg.Shutter.Required(&cfg.Rollup, cfg.Driver.SequencerEnabled) {
+		if cfg.Shutter.ServerAddress != "" {
+			n.log.Warn("not sequencing or shutter not scheduled, ignoring the shutter-node address", "address", cfg.Shutter.ServerAddress)
+		} else {
+			n.log.Info("shutter disabled")
+		}
+		return nil
+	}
 	c, err := cfg.Shutter.Setup()
 	if err != nil {
 		return fmt.Errorf("failed to setup shutter grpc-client condig: %w", err)
@@ -344,13 +462,17 @@ func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
+	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.safeDB, n.log, n.appVersion, n.metrics)
 	if err != nil {
 		return err
 	}
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
+	if n.shutterKeys != nil {
+		server.EnableShutterKeyAPI(NewShutterKeyAPI(n.shutterKeys, n.metrics))
+		n.log.Info("Shutter key RPC enabled")
+	}
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
@@ -425,6 +547,12 @@ func (n *OpNode) initP2P(ctx context.Context, cfg *Config) error {
 			return err
 		}
 		n.p2pNode = p2pNode
+		if cfg.P2P.ShutterKeyGossipEnabled() {
+			n.shutterKeys, err = lru.New[common.Hash, *eth.ShutterKey](shutterKeysCacheSize)
+			if err != nil {
+				return fmt.Errorf("failed to create shutter keys cache: %w", err)
+			}
+		}
 		if n.p2pNode.Dv5Udp() != nil {
 			go n.p2pNode.DiscoveryProcess(n.resourcesCtx, n.log, &cfg.Rollup, cfg.P2P.TargetPeers())
 		}
@@ -452,6 +580,12 @@ func (n *OpNode) Start(ctx context.Context) error {
 		return err
 	}
 
+	// Follow the sequencer election, which starts the sequencer once elected as leader
+	if n.election != nil {
+		n.election.Start(n.l2Driver)
+		n.log.Info("Started sequencer election")
+	}
+
 	// If the backup unsafe sync client is enabled, start its event loop
 	if n.rpcSync != nil {
 		if err := n.rpcSync.Start(); err != nil {
@@ -539,6 +673,31 @@ func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, payload *e
 	return nil
 }
 
+func (n *OpNode) PublishShutterKey(ctx context.Context, key *eth.ShutterKey) error {
+	if n.shutterKeys == nil {
+		return nil
+	}
+	n.shutterKeys.Add(key.BlockHash, key)
+	if n.p2pSigner == nil {
+		return fmt.Errorf("node has no p2p signer, shutter key of %s cannot be published", key.ID())
+	}
+	n.log.Info("Publishing signed shutter key on p2p", "id", key.ID())
+	return n.p2pNode.GossipOut().PublishShutterKey(ctx, key, n.p2pSigner)
+}
+
+func (n *OpNode) OnShutterKey(ctx context.Context, from peer.ID, key *eth.ShutterKey) error {
+	// ignore if it's from ourselves
+	if n.p2pNode != nil && from == n.p2pNode.Host().ID() {
+		return nil
+	}
+	if n.shutterKeys == nil {
+		return nil
+	}
+	n.log.Info("Received signed shutter key from p2p", "id", key.ID(), "peer", from)
+	n.shutterKeys.Add(key.BlockHash, key)
+	return nil
+}
+
 func (n *OpNode) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
 	if n.rpcSync != nil {
 		return n.rpcSync.RequestL2Range(ctx, start, end)
@@ -601,6 +760,13 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		n.l1HeadsSub.Unsubscribe()
 	}
 
+	// leave the sequencer election, handing over the leadership while the driver can still be stopped
+	if n.election != nil {
+		if err := n.election.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close sequencer election: %w", err))
+		}
+	}
+
 	// close L2 driver
 	if n.l2Driver != nil {
 		if err := n.l2Driver.Close(); err != nil {
@@ -615,6 +781,20 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	// close the safe head database, after the driver stopped writing to it
+	if n.safeDB != nil {
+		if err := n.safeDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
+		}
+	}
+
+	// flush the derivation trace, after the driver stopped writing to it
+	if n.derivationTrace != nil {
+		if err := n.derivationTrace.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close derivation trace: %w", err))
+		}
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
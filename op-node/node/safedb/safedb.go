@@ -0,0 +1,219 @@
+package safedb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEntry = errors.New("invalid db entry")
+
+	errDBClosed = errors.New("safe head db closed")
+)
+
+const (
+	// Keys are prefixed with a byte to allow for future expansion of the db.
+	keyPrefixSafeByL1BlockNum byte = 0
+
+	// pruneInterval is the number of L1 blocks the retention cut-off must advance
+	// by before old entries are pruned again. Pruning in batches avoids writing
+	// a range tombstone for every single new L1 block.
+	pruneInterval = 100
+)
+
+var (
+	safeByL1BlockNumKey = uint64Key{prefix: keyPrefixSafeByL1BlockNum}
+)
+
+type uint64Key struct {
+	prefix byte
+}
+
+func (c uint64Key) Of(num uint64) []byte {
+	key := make([]byte, 0, 9)
+	key = append(key, c.prefix)
+	key = binary.BigEndian.AppendUint64(key, num)
+	return key
+}
+
+func (c uint64Key) Max() []byte {
+	return c.Of(math.MaxUint64)
+}
+
+// SafeDB is an on-disk record of the L2 safe head that was derived after processing each L1 block.
+// Entries are keyed by L1 block number, so the safe head at any L1 block is the entry
+// with the highest L1 block number that is less than or equal to it.
+type SafeDB struct {
+	log log.Logger
+
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m  sync.RWMutex
+	db *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	// retention is the number of L1 blocks to keep entries for, 0 to keep all entries.
+	retention uint64
+	// prunedTo is the L1 block number before which all entries were pruned.
+	prunedTo uint64
+
+	closed bool
+}
+
+func NewSafeDB(logger log.Logger, path string, retention uint64) (*SafeDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open safe head db at %v: %w", path, err)
+	}
+	return &SafeDB{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+		retention: retention,
+	}, nil
+}
+
+func (d *SafeDB) Enabled() bool {
+	return true
+}
+
+// SafeHeadUpdated records that the given L2 safe head was derived after processing the given L1 block.
+func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return errDBClosed
+	}
+	d.log.Debug("Record safe head", "l2", safeHead.ID(), "l1", l1Head)
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(safeByL1BlockNumKey.Of(l1Head.Number), safeByL1BlockNumValue(l1Head, safeHead.ID()), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record safe head update: %w", err)
+	}
+	var pruneTo uint64
+	if d.retention > 0 && l1Head.Number > d.retention {
+		if cutoff := l1Head.Number - d.retention; cutoff >= d.prunedTo+pruneInterval {
+			if err := batch.DeleteRange(safeByL1BlockNumKey.Of(0), safeByL1BlockNumKey.Of(cutoff), d.writeOpts); err != nil {
+				return fmt.Errorf("failed to prune safe head entries before L1 block %v: %w", cutoff, err)
+			}
+			pruneTo = cutoff
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit safe head update: %w", err)
+	}
+	if pruneTo > 0 {
+		d.log.Debug("Pruned safe head entries", "before_l1", pruneTo)
+		d.prunedTo = pruneTo
+	}
+	return nil
+}
+
+// SafeHeadReset removes all entries for L2 blocks after the given safe head,
+// as they are no longer canonical after a reset of the derivation pipeline.
+func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return errDBClosed
+	}
+	iter, err := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey.Of(safeHead.L1Origin.Number),
+		UpperBound: safeByL1BlockNumKey.Max(),
+	})
+	if err != nil {
+		return fmt.Errorf("reset failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		l1Block, l2Block, err := decodeSafeByL1BlockNum(iter.Key(), iter.Value())
+		if err != nil {
+			return fmt.Errorf("reset failed to decode entry: %w", err)
+		}
+		if l2Block.Number >= safeHead.Number {
+			// The key is copied, as the iterator may reuse its key buffer.
+			from := append([]byte(nil), iter.Key()...)
+			d.log.Info("Truncating safe head db", "from_l1", l1Block, "l2", l2Block, "reset_safe_head", safeHead.ID())
+			if err := d.db.DeleteRange(from, safeByL1BlockNumKey.Max(), d.writeOpts); err != nil {
+				return fmt.Errorf("failed to truncate safe head entries: %w", err)
+			}
+			return nil
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("reset failed to iterate entries: %w", err)
+	}
+	// No entries at or after the reset safe head, nothing to truncate.
+	return nil
+}
+
+// SafeHeadAtL1 returns the L2 safe head derived after processing the given L1 block,
+// along with the L1 block that the safe head was last updated at.
+// Returns ErrNotFound if no safe head was recorded at or before the L1 block.
+func (d *SafeDB) SafeHeadAtL1(_ context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return eth.BlockID{}, eth.BlockID{}, errDBClosed
+	}
+	upperBound := safeByL1BlockNumKey.Max()
+	if l1BlockNum < math.MaxUint64 {
+		upperBound = safeByL1BlockNumKey.Of(l1BlockNum + 1)
+	}
+	iter, err := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey.Of(0),
+		UpperBound: upperBound,
+	})
+	if err != nil {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	if valid := iter.Last(); !valid {
+		if err := iter.Error(); err != nil {
+			return eth.BlockID{}, eth.BlockID{}, err
+		}
+		return eth.BlockID{}, eth.BlockID{}, ErrNotFound
+	}
+	return decodeSafeByL1BlockNum(iter.Key(), iter.Value())
+}
+
+func (d *SafeDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
+
+func safeByL1BlockNumValue(l1 eth.BlockID, l2 eth.BlockID) []byte {
+	val := make([]byte, 0, 72)
+	val = append(val, l1.Hash.Bytes()...)
+	val = append(val, l2.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, l2.Number)
+	return val
+}
+
+func decodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.BlockID, err error) {
+	if len(key) != 9 || len(val) != 72 || key[0] != keyPrefixSafeByL1BlockNum {
+		err = ErrInvalidEntry
+		return
+	}
+	copy(l1.Hash[:], val[:32])
+	l1.Number = binary.BigEndian.Uint64(key[1:])
+	copy(l2.Hash[:], val[32:64])
+	l2.Number = binary.BigEndian.Uint64(val[64:])
+	return
+}
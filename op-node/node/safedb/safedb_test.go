@@ -0,0 +1,147 @@
+package safedb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestStoreSafeHeads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir, 0)
+	require.NoError(t, err)
+	defer db.Close()
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 25}
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b))
+
+	verifySafeHeads := func(db *SafeDB) {
+		_, _, err = db.SafeHeadAtL1(context.Background(), l1a.Number-1)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), l1a.Number)
+		require.NoError(t, err)
+		require.Equal(t, l1a, actualL1)
+		require.Equal(t, l2a.ID(), actualL2)
+
+		actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1a.Number+1)
+		require.NoError(t, err)
+		require.Equal(t, l1a, actualL1)
+		require.Equal(t, l2a.ID(), actualL2)
+
+		actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1b.Number)
+		require.NoError(t, err)
+		require.Equal(t, l1b, actualL1)
+		require.Equal(t, l2b.ID(), actualL2)
+
+		actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), math.MaxUint64)
+		require.NoError(t, err)
+		require.Equal(t, l1b, actualL1)
+		require.Equal(t, l2b.ID(), actualL2)
+	}
+	verifySafeHeads(db)
+
+	// Data is persisted across restarts
+	require.NoError(t, db.Close())
+	db, err = NewSafeDB(logger, dir, 0)
+	require.NoError(t, err)
+	verifySafeHeads(db)
+}
+
+func TestSafeHeadUpdatedOverwritesL1Block(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir(), 0)
+	require.NoError(t, err)
+	defer db.Close()
+	l1 := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 21}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1))
+
+	actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), l1.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1, actualL1)
+	require.Equal(t, l2b.ID(), actualL2)
+}
+
+func TestSafeHeadReset(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir(), 0)
+	require.NoError(t, err)
+	defer db.Close()
+
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20, L1Origin: eth.BlockID{Number: 60}}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 22, L1Origin: eth.BlockID{Number: 90}}
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 25, L1Origin: eth.BlockID{Number: 110}}
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 110}
+	l1c := eth.BlockID{Hash: common.Hash{0x01, 0xcc}, Number: 120}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b))
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1c))
+
+	// Reset to just before l2b: the entries for l2b and l2c are no longer canonical
+	resetHead := eth.L2BlockRef{Hash: common.Hash{0x02, 0xdd}, Number: 21, L1Origin: eth.BlockID{Number: 80}}
+	require.NoError(t, db.SafeHeadReset(resetHead))
+
+	actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), l1c.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1a, actualL1)
+	require.Equal(t, l2a.ID(), actualL2)
+
+	// Resetting to a safe head after all entries keeps all entries
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1c))
+	require.NoError(t, db.SafeHeadReset(eth.L2BlockRef{Number: 30, L1Origin: eth.BlockID{Number: 130}}))
+	actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1c.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1c, actualL1)
+	require.Equal(t, l2c.ID(), actualL2)
+
+	// Resetting to before the first entry removes all entries
+	require.NoError(t, db.SafeHeadReset(eth.L2BlockRef{Number: 10, L1Origin: eth.BlockID{Number: 30}}))
+	_, _, err = db.SafeHeadAtL1(context.Background(), l1c.Number)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSafeHeadRetention(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir(), 10)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := uint64(1); i <= pruneInterval+10; i++ {
+		l1 := eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: i}
+		l2 := eth.L2BlockRef{Hash: common.Hash{0x02, byte(i)}, Number: i * 2}
+		require.NoError(t, db.SafeHeadUpdated(l2, l1))
+	}
+
+	// Entries before the retention cut-off were pruned
+	_, _, err = db.SafeHeadAtL1(context.Background(), pruneInterval-1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Entries within the retention window are kept
+	actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), pruneInterval)
+	require.NoError(t, err)
+	require.Equal(t, uint64(pruneInterval), actualL1.Number)
+	require.Equal(t, uint64(pruneInterval*2), actualL2.Number)
+}
+
+func TestDisabled(t *testing.T) {
+	require.False(t, Disabled.Enabled())
+	require.NoError(t, Disabled.SafeHeadUpdated(eth.L2BlockRef{}, eth.BlockID{}))
+	require.NoError(t, Disabled.SafeHeadReset(eth.L2BlockRef{}))
+	_, _, err := Disabled.SafeHeadAtL1(context.Background(), 100)
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
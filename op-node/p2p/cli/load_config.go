@@ -65,6 +65,7 @@ func NewConfig(ctx *cli.Context, rollupCfg *rollup.Config) (*p2p.Config, error)
 	}
 
 	conf.EnableReqRespSync = ctx.Bool(flags.SyncReqRespName)
+	conf.EnableShutterKeyGossip = ctx.Bool(flags.GossipShutterKeysName)
 
 	return conf, nil
 }
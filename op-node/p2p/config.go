@@ -50,6 +50,7 @@ type SetupP2P interface {
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	ShutterKeyGossipEnabled() bool
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
@@ -118,6 +119,9 @@ type Config struct {
 	Store ds.Batching
 
 	EnableReqRespSync bool
+
+	// EnableShutterKeyGossip joins the gossip topic of the shutter decryption keys used by the sequencer.
+	EnableShutterKeyGossip bool
 }
 
 func DefaultConnManager(conf *Config) (connmgr.ConnManager, error) {
@@ -160,6 +164,10 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) ShutterKeyGossipEnabled() bool {
+	return conf.EnableShutterKeyGossip
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
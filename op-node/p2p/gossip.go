@@ -45,6 +45,12 @@ const (
 	peerScoreInspectFrequency = 15 * time.Second
 )
 
+const (
+	// shutterKeyMinMessageSize and shutterKeyMaxMessageSize bound the size of an encoded shutter key, excluding the signature.
+	shutterKeyMinMessageSize = 32 + 8 + 8
+	shutterKeyMaxMessageSize = shutterKeyMinMessageSize + eth.MaxShutterKeySize
+)
+
 // Message domains, the msg id function uncompresses to keep data monomorphic,
 // but invalid compressed data will need a unique different id.
 
@@ -74,10 +80,14 @@ func blocksTopicV2(cfg *rollup.Config) string {
 	return fmt.Sprintf("/optimism/%s/1/blocks", cfg.L2ChainID.String())
 }
 
+func shutterKeysTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/0/shutter-keys", cfg.L2ChainID.String())
+}
+
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), shutterKeysTopicV1(cfg)) // add more topics here in the future, if any.
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -358,16 +368,112 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	}
 }
 
+// BuildShutterKeysValidator validates the shutter decryption keys gossiped by the sequencer,
+// like blocks: the key must be signed by the sequencer, recent, and only a few keys may be seen per block height.
+func BuildShutterKeysValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig) pubsub.ValidatorEx {
+	// Seen block hashes per block height
+	// uint64 -> *seenBlocks
+	blockHeightLRU, err := lru.New[uint64, *seenBlocks](1000)
+	if err != nil {
+		panic(fmt.Errorf("failed to set up block height LRU cache: %w", err))
+	}
+
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		// [REJECT] if the compression is not valid
+		outLen, err := snappy.DecodedLen(message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression length data", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if outLen > 65+shutterKeyMaxMessageSize {
+			log.Warn("shutter key message is too large", "decoded_length", outLen, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if outLen < 65+shutterKeyMinMessageSize {
+			log.Warn("rejecting undersized shutter key message")
+			return pubsub.ValidationReject
+		}
+		data, err := snappy.Decode(nil, message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// message starts with compact-encoding secp256k1 encoded signature
+		signatureBytes, keyBytes := data[:65], data[65:]
+
+		// [REJECT] if the signature by the sequencer is not valid
+		result := verifySequencerSignature(log, SigningDomainShutterKeysV1, cfg, runCfg, id, signatureBytes, keyBytes)
+		if result != pubsub.ValidationAccept {
+			return result
+		}
+
+		// [REJECT] if the key encoding is not valid
+		var key eth.ShutterKey
+		if err := key.UnmarshalBinary(keyBytes); err != nil {
+			log.Warn("invalid shutter key", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the key is for a block of before shutter was activated
+		if !cfg.IsShutter(uint64(key.Timestamp)) {
+			log.Warn("shutter key for block before shutter activation", "block", key.ID(), "timestamp", uint64(key.Timestamp))
+			return pubsub.ValidationReject
+		}
+
+		// rounding down to seconds is fine here.
+		now := uint64(time.Now().Unix())
+
+		// [REJECT] if the block timestamp is older than 60 seconds in the past
+		if uint64(key.Timestamp) < now-60 {
+			log.Warn("shutter key is too old", "timestamp", uint64(key.Timestamp))
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the block timestamp is more than 5 seconds into the future
+		if uint64(key.Timestamp) > now+5 {
+			log.Warn("shutter key is too new", "timestamp", uint64(key.Timestamp))
+			return pubsub.ValidationReject
+		}
+
+		seen, ok := blockHeightLRU.Get(uint64(key.BlockNumber))
+		if !ok {
+			seen = new(seenBlocks)
+			blockHeightLRU.Add(uint64(key.BlockNumber), seen)
+		}
+
+		if count, hasSeen := seen.hasSeen(key.BlockHash); count > 5 {
+			// [REJECT] if keys of more than 5 blocks have been seen with the same block height
+			log.Warn("seen too many shutter keys at same height", "height", key.BlockNumber)
+			return pubsub.ValidationReject
+		} else if hasSeen {
+			// [IGNORE] if the key has already been seen
+			log.Warn("validated already seen shutter key again")
+			return pubsub.ValidationIgnore
+		}
+		seen.markSeen(key.BlockHash)
+
+		// remember the decoded key for later usage in topic subscriber.
+		message.ValidatorData = &key
+		return pubsub.ValidationAccept
+	}
+}
+
 func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte) pubsub.ValidationResult {
-	signingHash, err := BlockSigningHash(cfg, payloadBytes)
+	return verifySequencerSignature(log, SigningDomainBlocksV1, cfg, runCfg, id, signatureBytes, payloadBytes)
+}
+
+// verifySequencerSignature checks that the message was signed by the sequencer, in the given signing domain.
+func verifySequencerSignature(log log.Logger, domain [32]byte, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte) pubsub.ValidationResult {
+	signingHash, err := SigningHash(domain, cfg.L2ChainID, payloadBytes)
 	if err != nil {
-		log.Warn("failed to compute block signing hash", "err", err, "peer", id)
+		log.Warn("failed to compute signing hash", "err", err, "peer", id)
 		return pubsub.ValidationReject
 	}
 
 	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
 	if err != nil {
-		log.Warn("invalid block signature", "err", err, "peer", id)
+		log.Warn("invalid signature", "err", err, "peer", id)
 		return pubsub.ValidationReject
 	}
 	addr := crypto.PubkeyToAddress(*pub)
@@ -378,10 +484,10 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	// This means we may drop old payloads upon key rotation,
 	// but this can be recovered from like any other missed unsafe payload.
 	if expected := runCfg.P2PSequencerAddress(); expected == (common.Address{}) {
-		log.Warn("no configured p2p sequencer address, ignoring gossiped block", "peer", id, "addr", addr)
+		log.Warn("no configured p2p sequencer address, ignoring gossiped message", "peer", id, "addr", addr)
 		return pubsub.ValidationIgnore
 	} else if addr != expected {
-		log.Warn("unexpected block author", "err", err, "peer", id, "addr", addr, "expected", expected)
+		log.Warn("unexpected message author", "err", err, "peer", id, "addr", addr, "expected", expected)
 		return pubsub.ValidationReject
 	}
 	return pubsub.ValidationAccept
@@ -389,6 +495,7 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 
 type GossipIn interface {
 	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
+	OnShutterKey(ctx context.Context, from peer.ID, msg *eth.ShutterKey) error
 }
 
 type GossipTopicInfo interface {
@@ -400,6 +507,8 @@ type GossipTopicInfo interface {
 type GossipOut interface {
 	GossipTopicInfo
 	PublishL2Payload(ctx context.Context, msg *eth.ExecutionPayload, signer Signer) error
+	// PublishShutterKey publishes the shutter decryption key used for a block, if shutter key gossip is enabled.
+	PublishShutterKey(ctx context.Context, msg *eth.ShutterKey, signer Signer) error
 	Close() error
 }
 
@@ -429,6 +538,8 @@ type publisher struct {
 
 	blocksV1 *blockTopic
 	blocksV2 *blockTopic
+	// shutterKeys is nil if shutter key gossip is disabled
+	shutterKeys *blockTopic
 
 	runCfg GossipRuntimeConfig
 }
@@ -493,19 +604,41 @@ func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.Execution
 	}
 }
 
+func (p *publisher) PublishShutterKey(ctx context.Context, key *eth.ShutterKey, signer Signer) error {
+	if p.shutterKeys == nil {
+		return nil
+	}
+	keyData, err := key.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode shutter key to publish: %w", err)
+	}
+	sig, err := signer.Sign(ctx, SigningDomainShutterKeysV1, p.cfg.L2ChainID, keyData)
+	if err != nil {
+		return fmt.Errorf("failed to sign shutter key with signer: %w", err)
+	}
+	data := make([]byte, 0, 65+len(keyData))
+	data = append(data, sig[:]...)
+	data = append(data, keyData...)
+	return p.shutterKeys.topic.Publish(ctx, snappy.Encode(nil, data))
+}
+
 func (p *publisher) Close() error {
 	p.p2pCancel()
 	e1 := p.blocksV1.Close()
 	e2 := p.blocksV2.Close()
-	return errors.Join(e1, e2)
+	var e3 error
+	if p.shutterKeys != nil {
+		e3 = p.shutterKeys.Close()
+	}
+	return errors.Join(e1, e2, e3)
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn, shutterKeys bool) (GossipOut, error) {
 	p2pCtx, p2pCancel := context.WithCancel(context.Background())
 
 	v1Logger := log.New("topic", "blocksV1")
 	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1)))
-	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, gossipIn, blocksV1Validator)
+	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, BlocksHandler(gossipIn.OnUnsafeL2Payload), blocksV1Validator)
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v1 p2p: %w", err)
@@ -513,23 +646,35 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 
 	v2Logger := log.New("topic", "blocksV2")
 	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2)))
-	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, gossipIn, blocksV2Validator)
+	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, BlocksHandler(gossipIn.OnUnsafeL2Payload), blocksV2Validator)
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v2 p2p: %w", err)
 	}
 
+	var shutterKeysTopic *blockTopic
+	if shutterKeys {
+		keysLogger := log.New("topic", "shutterKeysV1")
+		keysValidator := guardGossipValidator(log, logValidationResult(self, "validated shutter key", keysLogger, BuildShutterKeysValidator(keysLogger, cfg, runCfg)))
+		shutterKeysTopic, err = newBlockTopic(p2pCtx, shutterKeysTopicV1(cfg), ps, keysLogger, ShutterKeysHandler(gossipIn.OnShutterKey), keysValidator)
+		if err != nil {
+			p2pCancel()
+			return nil, fmt.Errorf("failed to setup shutter keys p2p: %w", err)
+		}
+	}
+
 	return &publisher{
-		log:       log,
-		cfg:       cfg,
-		p2pCancel: p2pCancel,
-		blocksV1:  blocksV1,
-		blocksV2:  blocksV2,
-		runCfg:    runCfg,
+		log:         log,
+		cfg:         cfg,
+		p2pCancel:   p2pCancel,
+		blocksV1:    blocksV1,
+		blocksV2:    blocksV2,
+		shutterKeys: shutterKeysTopic,
+		runCfg:      runCfg,
 	}, nil
 }
 
-func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, gossipIn GossipIn, validator pubsub.ValidatorEx) (*blockTopic, error) {
+func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, handler MessageHandler, validator pubsub.ValidatorEx) (*blockTopic, error) {
 	err := ps.RegisterTopicValidator(topicId,
 		validator,
 		pubsub.WithValidatorTimeout(3*time.Second),
@@ -557,7 +702,7 @@ func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log l
 		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
 	}
 
-	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
+	subscriber := MakeSubscriber(log, handler)
 	go subscriber(ctx, subscription)
 
 	return &blockTopic{
@@ -580,6 +725,16 @@ func BlocksHandler(onBlock func(ctx context.Context, from peer.ID, msg *eth.Exec
 	}
 }
 
+func ShutterKeysHandler(onKey func(ctx context.Context, from peer.ID, msg *eth.ShutterKey) error) MessageHandler {
+	return func(ctx context.Context, from peer.ID, msg any) error {
+		key, ok := msg.(*eth.ShutterKey)
+		if !ok {
+			return fmt.Errorf("expected topic validator to parse and validate data into shutter key, but got %T", msg)
+		}
+		return onKey(ctx, from, key)
+	}
+}
+
 func MakeSubscriber(log log.Logger, msgHandler MessageHandler) TopicSubscriber {
 	return func(ctx context.Context, sub *pubsub.Subscription) {
 		topicLog := log.New("topic", sub.Topic())
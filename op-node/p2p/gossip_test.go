@@ -161,3 +161,61 @@ func TestBlockValidator(t *testing.T) {
 	require.Equal(t, res, pubsub.ValidationReject)
 
 }
+
+func TestShutterKeysValidator(t *testing.T) {
+	shutterTime := uint64(0)
+	cfg := &rollup.Config{
+		L2ChainID:   big.NewInt(100),
+		ShutterTime: &shutterTime,
+	}
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)}
+	signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
+	valFn := BuildShutterKeysValidator(testlog.Logger(t, log.LvlCrit), cfg, runCfg)
+	peerID := peer.ID("foo")
+
+	validate := func(key *eth.ShutterKey, signer Signer, domain [32]byte) (pubsub.ValidationResult, *pubsub.Message) {
+		keyData, err := key.MarshalBinary()
+		require.NoError(t, err)
+		sig, err := signer.Sign(context.TODO(), domain, cfg.L2ChainID, keyData)
+		require.NoError(t, err)
+		message := &pubsub.Message{Message: &pubsub_pb.Message{Data: snappy.Encode(nil, append(sig[:], keyData...))}}
+		return valFn(context.TODO(), peerID, message), message
+	}
+
+	key := &eth.ShutterKey{
+		BlockHash:   common.Hash{0xaa},
+		BlockNumber: 10,
+		Timestamp:   hexutil.Uint64(time.Now().Unix()),
+		Key:         hexutil.Bytes{0x01, 0x02, 0x03},
+	}
+	res, message := validate(key, signer, SigningDomainShutterKeysV1)
+	require.Equal(t, pubsub.ValidationAccept, res)
+	require.Equal(t, key, message.ValidatorData)
+
+	// Seen already
+	res, _ = validate(key, signer, SigningDomainShutterKeysV1)
+	require.Equal(t, pubsub.ValidationIgnore, res)
+
+	// Block signatures are not valid for keys
+	key.BlockHash = common.Hash{0xbb}
+	res, _ = validate(key, signer, SigningDomainBlocksV1)
+	require.Equal(t, pubsub.ValidationReject, res)
+
+	// Not signed by the sequencer
+	other := &PreparedSigner{Signer: NewLocalSigner(secrets.Alice)}
+	res, _ = validate(key, other, SigningDomainShutterKeysV1)
+	require.Equal(t, pubsub.ValidationReject, res)
+
+	// Too old
+	key.Timestamp = hexutil.Uint64(time.Now().Unix() - 120)
+	res, _ = validate(key, signer, SigningDomainShutterKeysV1)
+	require.Equal(t, pubsub.ValidationReject, res)
+
+	// Before shutter activation
+	shutterTime = uint64(time.Now().Unix() + 1000)
+	key.Timestamp = hexutil.Uint64(time.Now().Unix())
+	res, _ = validate(key, signer, SigningDomainShutterKeysV1)
+	require.Equal(t, pubsub.ValidationReject, res)
+}
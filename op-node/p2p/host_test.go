@@ -73,6 +73,7 @@ func TestP2PSimple(t *testing.T) {
 
 type mockGossipIn struct {
 	OnUnsafeL2PayloadFn func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
+	OnShutterKeyFn      func(ctx context.Context, from peer.ID, msg *eth.ShutterKey) error
 }
 
 func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error {
@@ -82,6 +83,13 @@ func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg
 	return nil
 }
 
+func (m *mockGossipIn) OnShutterKey(ctx context.Context, from peer.ID, msg *eth.ShutterKey) error {
+	if m.OnShutterKeyFn != nil {
+		return m.OnShutterKeyFn(ctx, from, msg)
+	}
+	return nil
+}
+
 // Full setup, using negotiated transport security and muxes
 func TestP2PFull(t *testing.T) {
 	pA, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
@@ -24,7 +24,8 @@ type Prepared struct {
 	LocalNode *enode.LocalNode
 	UDPv5     *discover.UDPv5
 
-	EnableReqRespSync bool
+	EnableReqRespSync      bool
+	EnableShutterKeyGossip bool
 }
 
 var _ SetupP2P = (*Prepared)(nil)
@@ -92,3 +93,7 @@ func (p *Prepared) Disabled() bool {
 func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
+
+func (p *Prepared) ShutterKeyGossipEnabled() bool {
+	return p.EnableShutterKeyGossip
+}
@@ -15,6 +15,9 @@ import (
 
 var SigningDomainBlocksV1 = [32]byte{}
 
+// SigningDomainShutterKeysV1 separates the signatures of gossiped shutter keys from those of blocks.
+var SigningDomainShutterKeysV1 = [32]byte{31: 1}
+
 type Signer interface {
 	Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error)
 	io.Closer
@@ -59,12 +59,25 @@ const (
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// Maximum number of payloads a peer may request in a single range request.
+	// Each payload counts towards the rate-limits, so this must fit in the per-peer burst.
+	maxPayloadsPerRangeRequest = 8
 )
 
 func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_number/%d/0", l2ChainID))
 }
 
+// PayloadsByRangeProtocolID identifies the v2 protocol to request a range of consecutive payloads by number.
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
+// PayloadByHashProtocolID identifies the v2 protocol to request a single payload by block hash.
+func PayloadByHashProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_hash/%d/0", l2ChainID))
+}
+
 type requestHandlerFn func(ctx context.Context, log log.Logger, stream network.Stream)
 
 func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestHandlerFn) network.StreamHandler {
@@ -95,7 +108,12 @@ type syncResult struct {
 }
 
 type peerRequest struct {
+	// num is the first block of the range of blocks to request.
 	num uint64
+	// count is the number of consecutive blocks to request, starting at num.
+	count uint64
+	// hash is the block to request by hash, instead of by number, if not zero.
+	hash common.Hash
 
 	complete *atomic.Bool
 }
@@ -128,8 +146,9 @@ type SyncPeerScorer interface {
 // ### Stages
 //
 // The sync mechanism is implemented as following:
-// - User sends range request: blocks on sync main loop (with ctx timeout)
-// - Main loop processes range request (from high to low), dividing block requests by number between parallel peers.
+//   - User sends range request: blocks on sync main loop (with ctx timeout)
+//   - Main loop processes range request (from high to low), dividing block requests between parallel peers,
+//     grouping consecutive blocks into requests of up to maxPayloadsPerRangeRequest blocks.
 //   - The high part of the range has a known block-hash, and is marked as trusted.
 //   - Once there are no more peers available for buffering requests, we stop the range request processing.
 //   - Every request buffered for a peer is tracked as in-flight, by block number.
@@ -138,7 +157,10 @@ type SyncPeerScorer interface {
 //   - Data already in the quarantine that is trusted is attempted to be promoted.
 //
 // - Peers each have their own routine for processing requests.
-//   - They fetch the requested block by number, parse and validate it, and then send it back to the main loop
+//   - They fetch the requested range of blocks with a single v2 range request, parse and validate each block,
+//     and then send them back to the main loop. Peers that only support the v1 protocol are requested
+//     block by block, by number.
+//   - Blocks that the main loop found to be reorged are requested by hash, with the v2 by-hash protocol.
 //   - If peers fail to fetch or process it, or fail to send it back to the main loop within timeout,
 //     then the doRequest returns an error. It then marks the in-flight request as completed.
 //
@@ -191,6 +213,8 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
+	payloadByHash   protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -210,6 +234,8 @@ type SyncClient struct {
 
 	// inFlight requests are not repeated
 	inFlight map[uint64]*atomic.Bool
+	// inFlightByHash tracks the blocks that are requested by hash
+	inFlightByHash map[common.Hash]*atomic.Bool
 
 	requests       chan rangeRequest
 	peerRequests   chan peerRequest
@@ -244,9 +270,12 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		appScorer:       appScorer,
 		newStreamFn:     newStream,
 		payloadByNumber: PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange: PayloadsByRangeProtocolID(cfg.L2ChainID),
+		payloadByHash:   PayloadByHashProtocolID(cfg.L2ChainID),
 		peers:           make(map[peer.ID]context.CancelFunc),
 		quarantineByNum: make(map[uint64]common.Hash),
 		inFlight:        make(map[uint64]*atomic.Bool),
+		inFlightByHash:  make(map[common.Hash]*atomic.Bool),
 		requests:        make(chan rangeRequest), // blocking
 		peerRequests:    make(chan peerRequest, 128),
 		results:         make(chan syncResult, 128),
@@ -389,11 +418,19 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 			delete(s.inFlight, k)
 		}
 	}
+	for k, v := range s.inFlightByHash {
+		if v.Load() {
+			delete(s.inFlightByHash, k)
+		}
+	}
 
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
+	// Consecutive blocks that are needed are grouped into a single request, so peers can serve them as range.
+	var pending []uint64
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
 		if num <= req.start {
+			s.scheduleRange(ctx, log, pending)
 			return
 		}
 		// check if we have something in quarantine already
@@ -403,27 +440,70 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 			}
 			// Don't fetch things that we have a candidate for already.
 			// We'll evict it from quarantine by finding a conflict, or if we sync enough other blocks
+			if !s.scheduleRange(ctx, log, pending) {
+				return
+			}
+			pending = pending[:0]
 			continue
 		}
 
 		if _, ok := s.inFlight[num]; ok {
 			log.Debug("request still in-flight, not rescheduling sync request", "num", num)
+			if !s.scheduleRange(ctx, log, pending) {
+				return
+			}
+			pending = pending[:0]
 			continue // request still in flight
 		}
-		pr := peerRequest{num: num, complete: new(atomic.Bool)}
 
-		log.Debug("Scheduling P2P block request", "num", num)
-		// schedule number
-		select {
-		case s.peerRequests <- pr:
+		pending = append(pending, num)
+		if len(pending) >= maxPayloadsPerRangeRequest {
+			if !s.scheduleRange(ctx, log, pending) {
+				return
+			}
+			pending = pending[:0]
+		}
+	}
+}
+
+// scheduleRange schedules a request for the given consecutive block numbers, in descending order.
+// It returns false if no peer is available to take the request.
+func (s *SyncClient) scheduleRange(ctx context.Context, log log.Logger, nums []uint64) bool {
+	if len(nums) == 0 {
+		return true
+	}
+	first := nums[len(nums)-1]
+	pr := peerRequest{num: first, count: uint64(len(nums)), complete: new(atomic.Bool)}
+
+	log.Debug("Scheduling P2P block request", "num", first, "count", pr.count)
+	select {
+	case s.peerRequests <- pr:
+		for _, num := range nums {
 			s.inFlight[num] = pr.complete
-		case <-ctx.Done():
-			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
-			return
-		default: // peers may all be busy processing requests already
-			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", num)
-			return
 		}
+		return true
+	case <-ctx.Done():
+		log.Info("did not schedule full P2P sync range", "current", first, "err", ctx.Err())
+		return false
+	default: // peers may all be busy processing requests already
+		log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", first)
+		return false
+	}
+}
+
+// scheduleByHash schedules a request for the block with the given hash, if it is not in-flight already.
+// The request is dropped if no peer is available to take it, the block will then be synced by number instead.
+func (s *SyncClient) scheduleByHash(h common.Hash) {
+	if _, ok := s.inFlightByHash[h]; ok {
+		return
+	}
+	pr := peerRequest{hash: h, complete: new(atomic.Bool)}
+	select {
+	case s.peerRequests <- pr:
+		s.log.Debug("Scheduling P2P block request by hash", "hash", h)
+		s.inFlightByHash[h] = pr.complete
+	default:
+		s.log.Debug("no peers ready to handle block request by hash", "hash", h)
 	}
 }
 
@@ -477,6 +557,11 @@ func (s *SyncClient) promote(ctx context.Context, res syncResult) {
 	// clear what we buffered in favor of fetching something else.
 	if h, ok := s.quarantineByNum[uint64(res.payload.BlockNumber)-1]; ok {
 		s.quarantine.Remove(h)
+		// The block we buffered was reorged out: peers may serve the same stale block by number,
+		// so request the canonical parent by hash.
+		if h != res.payload.ParentHash {
+			s.scheduleByHash(res.payload.ParentHash)
+		}
 	}
 }
 
@@ -486,6 +571,7 @@ func (s *SyncClient) onResult(ctx context.Context, res syncResult) {
 	s.log.Debug("processing p2p sync result", "payload", res.payload.ID(), "peer", res.peer)
 	// Clean up the in-flight request, we have a result now.
 	delete(s.inFlight, uint64(res.payload.BlockNumber))
+	delete(s.inFlightByHash, res.payload.BlockHash)
 	// Always put it in quarantine first. If promotion fails because the receiver is too busy, this functions as cache.
 	s.quarantine.Add(res.payload.BlockHash, res)
 	s.quarantineByNum[uint64(res.payload.BlockNumber)] = res.payload.BlockHash
@@ -513,6 +599,9 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	// so we don't be too aggressive to the server.
 	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
 
+	// Peers that do not support the v2 protocols are synced from block by block.
+	v1Only := false
+
 	for {
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
@@ -526,14 +615,19 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		// once the peer is available, wait for a sync request.
 		select {
 		case pr := <-s.peerRequests:
+			if pr.hash != (common.Hash{}) && v1Only {
+				// The block will be synced by number instead.
+				pr.complete.Store(true)
+				continue
+			}
 			// We already established the peer is available w.r.t. rate-limiting,
 			// and this is the only loop over this peer, so we can request now.
 			start := time.Now()
-			err := s.doRequest(ctx, id, pr.num)
+			err := s.doPeerRequest(ctx, id, rl, pr, &v1Only)
+			// mark as complete: the results have been sent already, and any blocks that were not served can be requested again.
+			pr.complete.Store(true)
 			if err != nil {
-				// mark as complete if there's an error: we are not sending any result and can complete immediately.
-				pr.complete.Store(true)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
+				log.Warn("failed p2p sync request", "num", pr.num, "count", pr.count, "hash", pr.hash, "err", err)
 				s.appScorer.onResponseError(id)
 				// If we hit an error, then count it as many requests.
 				// We'd like to avoid making more requests for a while, to back off.
@@ -541,7 +635,7 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 					return
 				}
 			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
+				log.Debug("completed p2p sync request", "num", pr.num, "count", pr.count, "hash", pr.hash)
 				s.appScorer.onValidResponse(id)
 			}
 			took := time.Since(start)
@@ -554,7 +648,9 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 					resultCode = 1
 				}
 			}
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
+			if pr.hash == (common.Hash{}) {
+				s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
+			}
 		case <-ctx.Done():
 			return
 		}
@@ -571,6 +667,194 @@ func (r requestResultErr) ResultCode() byte {
 	return byte(r)
 }
 
+// doPeerRequest requests the blocks of the peer request from the given peer.
+// If the peer does not support the v2 range protocol, the blocks are requested one by one instead.
+// Every block after the first counts towards the rate-limits, the same way the server accounts for them.
+func (s *SyncClient) doPeerRequest(ctx context.Context, id peer.ID, rl *rate.Limiter, pr peerRequest, v1Only *bool) error {
+	if pr.hash != (common.Hash{}) {
+		return s.doRequestByHash(ctx, id, pr.hash)
+	}
+	// Request the highest block first, so the blocks can be promoted as they come in.
+	remaining := pr.count
+	if !*v1Only {
+		// open stream to peer, the peer picks the v1 protocol if it does not support range requests.
+		reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+		str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange, s.payloadByNumber)
+		reqCancel()
+		if err != nil {
+			return fmt.Errorf("failed to open stream: %w", err)
+		}
+		if str.Protocol() == s.payloadsByRange {
+			served, err := s.doRangeRequest(ctx, id, str, pr.num, pr.count)
+			if served > 1 {
+				if err := s.globalRL.WaitN(ctx, served-1); err != nil {
+					return err
+				}
+				if err := rl.WaitN(ctx, served-1); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+		s.log.Info("Peer does not support P2P sync range requests, syncing block by block", "peer", id)
+		*v1Only = true
+		remaining--
+		if err := s.doRequestOnStream(ctx, id, str, pr.num+remaining); err != nil {
+			return err
+		}
+	}
+	for ; remaining > 0; remaining-- {
+		if remaining < pr.count {
+			if err := s.globalRL.Wait(ctx); err != nil {
+				return err
+			}
+			if err := rl.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		if err := s.doRequest(ctx, id, pr.num+remaining-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// doRangeRequest requests count consecutive blocks, starting at the given block number, on the given stream.
+// The peer may serve fewer blocks than requested, if it does not have all of them.
+// It returns the number of blocks that were served.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, str network.Stream, start uint64, count uint64) (served int, err error) {
+	defer str.Close()
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	var req [16]byte
+	binary.LittleEndian.PutUint64(req[:8], start)
+	binary.LittleEndian.PutUint64(req[8:], count)
+	if _, err := str.Write(req[:]); err != nil {
+		return 0, fmt.Errorf("failed to write range request (%d, %d): %w", start, count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return 0, fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	for i := uint64(0); i < count; i++ {
+		// set read timeout per payload (if available)
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		res, err := s.readPayloadChunk(str)
+		if err != nil {
+			// The server ends the response early if it does not have all the requested blocks.
+			var resultErr requestResultErr
+			if served > 0 && (errors.Is(err, io.EOF) || errors.As(err, &resultErr)) {
+				return served, nil
+			}
+			return served, err
+		}
+		if err := verifyBlock(res, start+i); err != nil {
+			return served, fmt.Errorf("received execution payload is invalid: %w", err)
+		}
+		select {
+		case s.results <- syncResult{payload: res, peer: id}:
+		case <-ctx.Done():
+			return served, fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
+		served++
+	}
+	if err := str.CloseRead(); err != nil {
+		return served, fmt.Errorf("failed to close reading side")
+	}
+	return served, nil
+}
+
+// doRequestByHash requests the block with the given hash.
+func (s *SyncClient) doRequestByHash(ctx context.Context, id peer.ID, h common.Hash) error {
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadByHash)
+	reqCancel()
+	if err != nil {
+		return fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	if _, err := str.Write(h[:]); err != nil {
+		return fmt.Errorf("failed to write request (%s): %w", h, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	// set read timeout (if available)
+	_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+	res, err := s.readPayloadChunk(str)
+	if err != nil {
+		return err
+	}
+	if err := str.CloseRead(); err != nil {
+		return fmt.Errorf("failed to close reading side")
+	}
+	if res.BlockHash != h {
+		return fmt.Errorf("received execution payload %s, but expected block %s", res.ID(), h)
+	}
+	if err := verifyBlock(res, uint64(res.BlockNumber)); err != nil {
+		return fmt.Errorf("received execution payload is invalid: %w", err)
+	}
+	select {
+	case s.results <- syncResult{payload: res, peer: id}:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+	}
+	return nil
+}
+
+// readPayloadChunk reads a single payload of a v2 response. Every payload is prefixed with
+// a result code, the block version, and the length of the snappy block-compressed SSZ payload.
+func (s *SyncClient) readPayloadChunk(r io.Reader) (*eth.ExecutionPayload, error) {
+	var result [1]byte
+	if _, err := io.ReadFull(r, result[:]); err != nil {
+		return nil, fmt.Errorf("failed to read result part of response: %w", err)
+	}
+	if res := result[0]; res != 0 {
+		return nil, requestResultErr(res)
+	}
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read version and length part of response: %w", err)
+	}
+	version := eth.BlockVersion(binary.LittleEndian.Uint32(header[:4]))
+	if version != eth.BlockV1 && version != eth.BlockV2 {
+		return nil, fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
+	}
+	length := binary.LittleEndian.Uint32(header[4:])
+	if length > maxGossipSize {
+		return nil, fmt.Errorf("payload of %d bytes is too large", length)
+	}
+	compressed := make([]byte, length)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, fmt.Errorf("failed to read payload part of response: %w", err)
+	}
+	// Check the decompressed size before decompressing, to not be vulnerable to zip-bombs.
+	if n, err := snappy.DecodedLen(compressed); err != nil {
+		return nil, fmt.Errorf("invalid snappy data: %w", err)
+	} else if n > maxGossipSize {
+		return nil, fmt.Errorf("decompressed payload of %d bytes is too large", n)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	var res eth.ExecutionPayload
+	if err := res.UnmarshalSSZ(version, uint32(len(data)), bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	expectedVersion := eth.BlockV1
+	if s.cfg.IsCanyon(uint64(res.Timestamp)) {
+		expectedVersion = eth.BlockV2
+	}
+	if version != expectedVersion {
+		return nil, fmt.Errorf("received execution payload %s with version %d, but expected version %d", res.ID(), version, expectedVersion)
+	}
+	return &res, nil
+}
+
 func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum uint64) error {
 	// open stream to peer
 	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
@@ -579,6 +863,11 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	if err != nil {
 		return fmt.Errorf("failed to open stream: %w", err)
 	}
+	return s.doRequestOnStream(ctx, id, str, expectedBlockNum)
+}
+
+// doRequestOnStream makes a v1 request for a single block by number on the given stream, and closes the stream.
+func (s *SyncClient) doRequestOnStream(ctx context.Context, id peer.ID, str network.Stream, expectedBlockNum uint64) error {
 	defer str.Close()
 	// set write timeout (if available)
 	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
@@ -666,6 +955,7 @@ type peerStat struct {
 
 type L2Chain interface {
 	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error)
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error)
 }
 
 type ReqRespServerMetrics interface {
@@ -701,6 +991,8 @@ func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetri
 	}
 }
 
+type requestFn func(ctx context.Context, stream network.Stream) (uint64, error)
+
 // HandleSyncRequest is a stream handler function to register the L2 unsafe payloads alt-sync protocol.
 // See MakeStreamHandler to transform this into a LibP2P handler function.
 //
@@ -708,13 +1000,34 @@ func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetri
 //
 // The caller must Close the stream.
 func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	srv.handleRequest(ctx, log, stream, srv.handleSyncRequest)
+}
+
+// HandleSyncRangeRequest is a stream handler function to register the v2 range protocol of the L2 unsafe payloads alt-sync.
+// Every payload in the range is rate-limited as a request of its own.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleSyncRangeRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	srv.handleRequest(ctx, log, stream, srv.handleSyncRangeRequest)
+}
+
+// HandleSyncByHashRequest is a stream handler function to register the v2 by-hash protocol of the L2 unsafe payloads alt-sync.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleSyncByHashRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	srv.handleRequest(ctx, log, stream, srv.handleSyncByHashRequest)
+}
+
+func (srv *ReqRespServer) handleRequest(ctx context.Context, log log.Logger, stream network.Stream, fn requestFn) {
 	// may stay 0 if we fail to decode the request
 	start := time.Now()
 
 	// We wait as long as necessary; we throttle the peer instead of disconnecting,
 	// unless the delay reaches a threshold that is unreasonable to wait for.
 	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
-	req, err := srv.handleSyncRequest(ctx, stream)
+	req, err := fn(ctx, stream)
 	cancel()
 
 	resultCode := byte(0)
@@ -737,17 +1050,17 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 
 var invalidRequestErr = errors.New("invalid request")
 
-func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
-	peerId := stream.Conn().RemotePeer()
-
+// waitRateLimits takes a token from the global and the peer rate-limiter, to serve a single payload to the peer.
+func (srv *ReqRespServer) waitRateLimits(ctx context.Context, peerId peer.ID) error {
 	// take a token from the global rate-limiter,
 	// to make sure there's not too much concurrent server work between different peers.
 	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+		return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 	}
 
 	// find rate limiting data of peer, or add otherwise
 	srv.peerStatsLock.Lock()
+	defer srv.peerStatsLock.Unlock()
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
 		ps = &peerStat{
@@ -762,10 +1075,31 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 		// We'll disconnect ourselves only when failing to read/write,
 		// if the work is invalid (range validation), or when individual sub tasks timeout.
 		if err := ps.Requests.Wait(ctx); err != nil {
-			return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+			return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 		}
 	}
-	srv.peerStatsLock.Unlock()
+	return nil
+}
+
+// checkRequestedBlock checks the requested block number is within the expected range of blocks.
+func (srv *ReqRespServer) checkRequestedBlock(num uint64) error {
+	if num < srv.cfg.Genesis.L2.Number {
+		return fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", num, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+	}
+	if num > max {
+		return fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", num, max, invalidRequestErr)
+	}
+	return nil
+}
+
+func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	if err := srv.waitRateLimits(ctx, stream.Conn().RemotePeer()); err != nil {
+		return 0, err
+	}
 
 	// Set read deadline, if available
 	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
@@ -779,16 +1113,8 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 		return req, fmt.Errorf("failed to close reading-side of a P2P sync request call: %w", err)
 	}
 
-	// Check the request is within the expected range of blocks
-	if req < srv.cfg.Genesis.L2.Number {
-		return req, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req, srv.cfg.Genesis.L2.Number, invalidRequestErr)
-	}
-	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
-	if err != nil {
-		return req, fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
-	}
-	if req > max {
-		return req, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req, max, invalidRequestErr)
+	if err := srv.checkRequestedBlock(req); err != nil {
+		return req, err
 	}
 
 	payload, err := srv.l2.PayloadByNumber(ctx, req)
@@ -818,3 +1144,115 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	}
 	return req, nil
 }
+
+func (srv *ReqRespServer) handleSyncRangeRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	peerId := stream.Conn().RemotePeer()
+	if err := srv.waitRateLimits(ctx, peerId); err != nil {
+		return 0, err
+	}
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request: the first block number, and the number of blocks
+	var req [16]byte
+	if _, err := io.ReadFull(stream, req[:]); err != nil {
+		return 0, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	start := binary.LittleEndian.Uint64(req[:8])
+	count := binary.LittleEndian.Uint64(req[8:])
+	if err := stream.CloseRead(); err != nil {
+		return start, fmt.Errorf("failed to close reading-side of a P2P sync request call: %w", err)
+	}
+	if count == 0 || count > maxPayloadsPerRangeRequest {
+		return start, fmt.Errorf("cannot serve request for %d blocks: %w", count, invalidRequestErr)
+	}
+
+	for i := uint64(0); i < count; i++ {
+		num := start + i
+		// Blocks after the first are rate-limited as requests of their own.
+		if i > 0 {
+			if err := srv.waitRateLimits(ctx, peerId); err != nil {
+				return start, err
+			}
+		}
+		if err := srv.checkRequestedBlock(num); err != nil {
+			return start, err
+		}
+		payload, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return start, fmt.Errorf("peer requested unknown block %d in range: %w", num, err)
+			} else {
+				return start, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
+			}
+		}
+		if err := srv.writePayloadChunk(stream, payload); err != nil {
+			return start, err
+		}
+	}
+	return start, nil
+}
+
+func (srv *ReqRespServer) handleSyncByHashRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	if err := srv.waitRateLimits(ctx, stream.Conn().RemotePeer()); err != nil {
+		return 0, err
+	}
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	var req common.Hash
+	if _, err := io.ReadFull(stream, req[:]); err != nil {
+		return 0, fmt.Errorf("failed to read requested block hash: %w", err)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return 0, fmt.Errorf("failed to close reading-side of a P2P sync request call: %w", err)
+	}
+
+	payload, err := srv.l2.PayloadByHash(ctx, req)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return 0, fmt.Errorf("peer requested unknown block by hash %s: %w", req, err)
+		} else {
+			return 0, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
+		}
+	}
+	num := uint64(payload.BlockNumber)
+	if err := srv.writePayloadChunk(stream, payload); err != nil {
+		return num, err
+	}
+	return num, nil
+}
+
+// writePayloadChunk writes a single payload of a v2 response:
+// the result code, the block version, and the length of the snappy block-compressed SSZ payload, followed by the payload.
+func (srv *ReqRespServer) writePayloadChunk(stream network.Stream, payload *eth.ExecutionPayload) error {
+	version := eth.BlockV1
+	if srv.cfg.IsCanyon(uint64(payload.Timestamp)) {
+		version = eth.BlockV2
+	}
+	var buf bytes.Buffer
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return fmt.Errorf("failed to encode payload %s: %w", payload.ID(), err)
+	}
+	data := snappy.Encode(nil, buf.Bytes())
+
+	// 0 - resultCode: success = 0
+	// 1:5 - version
+	// 5:9 - length of the compressed payload
+	var header [9]byte
+	binary.LittleEndian.PutUint32(header[1:5], uint32(version))
+	binary.LittleEndian.PutUint32(header[5:9], uint32(len(data)))
+
+	// We set write deadline per payload, if available, to safely write without blocking on a throttling peer connection
+	_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+	if _, err := stream.Write(header[:]); err != nil {
+		return fmt.Errorf("failed to write response header data: %w", err)
+	}
+	if _, err := stream.Write(data); err != nil {
+		return fmt.Errorf("failed to write payload to sync response: %w", err)
+	}
+	return nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/require"
 
@@ -29,8 +31,28 @@ func (fn mockPayloadFn) PayloadByNumber(_ context.Context, number uint64) (*eth.
 	return fn(number)
 }
 
+func (fn mockPayloadFn) PayloadByHash(_ context.Context, _ common.Hash) (*eth.ExecutionPayload, error) {
+	return nil, ethereum.NotFound
+}
+
 var _ L2Chain = mockPayloadFn(nil)
 
+// mockL2Chain serves payloads by number and by hash
+type mockL2Chain struct {
+	byNumber func(n uint64) (*eth.ExecutionPayload, error)
+	byHash   func(h common.Hash) (*eth.ExecutionPayload, error)
+}
+
+func (m *mockL2Chain) PayloadByNumber(_ context.Context, number uint64) (*eth.ExecutionPayload, error) {
+	return m.byNumber(number)
+}
+
+func (m *mockL2Chain) PayloadByHash(_ context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	return m.byHash(hash)
+}
+
+var _ L2Chain = (*mockL2Chain)(nil)
+
 type syncTestData struct {
 	sync.RWMutex
 	payloads map[uint64]*eth.ExecutionPayload
@@ -157,6 +179,162 @@ func TestSinglePeerSync(t *testing.T) {
 	}
 }
 
+// registerSyncProtocols registers all sync protocols of the server with the host,
+// and counts the requests that are made per protocol.
+func registerSyncProtocols(ctx context.Context, logger log.Logger, h host.Host, cfg *rollup.Config, srv *ReqRespServer) map[protocol.ID]*atomic.Int64 {
+	handlers := map[protocol.ID]requestHandlerFn{
+		PayloadByNumberProtocolID(cfg.L2ChainID): srv.HandleSyncRequest,
+		PayloadsByRangeProtocolID(cfg.L2ChainID): srv.HandleSyncRangeRequest,
+		PayloadByHashProtocolID(cfg.L2ChainID):   srv.HandleSyncByHashRequest,
+	}
+	counts := make(map[protocol.ID]*atomic.Int64)
+	for id, fn := range handlers {
+		fn := fn
+		count := new(atomic.Int64)
+		counts[id] = count
+		h.SetStreamHandler(id, MakeStreamHandler(ctx, logger.New("serve", id), func(ctx context.Context, log log.Logger, stream network.Stream) {
+			count.Add(1)
+			fn(ctx, log, stream)
+		}))
+	}
+	return counts
+}
+
+func TestSinglePeerSyncRange(t *testing.T) {
+	t.Parallel()
+
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(25)
+
+	servePayloads := &mockL2Chain{
+		byNumber: func(n uint64) (*eth.ExecutionPayload, error) {
+			p, ok := payloads.getPayload(n)
+			if !ok {
+				return nil, ethereum.NotFound
+			}
+			return p, nil
+		},
+		byHash: func(h common.Hash) (*eth.ExecutionPayload, error) {
+			return nil, ethereum.NotFound
+		},
+	}
+
+	received := make(chan *eth.ExecutionPayload, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+		received <- payload
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the server, with the v2 protocols
+	srv := NewReqRespServer(cfg, servePayloads, metrics.NoopMetrics)
+	counts := registerSyncProtocols(ctx, log.New("role", "server"), hostA, cfg, srv)
+
+	// Setup host B as the client
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	// request to start syncing between 5 and 25, which covers more than a single range request
+	require.NoError(t, cl.RequestL2Range(ctx, payloads.getBlockRef(5), payloads.getBlockRef(25)))
+
+	for i := uint64(24); i > 5; i-- {
+		p := <-received
+		require.Equal(t, uint64(p.BlockNumber), i, "expecting payloads in order")
+		exp, ok := payloads.getPayload(uint64(p.BlockNumber))
+		require.True(t, ok, "expecting known payload")
+		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
+	}
+	// all blocks are synced with range requests
+	require.Positive(t, counts[PayloadsByRangeProtocolID(cfg.L2ChainID)].Load())
+	require.Zero(t, counts[PayloadByNumberProtocolID(cfg.L2ChainID)].Load())
+	require.Zero(t, counts[PayloadByHashProtocolID(cfg.L2ChainID)].Load())
+}
+
+func TestSinglePeerSyncReorgByHash(t *testing.T) {
+	t.Parallel()
+
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(25)
+
+	// The server still serves a reorged block 15 by number, but knows the canonical block 15 by hash.
+	canonical, _ := payloads.getPayload(15)
+	parent, _ := payloads.getPayload(14)
+	reorged := &eth.ExecutionPayload{
+		ParentHash:  parent.BlockHash,
+		BlockNumber: canonical.BlockNumber,
+		Timestamp:   canonical.Timestamp,
+		ExtraData:   eth.BytesMax32{0x01},
+	}
+	reorged.BlockHash, _ = reorged.CheckBlockHash()
+	require.NotEqual(t, canonical.BlockHash, reorged.BlockHash)
+
+	servePayloads := &mockL2Chain{
+		byNumber: func(n uint64) (*eth.ExecutionPayload, error) {
+			if n == 15 {
+				return reorged, nil
+			}
+			p, ok := payloads.getPayload(n)
+			if !ok {
+				return nil, ethereum.NotFound
+			}
+			return p, nil
+		},
+		byHash: func(h common.Hash) (*eth.ExecutionPayload, error) {
+			if h == canonical.BlockHash {
+				return canonical, nil
+			}
+			return nil, ethereum.NotFound
+		},
+	}
+
+	received := make(chan *eth.ExecutionPayload, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+		received <- payload
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := NewReqRespServer(cfg, servePayloads, metrics.NoopMetrics)
+	counts := registerSyncProtocols(ctx, log.New("role", "server"), hostA, cfg, srv)
+
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	require.NoError(t, cl.RequestL2Range(ctx, payloads.getBlockRef(10), payloads.getBlockRef(20)))
+
+	// The canonical block 15 is fetched by hash, and the sync continues with the parent blocks.
+	for i := uint64(19); i > 10; i-- {
+		p := <-received
+		require.Equal(t, uint64(p.BlockNumber), i, "expecting payloads in order")
+		exp, ok := payloads.getPayload(uint64(p.BlockNumber))
+		require.True(t, ok, "expecting known payload")
+		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the canonical payload")
+	}
+	require.Positive(t, counts[PayloadByHashProtocolID(cfg.L2ChainID)].Load())
+}
+
 func TestMultiPeerSync(t *testing.T) {
 	t.Parallel() // Takes a while, but can run in parallel
 
@@ -0,0 +1,69 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type AltDAInputFetcher interface {
+	// GetInput fetches the input data of the given commitment from the DA server.
+	// The input must be verified against the commitment.
+	GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error)
+}
+
+// AltDADataSource resolves alt-DA commitments in the batcher data of the wrapped source
+// to the frame data that is stored on the DA server. Other batcher data is passed through
+// unchanged, so that batchers can fall back to posting frames on L1.
+type AltDADataSource struct {
+	log     log.Logger
+	src     DataIter
+	fetcher AltDAInputFetcher
+	// comm is the commitment of which the input is being fetched, nil if there is none.
+	comm *altda.Keccak256Commitment
+}
+
+func NewAltDADataSource(log log.Logger, src DataIter, fetcher AltDAInputFetcher) *AltDADataSource {
+	return &AltDADataSource{
+		log:     log,
+		src:     src,
+		fetcher: fetcher,
+	}
+}
+
+// Next returns the next piece of batcher data, resolving commitments. It returns a TemporaryError
+// if the input of a commitment cannot be fetched, and retries the same commitment on the next call.
+func (s *AltDADataSource) Next(ctx context.Context) (eth.Data, error) {
+	if s.comm == nil {
+		data, err := s.src.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 || data[0] != altda.TxDataVersion1 {
+			return data, nil
+		}
+		comm, err := altda.DecodeTxData(data)
+		if err != nil {
+			// Like any other invalid batcher data, the frame queue drops it.
+			s.log.Warn("invalid alt-DA commitment", "err", err)
+			return data, nil
+		}
+		s.comm = &comm
+	}
+	input, err := s.fetcher.GetInput(ctx, *s.comm)
+	if errors.Is(err, altda.ErrNotFound) {
+		// There is no challenge mechanism for unavailable data yet,
+		// so derivation stalls until the input becomes available.
+		s.log.Warn("input of alt-DA commitment not found, waiting for it", "commitment", s.comm)
+		return nil, NewTemporaryError(fmt.Errorf("input of commitment %s not found: %w", s.comm, err))
+	} else if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to fetch input of commitment %s: %w", s.comm, err))
+	}
+	s.comm = nil
+	return input, nil
+}
@@ -0,0 +1,134 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// fakeAltDAFetcher serves the inputs of alt-DA commitments.
+type fakeAltDAFetcher struct {
+	inputs map[altda.Keccak256Commitment][]byte
+	err    error
+	calls  int
+}
+
+func (f *fakeAltDAFetcher) GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	input, ok := f.inputs[comm]
+	if !ok {
+		return nil, altda.ErrNotFound
+	}
+	return input, nil
+}
+
+func (f *fakeAltDAFetcher) addInput(input []byte) altda.Keccak256Commitment {
+	comm := altda.Keccak256(input)
+	f.inputs[comm] = input
+	return comm
+}
+
+func TestAltDADataSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	altDATime := uint64(0)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+		AltDATime:         &altDATime,
+	}
+	signer := cfg.L1Signer()
+	logger := testlog.Logger(t, log.LvlCrit)
+	ref := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: 10, Time: 100}
+
+	fetcher := &fakeAltDAFetcher{inputs: map[altda.Keccak256Commitment][]byte{}}
+	c1 := fetcher.addInput([]byte("\x00first frames"))
+	c2 := fetcher.addInput([]byte("\x00second frames"))
+	invalid := append(c1.TxData(), 0xff)
+	txs := types.Transactions{
+		newCalldataTx(t, signer, batcherPriv, cfg.BatchInboxAddress, c1.TxData()),
+		newCalldataTx(t, signer, batcherPriv, cfg.BatchInboxAddress, []byte("\x00calldata frames")),
+		newCalldataTx(t, signer, batcherPriv, cfg.BatchInboxAddress, invalid),
+		newCalldataTx(t, signer, batcherPriv, cfg.BatchInboxAddress, c2.TxData()),
+	}
+
+	t.Run("data", func(t *testing.T) {
+		l1 := &testutils.MockL1Source{}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		src, err := NewDataSourceFactory(logger, cfg, l1, nil, fetcher).OpenData(context.Background(), ref, batcherAddr)
+		require.NoError(t, err)
+
+		var out []string
+		for {
+			data, err := src.Next(context.Background())
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			out = append(out, string(data))
+		}
+		// invalid commitments are passed on, to be dropped by the frame queue
+		require.Equal(t, []string{"\x00first frames", "\x00calldata frames", string(invalid), "\x00second frames"}, out)
+		l1.AssertExpectations(t)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		l1 := &testutils.MockL1Source{}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs[3:], nil)
+		missing := &fakeAltDAFetcher{inputs: map[altda.Keccak256Commitment][]byte{}}
+		src := NewAltDADataSource(logger, NewDataSource(context.Background(), logger, cfg, l1, ref.ID(), batcherAddr), missing)
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+		require.ErrorIs(t, err, altda.ErrNotFound)
+
+		missing.err = errors.New("connection refused")
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+
+		// the same commitment is fetched again once it becomes available
+		missing.err = nil
+		missing.addInput([]byte("\x00second frames"))
+		data, err := src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("\x00second frames"), data)
+		_, err = src.Next(context.Background())
+		require.Equal(t, io.EOF, err)
+		require.Equal(t, 3, missing.calls)
+	})
+
+	t.Run("fork gate", func(t *testing.T) {
+		preFork := *cfg
+		altDATime := uint64(200)
+		preFork.AltDATime = &altDATime
+		l1 := &testutils.MockL1Source{}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		factory := NewDataSourceFactory(logger, &preFork, l1, nil, nil)
+		src, err := factory.OpenData(context.Background(), ref, batcherAddr)
+		require.NoError(t, err)
+		data, err := src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data(c1.TxData()), data, "commitments are not resolved before the fork")
+
+		_, err = factory.OpenData(context.Background(), eth.L1BlockRef{Time: altDATime}, batcherAddr)
+		require.ErrorContains(t, err, "no alt-DA fetcher")
+	})
+}
@@ -23,6 +23,12 @@ type SystemConfigL2Fetcher interface {
 	SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error)
 }
 
+// L1InputsPrefetcher is implemented by attributes builders that can fetch the L1 inputs of the payload attributes
+// ahead of time, when the L2 parent block is known by number and L1 origin, but not sealed yet.
+type L1InputsPrefetcher interface {
+	PrefetchL1Inputs(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) error
+}
+
 // FetchingAttributesBuilder fetches inputs for the building of L2 payload attributes on the fly.
 type FetchingAttributesBuilder struct {
 	cfg *rollup.Config
@@ -38,6 +44,22 @@ func NewFetchingAttributesBuilder(cfg *rollup.Config, l1 L1ReceiptsFetcher, l2 S
 	}
 }
 
+// PrefetchL1Inputs fetches the L1 info, and the receipts at the start of an epoch,
+// that PreparePayloadAttributes will use to build on top of the given l2Parent with the given epoch as L1 origin.
+// The L2 parent only has to be known by number and L1 origin, its hash is not used.
+func (ba *FetchingAttributesBuilder) PrefetchL1Inputs(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) error {
+	if l2Parent.L1Origin.Number != epoch.Number {
+		if _, _, err := ba.l1.FetchReceipts(ctx, epoch.Hash); err != nil {
+			return NewTemporaryError(fmt.Errorf("failed to prefetch L1 block info and receipts: %w", err))
+		}
+		return nil
+	}
+	if _, err := ba.l1.InfoByHash(ctx, epoch.Hash); err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to prefetch L1 block info: %w", err))
+	}
+	return nil
+}
+
 // PreparePayloadAttributes prepares a PayloadAttributes template that is ready to build a L2 block with deposits only, on top of the given l2Parent, with the given epoch as L1 origin.
 // The template defaults to NoTxPool=true, and no sequencer transactions: the caller has to modify the template to add transactions,
 // by setting NoTxPool=false as sequencer, or by appending batch transactions as verifier.
@@ -0,0 +1,204 @@
+package derive
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// fakeBlobsFetcher serves blobs by their versioned hash.
+type fakeBlobsFetcher struct {
+	blobs map[common.Hash]*eth.Blob
+	err   error
+	calls int
+}
+
+func (f *fakeBlobsFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]*eth.Blob, 0, len(hashes))
+	for _, h := range hashes {
+		b, ok := f.blobs[h.Hash]
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func (f *fakeBlobsFetcher) addBlob(t *testing.T, rng *rand.Rand, data eth.Data) common.Hash {
+	t.Helper()
+	var b eth.Blob
+	require.NoError(t, b.FromData(data))
+	h := testutils.RandomHash(rng)
+	h[0] = params.BlobTxHashVersion
+	f.blobs[h] = &b
+	return h
+}
+
+func newBlobTx(t *testing.T, signer types.Signer, key *ecdsa.PrivateKey, to common.Address, data []byte, hashes ...common.Hash) *types.Transaction {
+	t.Helper()
+	tx, err := types.SignNewTx(key, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(signer.ChainID()),
+		GasTipCap:  uint256.NewInt(2 * params.GWei),
+		GasFeeCap:  uint256.NewInt(30 * params.GWei),
+		Gas:        100_000,
+		To:         to,
+		Value:      uint256.NewInt(0),
+		Data:       data,
+		BlobFeeCap: uint256.NewInt(params.GWei),
+		BlobHashes: hashes,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func newCalldataTx(t *testing.T, signer types.Signer, key *ecdsa.PrivateKey, to common.Address, data []byte) *types.Transaction {
+	t.Helper()
+	tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   signer.ChainID(),
+		GasTipCap: big.NewInt(2 * params.GWei),
+		GasFeeCap: big.NewInt(30 * params.GWei),
+		Gas:       100_000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      data,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestBlobDataSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	otherPriv := testutils.RandomKey()
+	blobsTime := uint64(0)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+		BlobsTime:         &blobsTime,
+	}
+	signer := cfg.L1Signer()
+	otherInbox := testutils.RandomAddress(rng)
+	logger := testlog.Logger(t, log.LvlCrit)
+
+	blobs := &fakeBlobsFetcher{blobs: map[common.Hash]*eth.Blob{}}
+	h0 := blobs.addBlob(t, rng, eth.Data("unrelated blob"))
+	h1 := blobs.addBlob(t, rng, eth.Data("first batcher blob"))
+	h2 := blobs.addBlob(t, rng, eth.Data("second batcher blob"))
+	h3 := blobs.addBlob(t, rng, eth.Data("blob of other submitter"))
+	h4 := blobs.addBlob(t, rng, eth.Data("third batcher blob"))
+
+	txs := types.Transactions{
+		newBlobTx(t, signer, batcherPriv, otherInbox, nil, h0),
+		newCalldataTx(t, signer, batcherPriv, cfg.BatchInboxAddress, []byte("calldata")),
+		newBlobTx(t, signer, batcherPriv, cfg.BatchInboxAddress, []byte("ignored calldata"), h1, h2),
+		newBlobTx(t, signer, otherPriv, cfg.BatchInboxAddress, nil, h3),
+		newCalldataTx(t, signer, otherPriv, cfg.BatchInboxAddress, []byte("unauthorized calldata")),
+		newBlobTx(t, signer, batcherPriv, cfg.BatchInboxAddress, nil, h4),
+	}
+	ref := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: 10, Time: 100}
+
+	t.Run("hashes", func(t *testing.T) {
+		data, hashes := dataAndHashesFromTxs(txs, cfg, batcherAddr, logger)
+		require.Len(t, data, 4)
+		require.Equal(t, []eth.IndexedBlobHash{
+			{Index: 1, Hash: h1},
+			{Index: 2, Hash: h2},
+			{Index: 4, Hash: h4},
+		}, hashes)
+	})
+
+	t.Run("data", func(t *testing.T) {
+		l1 := &testutils.MockL1Source{}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		src, err := NewDataSourceFactory(logger, cfg, l1, blobs, nil).OpenData(context.Background(), ref, batcherAddr)
+		require.NoError(t, err)
+
+		var out []string
+		for {
+			data, err := src.Next(context.Background())
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			out = append(out, string(data))
+		}
+		require.Equal(t, []string{"calldata", "first batcher blob", "second batcher blob", "third batcher blob"}, out)
+		l1.AssertExpectations(t)
+	})
+
+	t.Run("calldata only", func(t *testing.T) {
+		calls := blobs.calls
+		l1 := &testutils.MockL1Source{}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs[1:2], nil)
+		src := NewBlobDataSource(logger, cfg, l1, blobs, ref, batcherAddr)
+		data, err := src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("calldata"), data)
+		_, err = src.Next(context.Background())
+		require.Equal(t, io.EOF, err)
+		require.Equal(t, calls, blobs.calls, "no blobs should be fetched")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		l1 := &testutils.MockL1Source{}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), nil, ethereum.NotFound)
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), nil, errors.New("connection refused"))
+		src := NewBlobDataSource(logger, cfg, l1, blobs, ref, batcherAddr)
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, ErrReset)
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+
+		failing := &fakeBlobsFetcher{err: errors.New("beacon unavailable")}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		src = NewBlobDataSource(logger, cfg, l1, failing, ref, batcherAddr)
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+
+		missing := &fakeBlobsFetcher{blobs: map[common.Hash]*eth.Blob{}}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		src = NewBlobDataSource(logger, cfg, l1, missing, ref, batcherAddr)
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, ErrReset)
+	})
+
+	t.Run("fork gate", func(t *testing.T) {
+		preFork := *cfg
+		blobsTime := uint64(200)
+		preFork.BlobsTime = &blobsTime
+		l1 := &testutils.MockL1Source{}
+		l1.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), nil, nil)
+		factory := NewDataSourceFactory(logger, &preFork, l1, nil, nil)
+		src, err := factory.OpenData(context.Background(), ref, batcherAddr)
+		require.NoError(t, err)
+		require.IsType(t, &DataSource{}, src)
+
+		_, err = factory.OpenData(context.Background(), eth.L1BlockRef{Time: blobsTime}, batcherAddr)
+		require.Error(t, err, "no blobs fetcher configured")
+	})
+}
@@ -27,18 +27,40 @@ type L1TransactionFetcher interface {
 // batch submitter transactions.
 // This is not a stage in the pipeline, but a wrapper for another stage in the pipeline
 type DataSourceFactory struct {
-	log     log.Logger
-	cfg     *rollup.Config
-	fetcher L1TransactionFetcher
+	log          log.Logger
+	cfg          *rollup.Config
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+	altDAFetcher AltDAInputFetcher
 }
 
-func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher) *DataSourceFactory {
-	return &DataSourceFactory{log: log, cfg: cfg, fetcher: fetcher}
+// NewDataSourceFactory creates a DataSourceFactory. The blobsFetcher and altDAFetcher may be nil
+// as long as the blobs and alt-DA forks, respectively, are not scheduled.
+func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher, altDAFetcher AltDAInputFetcher) *DataSourceFactory {
+	return &DataSourceFactory{log: log, cfg: cfg, fetcher: fetcher, blobsFetcher: blobsFetcher, altDAFetcher: altDAFetcher}
 }
 
 // OpenData returns a DataIter. This struct implements the `Next` function.
-func (ds *DataSourceFactory) OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter {
-	return NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, id, batcherAddr)
+// Starting with the blobs fork, the data is read from both blobs and calldata.
+// Starting with the alt-DA fork, alt-DA commitments in the data are resolved.
+func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error) {
+	altDA := ds.cfg.IsAltDA(ref.Time)
+	if altDA && ds.altDAFetcher == nil {
+		return nil, fmt.Errorf("alt-DA is active at L1 block %s, but no alt-DA fetcher is configured", ref)
+	}
+	var src DataIter
+	if ds.cfg.IsBlobs(ref.Time) {
+		if ds.blobsFetcher == nil {
+			return nil, fmt.Errorf("blobs are active at L1 block %s, but no blobs fetcher is configured", ref)
+		}
+		src = NewBlobDataSource(ds.log, ds.cfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddr)
+	} else {
+		src = NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, ref.ID(), batcherAddr)
+	}
+	if altDA {
+		src = NewAltDADataSource(ds.log.New("origin", ref), src, ds.altDAFetcher)
+	}
+	return src, nil
 }
 
 // DataSource is a fault tolerant approach to fetching data.
@@ -108,19 +130,29 @@ func DataFromEVMTransactions(config *rollup.Config, batcherAddr common.Address,
 	var out []eth.Data
 	l1Signer := config.L1Signer()
 	for j, tx := range txs {
-		if to := tx.To(); to != nil && *to == config.BatchInboxAddress {
-			seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
-			if err != nil {
-				log.Warn("tx in inbox with invalid signature", "index", j, "err", err)
-				continue // bad signature, ignore
-			}
-			// some random L1 user might have sent a transaction to our batch inbox, ignore them
-			if seqDataSubmitter != batcherAddr {
-				log.Warn("tx in inbox with unauthorized submitter", "index", j, "err", err)
-				continue // not an authorized batch submitter, ignore
-			}
+		if isValidBatchTx(tx, l1Signer, config.BatchInboxAddress, batcherAddr, log.New("index", j)) {
 			out = append(out, tx.Data())
 		}
 	}
 	return out
 }
+
+// isValidBatchTx returns whether the transaction was sent to the batch inbox address
+// by the authorized batch submitter.
+func isValidBatchTx(tx *types.Transaction, l1Signer types.Signer, batchInboxAddr, batcherAddr common.Address, log log.Logger) bool {
+	to := tx.To()
+	if to == nil || *to != batchInboxAddr {
+		return false
+	}
+	seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
+	if err != nil {
+		log.Warn("tx in inbox with invalid signature", "hash", tx.Hash(), "err", err)
+		return false // bad signature, ignore
+	}
+	// some random L1 user might have sent a transaction to our batch inbox, ignore them
+	if seqDataSubmitter != batcherAddr {
+		log.Warn("tx in inbox with unauthorized submitter", "addr", seqDataSubmitter, "hash", tx.Hash())
+		return false // not an authorized batch submitter, ignore
+	}
+	return true
+}
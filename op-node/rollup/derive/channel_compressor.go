@@ -0,0 +1,178 @@
+package derive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionAlgo is the algorithm used to compress the batches of a channel.
+type CompressionAlgo string
+
+const (
+	// Zlib is the original channel compression, without channel version byte.
+	Zlib   CompressionAlgo = "zlib"
+	Zstd   CompressionAlgo = "zstd"
+	Brotli CompressionAlgo = "brotli"
+)
+
+var CompressionAlgos = []CompressionAlgo{
+	Zlib,
+	Zstd,
+	Brotli,
+}
+
+func (algo CompressionAlgo) String() string {
+	return string(algo)
+}
+
+func (algo *CompressionAlgo) Set(value string) error {
+	if !ValidCompressionAlgo(CompressionAlgo(value)) {
+		return fmt.Errorf("unknown compression algo: %q", value)
+	}
+	*algo = CompressionAlgo(value)
+	return nil
+}
+
+func (algo *CompressionAlgo) Clone() any {
+	cpy := *algo
+	return &cpy
+}
+
+func ValidCompressionAlgo(value CompressionAlgo) bool {
+	for _, k := range CompressionAlgos {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Channel versions are prefixed to the compressed channel data of versioned channels.
+// Zlib compressed channels are not prefixed, for compatibility with channels before the
+// channel compression fork. The low nibble of the first byte of a zlib stream is always
+// 8 or 15, so it never collides with a channel version.
+const (
+	ChannelVersionBrotli byte = 0x01
+	ChannelVersionZstd   byte = 0x02
+)
+
+const (
+	zlibCMDeflate  = 8
+	zlibCMReserved = 15
+)
+
+// ChannelCompressor compresses the batches of a channel with a single compression
+// algorithm, and prefixes the channel version byte if the algorithm requires one.
+type ChannelCompressor interface {
+	Write([]byte) (int, error)
+	Flush() error
+	Close() error
+	Reset()
+	Len() int
+	Read([]byte) (int, error)
+}
+
+type compressWriter interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+type channelCompressor struct {
+	buf      bytes.Buffer
+	compress compressWriter
+	version  []byte
+}
+
+// NewChannelCompressor creates a ChannelCompressor for the given algorithm, at the
+// best compression level of the algorithm.
+func NewChannelCompressor(algo CompressionAlgo) (ChannelCompressor, error) {
+	c := new(channelCompressor)
+	switch algo {
+	case Zlib, "":
+		w, err := zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		c.compress = w
+	case Zstd:
+		w, err := zstd.NewWriter(&c.buf, zstd.WithEncoderLevel(zstd.SpeedBestCompression), zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		c.compress = w
+		c.version = []byte{ChannelVersionZstd}
+	case Brotli:
+		c.compress = brotli.NewWriterLevel(&c.buf, brotli.BestCompression)
+		c.version = []byte{ChannelVersionBrotli}
+	default:
+		return nil, fmt.Errorf("unknown compression algo: %q", algo)
+	}
+	c.buf.Write(c.version)
+	return c, nil
+}
+
+func (c *channelCompressor) Write(p []byte) (int, error) {
+	return c.compress.Write(p)
+}
+
+func (c *channelCompressor) Flush() error {
+	return c.compress.Flush()
+}
+
+func (c *channelCompressor) Close() error {
+	return c.compress.Close()
+}
+
+func (c *channelCompressor) Reset() {
+	c.buf.Reset()
+	c.buf.Write(c.version)
+	c.compress.Reset(&c.buf)
+}
+
+func (c *channelCompressor) Len() int {
+	return c.buf.Len()
+}
+
+func (c *channelCompressor) Read(p []byte) (int, error) {
+	return c.buf.Read(p)
+}
+
+var errUnversionedChannel = errors.New("channel version is not supported before the channel compression fork")
+
+// NewChannelDecompressor reads the channel version from the channel data, and returns a reader of the
+// decompressed channel. Versioned channels are only accepted after the channel compression fork.
+func NewChannelDecompressor(r io.Reader, versioned bool) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	first, err := br.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	switch cm := first[0] & 0x0f; {
+	case cm == zlibCMDeflate || cm == zlibCMReserved:
+		return zlib.NewReader(br)
+	case !versioned:
+		return nil, errUnversionedChannel
+	}
+	switch first[0] {
+	case ChannelVersionBrotli:
+		_, _ = br.ReadByte()
+		return brotli.NewReader(br), nil
+	case ChannelVersionZstd:
+		_, _ = br.ReadByte()
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unknown channel version: %d", first[0])
+	}
+}
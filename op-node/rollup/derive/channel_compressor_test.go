@@ -0,0 +1,154 @@
+package derive
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+func compressBatches(t *testing.T, algo CompressionAlgo, batches []*SingularBatch) []byte {
+	c, err := NewChannelCompressor(algo)
+	require.NoError(t, err)
+	for _, batch := range batches {
+		require.NoError(t, rlp.Encode(c, NewBatchData(batch)))
+		require.NoError(t, c.Flush())
+	}
+	require.NoError(t, c.Close())
+	data, err := io.ReadAll(c)
+	require.NoError(t, err)
+	return data
+}
+
+func readBatches(t *testing.T, data []byte, versioned bool) ([]*SingularBatch, error) {
+	next, err := BatchReader(bytes.NewReader(data), versioned)
+	if err != nil {
+		return nil, err
+	}
+	var batches []*SingularBatch
+	for {
+		batchData, err := next()
+		if err == io.EOF {
+			return batches, nil
+		} else if err != nil {
+			return nil, err
+		}
+		batch, ok := batchData.inner.(*SingularBatch)
+		require.True(t, ok)
+		batches = append(batches, batch)
+	}
+}
+
+func TestChannelCompressorVersion(t *testing.T) {
+	batches := []*SingularBatch{{Timestamp: 1, Transactions: []hexutil.Bytes{{0x01}}}}
+	for _, algo := range CompressionAlgos {
+		algo := algo
+		t.Run(algo.String(), func(t *testing.T) {
+			data := compressBatches(t, algo, batches)
+			switch algo {
+			case Zlib:
+				require.Equal(t, byte(zlibCMDeflate), data[0]&0x0f)
+			case Zstd:
+				require.Equal(t, ChannelVersionZstd, data[0])
+			case Brotli:
+				require.Equal(t, ChannelVersionBrotli, data[0])
+			}
+
+			out, err := readBatches(t, data, true)
+			require.NoError(t, err)
+			require.Equal(t, batches, out)
+
+			// Versioned channels must be rejected before the channel compression fork.
+			out, err = readBatches(t, data, false)
+			if algo == Zlib {
+				require.NoError(t, err)
+				require.Equal(t, batches, out)
+			} else {
+				require.ErrorIs(t, err, errUnversionedChannel)
+			}
+		})
+	}
+}
+
+func TestChannelCompressorReset(t *testing.T) {
+	for _, algo := range CompressionAlgos {
+		c, err := NewChannelCompressor(algo)
+		require.NoError(t, err)
+		_, err = c.Write(bytes.Repeat([]byte{0xaa}, 1000))
+		require.NoError(t, err)
+		require.NoError(t, c.Close())
+		first, err := io.ReadAll(c)
+		require.NoError(t, err)
+
+		c.Reset()
+		_, err = c.Write(bytes.Repeat([]byte{0xaa}, 1000))
+		require.NoError(t, err)
+		require.NoError(t, c.Close())
+		second, err := io.ReadAll(c)
+		require.NoError(t, err)
+		require.Equal(t, first, second, "compressor %s must start a new channel on reset", algo)
+	}
+}
+
+func TestChannelDecompressorUnknownVersion(t *testing.T) {
+	_, err := NewChannelDecompressor(bytes.NewReader([]byte{0x03, 0x00}), true)
+	require.ErrorContains(t, err, "unknown channel version")
+}
+
+// FuzzChannelCompressor checks that batches round trip through every compression algorithm,
+// and that all algorithms decode to the same batches.
+func FuzzChannelCompressor(f *testing.F) {
+	f.Add([]byte{}, uint64(0), uint64(0), []byte{})
+	f.Add([]byte{0x01}, uint64(1), uint64(2), bytes.Repeat([]byte{0x42}, 300))
+	f.Fuzz(func(t *testing.T, parentHash []byte, epochNum, timestamp uint64, txData []byte) {
+		var batches []*SingularBatch
+		// split the tx data in a few batches, to cover flushes between batches
+		for i := 0; i < 3; i++ {
+			batches = append(batches, &SingularBatch{
+				ParentHash:   common.BytesToHash(parentHash),
+				EpochNum:     rollup.Epoch(epochNum),
+				EpochHash:    common.BytesToHash(txData),
+				Timestamp:    timestamp + uint64(i),
+				Transactions: []hexutil.Bytes{append([]byte{byte(i)}, txData...)},
+			})
+		}
+		var decoded [][]*SingularBatch
+		for _, algo := range CompressionAlgos {
+			data := compressBatches(t, algo, batches)
+			out, err := readBatches(t, data, true)
+			require.NoError(t, err, "algo %s", algo)
+			require.Equal(t, batches, out, "algo %s", algo)
+			decoded = append(decoded, out)
+		}
+		for i := 1; i < len(decoded); i++ {
+			require.Equal(t, decoded[0], decoded[i])
+		}
+	})
+}
+
+// FuzzChannelDecompressor checks that arbitrary channel data never panics the decompressors.
+func FuzzChannelDecompressor(f *testing.F) {
+	for _, algo := range CompressionAlgos {
+		c, err := NewChannelCompressor(algo)
+		require.NoError(f, err)
+		_, err = c.Write([]byte("channel data"))
+		require.NoError(f, err)
+		require.NoError(f, c.Close())
+		data, err := io.ReadAll(c)
+		require.NoError(f, err)
+		f.Add(data, true)
+	}
+	f.Fuzz(func(t *testing.T, data []byte, versioned bool) {
+		r, err := NewChannelDecompressor(bytes.NewReader(data), versioned)
+		if err != nil {
+			return
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(r, int64(MaxRLPBytesPerChannel)))
+	})
+}
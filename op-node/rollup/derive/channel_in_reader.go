@@ -24,7 +24,7 @@ type ChannelInReader struct {
 
 	nextBatchFn func() (*BatchData, error)
 
-	prev *ChannelBank
+	prev NextDataProvider
 
 	metrics Metrics
 }
@@ -32,7 +32,7 @@ type ChannelInReader struct {
 var _ ResettableStage = (*ChannelInReader)(nil)
 
 // NewChannelInReader creates a ChannelInReader, which should be Reset(origin) before use.
-func NewChannelInReader(cfg *rollup.Config, log log.Logger, prev *ChannelBank, metrics Metrics) *ChannelInReader {
+func NewChannelInReader(cfg *rollup.Config, log log.Logger, prev NextDataProvider, metrics Metrics) *ChannelInReader {
 	return &ChannelInReader{
 		cfg:     cfg,
 		log:     log,
@@ -47,7 +47,7 @@ func (cr *ChannelInReader) Origin() eth.L1BlockRef {
 
 // TODO: Take full channel for better logging
 func (cr *ChannelInReader) WriteChannel(data []byte) error {
-	if f, err := BatchReader(bytes.NewBuffer(data)); err == nil {
+	if f, err := BatchReader(bytes.NewBuffer(data), cr.cfg.IsChannelCompression(cr.Origin().Time)); err == nil {
 		cr.nextBatchFn = f
 		cr.metrics.RecordChannelInputBytes(len(data))
 		return nil
@@ -74,6 +74,22 @@ func NewChannelOut(batchType uint, compress Compressor, spanBatchBuilder *SpanBa
 	}
 }
 
+// NewChannelOutWithID creates a ChannelOut with the given channel ID instead of a random one.
+// It is used to rebuild a channel of which some frames may have been submitted already.
+func NewChannelOutWithID(batchType uint, compress Compressor, spanBatchBuilder *SpanBatchBuilder, id ChannelID) (ChannelOut, error) {
+	co, err := NewChannelOut(batchType, compress, spanBatchBuilder)
+	if err != nil {
+		return nil, err
+	}
+	switch co := co.(type) {
+	case *SingularChannelOut:
+		co.id = id
+	case *SpanChannelOut:
+		co.id = id
+	}
+	return co, nil
+}
+
 type SingularChannelOut struct {
 	id ChannelID
 	// Frame ID of the next frame to emit. Increment after emitting
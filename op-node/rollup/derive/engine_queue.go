@@ -65,6 +65,19 @@ type EngineControl interface {
 	BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool)
 }
 
+// SafeHeadListener is notified of the L2 safe head derived from each L1 block.
+// The notification for a particular L1 block may be repeated, and always carries
+// the latest safe head derived while processing that L1 block.
+type SafeHeadListener interface {
+	// Enabled reports if the listener is actively using the posted data, so notifications can be skipped otherwise.
+	Enabled() bool
+	// SafeHeadUpdated indicates that newSafeHead was derived after processing data up to and including l1Block.
+	SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error
+	// SafeHeadReset indicates that the derivation pipeline was reset back to the given safe head.
+	// Any records of later safe heads are no longer canonical.
+	SafeHeadReset(resetSafeHead eth.L2BlockRef) error
+}
+
 // Max memory used for buffering unsafe payloads
 const maxUnsafePayloadsMemory = 500 * 1024 * 1024
 
@@ -145,12 +158,20 @@ type EngineQueue struct {
 	l1Fetcher L1Fetcher
 
 	syncCfg *sync.Config
+
+	safeHeadNotifs SafeHeadListener
+	// The safe head and L1 block that the safeHeadNotifs listener was last notified of.
+	notifiedSafeHead   eth.L2BlockRef
+	notifiedSafeHeadL1 eth.BlockID
+	// The safe head the pipeline was last reset to. The L1 block it was derived from is unknown,
+	// so the listener is only notified once the safe head progressed past it.
+	resetSafeHead eth.L2BlockRef
 }
 
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config, safeHeadListener SafeHeadListener) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
@@ -161,6 +182,7 @@ func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics M
 		prev:           prev,
 		l1Fetcher:      l1Fetcher,
 		syncCfg:        syncCfg,
+		safeHeadNotifs: safeHeadListener,
 	}
 }
 
@@ -257,6 +279,10 @@ func (eq *EngineQueue) Step(ctx context.Context) error {
 	if eq.needForkchoiceUpdate {
 		return eq.tryUpdateEngine(ctx)
 	}
+	// Only notify of the safe head once the engine was updated to it.
+	if err := eq.notifySafeHead(); err != nil {
+		return err
+	}
 	// Trying unsafe payload should be done before safe attributes
 	// It allows the unsafe head can move forward while the long-range consolidation is in progress.
 	if eq.unsafePayloads.Len() > 0 {
@@ -413,6 +439,24 @@ func (eq *EngineQueue) postProcessSafeL2() {
 	}
 }
 
+// notifySafeHead informs the safe head listener of the current safe head and the L1 block it was derived from,
+// if the listener was not yet notified of it.
+func (eq *EngineQueue) notifySafeHead() error {
+	if !eq.safeHeadNotifs.Enabled() || eq.safeHead.Number <= eq.resetSafeHead.Number {
+		return nil
+	}
+	l1Block := eq.origin.ID()
+	if eq.notifiedSafeHead == eq.safeHead && eq.notifiedSafeHeadL1 == l1Block {
+		return nil
+	}
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(eq.safeHead, l1Block); err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to notify safe head listener of safe head %s at L1 block %s: %w", eq.safeHead, l1Block, err))
+	}
+	eq.notifiedSafeHead = eq.safeHead
+	eq.notifiedSafeHeadL1 = l1Block
+	return nil
+}
+
 func (eq *EngineQueue) logSyncProgress(reason string) {
 	eq.log.Info("Sync progress",
 		"reason", reason,
@@ -826,6 +870,15 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to fetch L1 config of L2 block %s: %w", pipelineL2.ID(), err))
 	}
+	if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to reset safe head listener to %s: %w", safe, err))
+	}
+	if safe.ID() == eq.cfg.Genesis.L2 {
+		// The rollup genesis block is always safe by definition, as of the L1 genesis block.
+		if err := eq.safeHeadNotifs.SafeHeadUpdated(safe, eq.cfg.Genesis.L1); err != nil {
+			return NewTemporaryError(fmt.Errorf("failed to notify safe head listener of genesis: %w", err))
+		}
+	}
 	eq.log.Debug("Reset engine queue", "safeHead", safe, "unsafe", unsafe, "safe_timestamp", safe.Time, "unsafe_timestamp", unsafe.Time, "l1Origin", l1Origin)
 	eq.unsafeHead = unsafe
 	eq.engineSyncTarget = unsafe
@@ -836,6 +889,9 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	eq.resetBuildingState()
 	eq.needForkchoiceUpdate = true
 	eq.finalityData = eq.finalityData[:0]
+	eq.notifiedSafeHead = eth.L2BlockRef{}
+	eq.notifiedSafeHeadL1 = eth.BlockID{}
+	eq.resetSafeHead = safe
 	// note: finalizedL1 and triedFinalizeAt do not reset, since these do not change between reorgs.
 	// note: we do not clear the unsafe payloads queue; if the payloads are not applicable anymore the parent hash checks will clear out the old payloads.
 	eq.origin = pipelineOrigin
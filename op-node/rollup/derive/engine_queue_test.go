@@ -2,6 +2,7 @@ package derive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -248,7 +249,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 
 	prev := &fakeAttributesQueue{}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, noopSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -482,7 +483,7 @@ func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refE}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, noopSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -813,7 +814,7 @@ func TestVerifyNewL1Origin(t *testing.T) {
 			}, nil)
 
 			prev := &fakeAttributesQueue{origin: refE}
-			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, noopSafeHeadListener{})
 			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 			require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -911,7 +912,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	}
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, noopSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	id := eth.PayloadID{0xff}
@@ -1081,7 +1082,7 @@ func TestResetLoop(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, noopSafeHeadListener{})
 	eq.unsafeHead = refA2
 	eq.engineSyncTarget = refA2
 	eq.safeHead = refA1
@@ -1179,7 +1180,7 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, noopSafeHeadListener{})
 	eq.unsafeHead = refA2
 	eq.safeHead = refA0
 	eq.finalized = refA0
@@ -1195,3 +1196,85 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+// noopSafeHeadListener is a SafeHeadListener that is not enabled, like the one of a node without a safe head database.
+type noopSafeHeadListener struct{}
+
+func (noopSafeHeadListener) Enabled() bool {
+	return false
+}
+
+func (noopSafeHeadListener) SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error {
+	return nil
+}
+
+func (noopSafeHeadListener) SafeHeadReset(resetSafeHead eth.L2BlockRef) error {
+	return nil
+}
+
+type safeHeadUpdate struct {
+	safeHead eth.L2BlockRef
+	l1Block  eth.BlockID
+}
+
+type fakeSafeHeadListener struct {
+	updates []safeHeadUpdate
+	resets  []eth.L2BlockRef
+	err     error
+}
+
+func (f *fakeSafeHeadListener) Enabled() bool {
+	return true
+}
+
+func (f *fakeSafeHeadListener) SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.updates = append(f.updates, safeHeadUpdate{safeHead: newSafeHead, l1Block: l1Block})
+	return nil
+}
+
+func (f *fakeSafeHeadListener) SafeHeadReset(resetSafeHead eth.L2BlockRef) error {
+	f.resets = append(f.resets, resetSafeHead)
+	return nil
+}
+
+func TestEngineQueue_NotifySafeHead(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	rng := rand.New(rand.NewSource(1234))
+
+	refA := testutils.RandomBlockRef(rng)
+	refB := testutils.NextRandomRef(rng, refA)
+	refA0 := testutils.RandomL2BlockRef(rng)
+	refA1 := testutils.NextRandomL2Ref(rng, 2, refA0, refA.ID())
+
+	listener := &fakeSafeHeadListener{}
+	eq := NewEngineQueue(logger, &rollup.Config{}, &testutils.MockEngine{}, metrics.NoopMetrics, &fakeAttributesQueue{origin: refA}, &testutils.MockL1Source{}, &sync.Config{}, listener)
+	eq.origin = refA
+	eq.safeHead = refA0
+	eq.resetSafeHead = refA0
+
+	// The L1 block the reset safe head was derived from is unknown, so it is not notified
+	require.NoError(t, eq.notifySafeHead())
+	require.Empty(t, listener.updates)
+
+	// Notified once the safe head progresses
+	eq.safeHead = refA1
+	require.NoError(t, eq.notifySafeHead())
+	require.NoError(t, eq.notifySafeHead())
+	require.Equal(t, []safeHeadUpdate{{safeHead: refA1, l1Block: refA.ID()}}, listener.updates)
+
+	// Notified again when the same safe head is still the latest after processing the next L1 block
+	eq.origin = refB
+	require.NoError(t, eq.notifySafeHead())
+	require.Equal(t, []safeHeadUpdate{
+		{safeHead: refA1, l1Block: refA.ID()},
+		{safeHead: refA1, l1Block: refB.ID()},
+	}, listener.updates)
+
+	// Failing to notify is a temporary error, to be retried
+	listener.err = errors.New("disk full")
+	eq.safeHead = testutils.NextRandomL2Ref(rng, 2, refA1, refB.ID())
+	require.ErrorIs(t, eq.notifySafeHead(), ErrTemporary)
+}
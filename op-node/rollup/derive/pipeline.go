@@ -79,23 +79,28 @@ type DerivationPipeline struct {
 	eng       EngineQueueStage
 
 	metrics Metrics
+	tracer  Tracer
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
-func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, syncCfg *sync.Config) *DerivationPipeline {
+// The l1Blobs fetcher is only used once the blobs fork is active, and may be nil before that.
+// Likewise, the altDA fetcher is only used once the alt-DA fork is active.
+// The safeHeadListener is notified of every safe head update, and of resets of the safe head.
+// The tracer records the data passed between the stages, see Replay to re-derive from a recorded trace.
+func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, l1Blobs L1BlobsFetcher, altDA AltDAInputFetcher, engine Engine, metrics Metrics, syncCfg *sync.Config, safeHeadListener SafeHeadListener, tracer Tracer) *DerivationPipeline {
 	// Pull stages
 	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher)
-	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher) // auxiliary stage for L1Retrieval
-	l1Src := NewL1Retrieval(log, dataSrc, l1Traversal)
-	frameQueue := NewFrameQueue(log, l1Src)
-	bank := NewChannelBank(log, cfg, frameQueue, l1Fetcher, metrics)
-	chInReader := NewChannelInReader(cfg, log, bank, metrics)
-	batchQueue := NewBatchQueue(log, cfg, chInReader, engine)
+	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher, l1Blobs, altDA) // auxiliary stage for L1Retrieval
+	l1Src := NewL1Retrieval(log, dataSrc, &tracingL1BlockProvider{l1Traversal, tracer})
+	frameQueue := NewFrameQueue(log, &tracingDataProvider{l1Src, tracer, TraceData})
+	bank := NewChannelBank(log, cfg, &tracingFrameProvider{frameQueue, tracer}, l1Fetcher, metrics)
+	chInReader := NewChannelInReader(cfg, log, &tracingDataProvider{bank, tracer, TraceChannel}, metrics)
+	batchQueue := NewBatchQueue(log, cfg, &tracingBatchProvider{chInReader, tracer}, engine)
 	attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, engine)
 	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue)
 
 	// Step stages
-	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher, syncCfg)
+	eng := NewEngineQueue(log, cfg, engine, metrics, &tracingAttributesProvider{attributesQueue, tracer}, l1Fetcher, syncCfg, safeHeadListener)
 
 	// Reset from engine queue then up from L1 Traversal. The stages do not talk to each other during
 	// the reset, but after the engine queue, this is the order in which the stages could talk to each other.
@@ -111,6 +116,7 @@ func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetch
 		eng:       eng,
 		metrics:   metrics,
 		traversal: l1Traversal,
+		tracer:    tracer,
 	}
 }
 
@@ -200,6 +206,10 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 	if dp.resetting < len(dp.stages) {
 		if err := dp.stages[dp.resetting].Reset(ctx, dp.eng.Origin(), dp.eng.SystemConfig()); err == io.EOF {
 			dp.log.Debug("reset of stage completed", "stage", dp.resetting, "origin", dp.eng.Origin())
+			if dp.resetting == 0 {
+				// The engine queue determined where the other stages reset to.
+				dp.traceReset()
+			}
 			dp.resetting += 1
 			return nil
 		} else if err != nil {
@@ -221,3 +231,14 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 		return nil
 	}
 }
+
+func (dp *DerivationPipeline) traceReset() {
+	origin, sysCfg, safeHead := dp.eng.Origin(), dp.eng.SystemConfig(), dp.eng.PendingSafeL2Head()
+	dp.tracer.TraceEntry(&TraceEntry{
+		Stage:        TraceReset,
+		Origin:       origin.ID(),
+		L1Block:      &origin,
+		SystemConfig: &sysCfg,
+		Parent:       &safeHead,
+	})
+}
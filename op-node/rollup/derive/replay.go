@@ -0,0 +1,407 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Replay re-derives the payload attributes from the L1 inputs of a recorded trace, and records the result to the tracer.
+//
+// Like the fault-proof program, the replay runs fully offline: the L1 data, the deposits and the L1 info
+// of the attributes are taken from the trace, while the frames, channels, batches and attributes are derived again
+// by the current pipeline stages. The derived L2 blocks are not executed: a fake engine returns the L2 blocks
+// of the recorded trace as long as the derived attributes match the recorded attributes.
+//
+// Every reset in the trace starts a new replay segment from the recorded reset origin and safe head.
+// Resets during the replay restart from the L1 origin of the safe head, and a segment is aborted
+// if derivation makes no progress after repeated temporary errors or resets.
+// A segment ends early once derivation builds on an L2 block that the trace has no attributes for,
+// i.e. once the replay diverged from the recorded chain or went past the end of the recording.
+// The replay does not support span batches that overlap with blocks before the reset safe head,
+// as the fake engine has no L2 transactions to compare them with.
+func Replay(ctx context.Context, log log.Logger, cfg *rollup.Config, metrics Metrics, trace []TraceEntry, tracer Tracer) error {
+	segments, err := replaySegments(trace)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return errors.New("trace has no pipeline reset to start replaying from")
+	}
+	for i, seg := range segments {
+		log.Info("Replaying derivation", "segment", i, "origin", seg.reset.L1Block, "safe_head", seg.reset.Parent, "l1_blocks", len(seg.l1Blocks))
+		if err := seg.replay(ctx, log, cfg, metrics, tracer); err != nil {
+			return fmt.Errorf("failed to replay segment %d from %s: %w", i, seg.reset.L1Block, err)
+		}
+	}
+	return nil
+}
+
+// errReplayDiverged is returned by the replay attributes builder if no recorded attributes build on the L2 parent.
+var errReplayDiverged = errors.New("no recorded attributes build on the L2 parent")
+
+// replaySegment is the part of a trace after a pipeline reset, up to the next reset.
+type replaySegment struct {
+	reset    TraceEntry
+	l1Blocks []eth.L1BlockRef
+	// data is the batcher data retrieved from each L1 block, by L1 block hash
+	data map[common.Hash][]hexutil.Bytes
+	// attributes are the recorded attributes, by the hash of the L2 block they build on
+	attributes map[common.Hash]*TraceEntry
+	// children are the recorded L2 blocks that attributes build on, by their parent hash
+	children map[common.Hash]eth.L2BlockRef
+}
+
+func replaySegments(trace []TraceEntry) ([]*replaySegment, error) {
+	var segments []*replaySegment
+	var seg *replaySegment
+	for i := range trace {
+		entry := &trace[i]
+		if entry.Stage == TraceReset {
+			if entry.L1Block == nil || entry.SystemConfig == nil || entry.Parent == nil {
+				return nil, fmt.Errorf("incomplete reset entry %d", i)
+			}
+			seg = &replaySegment{
+				reset:      *entry,
+				data:       make(map[common.Hash][]hexutil.Bytes),
+				attributes: make(map[common.Hash]*TraceEntry),
+				children:   make(map[common.Hash]eth.L2BlockRef),
+			}
+			segments = append(segments, seg)
+			continue
+		}
+		if seg == nil {
+			// Entries before the first reset cannot be replayed, the pipeline state they build on is unknown.
+			continue
+		}
+		switch entry.Stage {
+		case TraceL1Block:
+			if entry.L1Block == nil {
+				return nil, fmt.Errorf("incomplete L1 block entry %d", i)
+			}
+			seg.l1Blocks = append(seg.l1Blocks, *entry.L1Block)
+		case TraceData:
+			seg.data[entry.Origin.Hash] = append(seg.data[entry.Origin.Hash], entry.Data)
+		case TraceAttributes:
+			if entry.Parent == nil || entry.Attributes == nil {
+				return nil, fmt.Errorf("incomplete attributes entry %d", i)
+			}
+			seg.attributes[entry.Parent.Hash] = entry
+			seg.children[entry.Parent.ParentHash] = *entry.Parent
+		}
+	}
+	return segments, nil
+}
+
+func (seg *replaySegment) replay(ctx context.Context, log log.Logger, cfg *rollup.Config, metrics Metrics, tracer Tracer) error {
+	if len(seg.l1Blocks) == 0 {
+		return nil
+	}
+	tracer.TraceEntry(&seg.reset)
+
+	src := &replayL1Source{blocks: seg.l1Blocks, data: seg.data}
+	eng := newReplayEngine(seg)
+	frameQueue := NewFrameQueue(log, &tracingDataProvider{src, tracer, TraceData})
+	bank := NewChannelBank(log, cfg, &tracingFrameProvider{frameQueue, tracer}, nil, metrics)
+	chInReader := NewChannelInReader(cfg, log, &tracingDataProvider{bank, tracer, TraceChannel}, metrics)
+	batchQueue := NewBatchQueue(log, cfg, &tracingBatchProvider{chInReader, tracer}, eng)
+	attributesQueue := NewAttributesQueue(log, cfg, &replayAttributesBuilder{seg}, batchQueue)
+	attrs := &tracingAttributesProvider{attributesQueue, tracer}
+	stages := []ResettableStage{frameQueue, bank, chInReader, batchQueue, attributesQueue}
+
+	sysCfg := *seg.reset.SystemConfig
+	if err := resetStages(ctx, stages, *seg.reset.L1Block, sysCfg); err != nil {
+		return err
+	}
+	ref := src.Origin()
+	tracer.TraceEntry(&TraceEntry{Stage: TraceL1Block, Origin: ref.ID(), L1Block: &ref})
+	safeHead := *seg.reset.Parent
+	// retries counts the temporary errors and resets since the safe head last advanced.
+	// The replay runs offline, so retrying does not fetch any new data: it only helps
+	// if the stages make progress with the data they have.
+	retries := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		next, err := attrs.NextAttributes(ctx, safeHead)
+		if err == io.EOF {
+			if !src.advance() {
+				return nil
+			}
+			ref := src.Origin()
+			tracer.TraceEntry(&TraceEntry{Stage: TraceL1Block, Origin: ref.ID(), L1Block: &ref})
+			continue
+		} else if errors.Is(err, NotEnoughData) {
+			continue
+		} else if errors.Is(err, errReplayDiverged) {
+			log.Warn("Stopping replay segment, derivation builds on an L2 block without recorded attributes", "origin", src.Origin(), "safe_head", safeHead)
+			return nil
+		} else if errors.Is(err, ErrTemporary) || errors.Is(err, ErrReset) {
+			if retries >= replayMaxRetries {
+				return fmt.Errorf("no progress after %d retries at origin %s and safe head %s: %w", retries, src.Origin(), safeHead, err)
+			}
+			retries++
+			log.Warn("Error while replaying derivation", "origin", src.Origin(), "safe_head", safeHead, "err", err)
+			if errors.Is(err, ErrReset) {
+				// Like the live pipeline, restart from the L1 origin of the safe head,
+				// or from the start of the segment if the replay does not have it.
+				src.rewind(safeHead.L1Origin)
+				base := src.Origin()
+				if err := resetStages(ctx, stages, base, sysCfg); err != nil {
+					return err
+				}
+				tracer.TraceEntry(&TraceEntry{Stage: TraceReset, Origin: base.ID(), L1Block: &base, SystemConfig: &sysCfg, Parent: &safeHead})
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+		retries = 0
+		safeHead = eng.insert(next.attributes, safeHead)
+	}
+}
+
+// replayMaxRetries is the number of temporary errors and resets without progress after which a replay segment is aborted.
+const replayMaxRetries = 10
+
+func resetStages(ctx context.Context, stages []ResettableStage, base eth.L1BlockRef, sysCfg eth.SystemConfig) error {
+	for _, stage := range stages {
+		if err := stage.Reset(ctx, base, sysCfg); err != io.EOF {
+			return fmt.Errorf("failed to reset stage: %w", err)
+		}
+	}
+	return nil
+}
+
+// replayL1Source serves the recorded batcher data of the recorded L1 blocks, one L1 block at a time.
+type replayL1Source struct {
+	blocks []eth.L1BlockRef
+	data   map[common.Hash][]hexutil.Bytes
+	// current is the index of the current L1 block
+	current int
+	// next is the index of the next data item of the current L1 block
+	next int
+}
+
+func (s *replayL1Source) Origin() eth.L1BlockRef {
+	return s.blocks[s.current]
+}
+
+func (s *replayL1Source) NextData(_ context.Context) ([]byte, error) {
+	data := s.data[s.Origin().Hash]
+	if s.next >= len(data) {
+		return nil, io.EOF
+	}
+	s.next++
+	return data[s.next-1], nil
+}
+
+// advance moves to the next L1 block, and returns false if there are no more L1 blocks.
+func (s *replayL1Source) advance() bool {
+	if s.current+1 >= len(s.blocks) {
+		return false
+	}
+	s.current++
+	s.next = 0
+	return true
+}
+
+// rewind moves back to the given L1 block, or to the first L1 block if the given block was not recorded.
+func (s *replayL1Source) rewind(id eth.BlockID) {
+	current := 0
+	for i, block := range s.blocks[:s.current+1] {
+		if block.ID() == id {
+			current = i
+		}
+	}
+	s.current = current
+	s.next = 0
+}
+
+// replayAttributesBuilder serves the L1 derived part of the recorded attributes: the L1 info and deposits.
+type replayAttributesBuilder struct {
+	seg *replaySegment
+}
+
+func (b *replayAttributesBuilder) PreparePayloadAttributes(_ context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+	entry, ok := b.seg.attributes[l2Parent.Hash]
+	if !ok {
+		return nil, fmt.Errorf("%w %s in epoch %s", errReplayDiverged, l2Parent, epoch)
+	}
+	attrs := *entry.Attributes
+	attrs.Transactions = nil
+	for _, tx := range entry.Attributes.Transactions {
+		if len(tx) == 0 || tx[0] != types.DepositTxType {
+			break
+		}
+		attrs.Transactions = append(attrs.Transactions, tx)
+	}
+	attrs.NoTxPool = false
+	attrs.DecryptionKey = nil
+	return &attrs, nil
+}
+
+// replayEngine serves the L2 blocks that the replay derived so far.
+type replayEngine struct {
+	seg *replaySegment
+
+	blocks map[uint64]eth.L2BlockRef
+}
+
+func newReplayEngine(seg *replaySegment) *replayEngine {
+	safeHead := *seg.reset.Parent
+	return &replayEngine{
+		seg:    seg,
+		blocks: map[uint64]eth.L2BlockRef{safeHead.Number: safeHead},
+	}
+}
+
+// insert returns the L2 block that the attributes derive on top of the parent.
+// That is the L2 block of the recorded trace if the attributes match the recorded attributes,
+// or else a block with a made-up hash, so the replay can continue on a diverged chain.
+func (e *replayEngine) insert(attrs *eth.PayloadAttributes, parent eth.L2BlockRef) eth.L2BlockRef {
+	var ref eth.L2BlockRef
+	if recorded, ok := e.seg.attributes[parent.Hash]; ok && sameAttributes(recorded.Attributes, attrs) {
+		ref = e.seg.children[parent.Hash]
+	}
+	if ref == (eth.L2BlockRef{}) {
+		ref = e.madeUpBlock(attrs, parent)
+	}
+	e.blocks[ref.Number] = ref
+	return ref
+}
+
+func (e *replayEngine) madeUpBlock(attrs *eth.PayloadAttributes, parent eth.L2BlockRef) eth.L2BlockRef {
+	data, _ := json.Marshal(attrs)
+	ref := eth.L2BlockRef{
+		Hash:       crypto.Keccak256Hash(parent.Hash[:], data),
+		Number:     parent.Number + 1,
+		ParentHash: parent.Hash,
+		Time:       uint64(attrs.Timestamp),
+	}
+	if len(attrs.Transactions) > 0 {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(attrs.Transactions[0]); err == nil {
+			if info, err := L1InfoDepositTxData(tx.Data()); err == nil {
+				ref.L1Origin = eth.BlockID{Hash: info.BlockHash, Number: info.Number}
+				ref.SequenceNumber = info.SequenceNumber
+			}
+		}
+	}
+	return ref
+}
+
+func (e *replayEngine) L2BlockRefByNumber(_ context.Context, num uint64) (eth.L2BlockRef, error) {
+	if ref, ok := e.blocks[num]; ok {
+		return ref, nil
+	}
+	return eth.L2BlockRef{}, ethereum.NotFound
+}
+
+func (e *replayEngine) PayloadByNumber(_ context.Context, _ uint64) (*eth.ExecutionPayload, error) {
+	return nil, ethereum.NotFound
+}
+
+func sameAttributes(a, b *eth.PayloadAttributes) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
+
+// TraceDivergence describes the first entry of a stage that differs between two traces.
+type TraceDivergence struct {
+	Stage TraceStage
+	// Index is the position of the entry among the entries of the same stage.
+	Index int
+	// A and B are the differing entries, one of them is nil if the trace has fewer entries of the stage.
+	A, B *TraceEntry
+}
+
+// DiffTraces compares the entries of each stage of two traces in order, and returns the earliest divergence,
+// or nil if the traces match. The divergence with the lowest L1 origin is the earliest, ties are broken by stage order.
+//
+// Entries at or after the last L1 block of either trace are not compared, as a recording may have stopped
+// half-way through processing an L1 block.
+func DiffTraces(a, b []TraceEntry) *TraceDivergence {
+	cutoff := min(lastL1Block(a), lastL1Block(b))
+	streamsA, streamsB := traceStreams(a, cutoff), traceStreams(b, cutoff)
+
+	var first *TraceDivergence
+	for _, stage := range traceStages {
+		div := diffStream(stage, streamsA[stage], streamsB[stage])
+		if div == nil {
+			continue
+		}
+		if first == nil || div.originNumber() < first.originNumber() {
+			first = div
+		}
+	}
+	return first
+}
+
+func (d *TraceDivergence) originNumber() uint64 {
+	switch {
+	case d.A == nil:
+		return d.B.Origin.Number
+	case d.B == nil:
+		return d.A.Origin.Number
+	default:
+		return min(d.A.Origin.Number, d.B.Origin.Number)
+	}
+}
+
+func lastL1Block(trace []TraceEntry) uint64 {
+	for i := len(trace) - 1; i >= 0; i-- {
+		if trace[i].Stage == TraceL1Block {
+			return trace[i].Origin.Number
+		}
+	}
+	return 0
+}
+
+func traceStreams(trace []TraceEntry, cutoff uint64) map[TraceStage][]*TraceEntry {
+	streams := make(map[TraceStage][]*TraceEntry)
+	for i := range trace {
+		entry := &trace[i]
+		if entry.Origin.Number >= cutoff {
+			continue
+		}
+		streams[entry.Stage] = append(streams[entry.Stage], entry)
+	}
+	return streams
+}
+
+func diffStream(stage TraceStage, a, b []*TraceEntry) *TraceDivergence {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		div := &TraceDivergence{Stage: stage, Index: i}
+		if i < len(a) {
+			div.A = a[i]
+		}
+		if i < len(b) {
+			div.B = b[i]
+		}
+		if div.A == nil || div.B == nil {
+			return div
+		}
+		dataA, errA := json.Marshal(div.A)
+		dataB, errB := json.Marshal(div.B)
+		if errA != nil || errB != nil || !bytes.Equal(dataA, dataB) {
+			return div
+		}
+	}
+	return nil
+}
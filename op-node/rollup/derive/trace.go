@@ -0,0 +1,258 @@
+package derive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// TraceStage identifies the point in the derivation pipeline that a trace entry was recorded at.
+type TraceStage string
+
+const (
+	// TraceReset marks a reset of the derivation pipeline: the entries that follow build on the reset origin and safe head.
+	TraceReset TraceStage = "reset"
+	// TraceL1Block is an L1 block that the pipeline traversed.
+	TraceL1Block TraceStage = "l1"
+	// TraceData is a piece of batcher data retrieved from an L1 block.
+	TraceData TraceStage = "data"
+	// TraceFrame is a frame parsed from batcher data.
+	TraceFrame TraceStage = "frame"
+	// TraceChannel is the data of a channel that was read out of the channel bank.
+	TraceChannel TraceStage = "channel"
+	// TraceBatch is a batch decoded from channel data.
+	TraceBatch TraceStage = "batch"
+	// TraceAttributes are the payload attributes derived from a batch, including any shutter decryption key.
+	TraceAttributes TraceStage = "attributes"
+)
+
+// traceStages lists the traced stages in pipeline order, upstream first.
+var traceStages = []TraceStage{TraceReset, TraceL1Block, TraceData, TraceFrame, TraceChannel, TraceBatch, TraceAttributes}
+
+// TraceEntry is a single input or output of a derivation pipeline stage.
+// Only the fields that apply to the stage of the entry are set.
+type TraceEntry struct {
+	Stage TraceStage `json:"stage"`
+	// Origin is the L1 block that the stage was processing when the entry was recorded.
+	Origin eth.BlockID `json:"origin"`
+
+	L1Block      *eth.L1BlockRef   `json:"l1Block,omitempty"`
+	SystemConfig *eth.SystemConfig `json:"systemConfig,omitempty"`
+	// Parent is the L2 safe head after a reset, or the L2 block that attributes build on.
+	Parent *eth.L2BlockRef `json:"parent,omitempty"`
+
+	Data  hexutil.Bytes `json:"data,omitempty"`
+	Frame *Frame        `json:"frame,omitempty"`
+	Batch *TracedBatch  `json:"batch,omitempty"`
+
+	Attributes   *eth.PayloadAttributes `json:"attributes,omitempty"`
+	IsLastInSpan bool                   `json:"isLastInSpan,omitempty"`
+}
+
+// TracedBatch is the decoded form of a singular or span batch, as recorded in a trace.
+type TracedBatch struct {
+	Type int `json:"type"`
+	// ParentHash is the full parent hash of a singular batch, or the parent check of a span batch.
+	ParentHash hexutil.Bytes `json:"parentHash"`
+	// EpochHash is the full epoch hash of a singular batch, or the L1 origin check of a span batch.
+	EpochHash hexutil.Bytes `json:"epochHash"`
+	Blocks    []TracedBlock `json:"blocks"`
+}
+
+// TracedBlock is the input of a single L2 block within a traced batch.
+type TracedBlock struct {
+	Timestamp    uint64          `json:"timestamp"`
+	EpochNum     uint64          `json:"epochNum"`
+	Transactions []hexutil.Bytes `json:"transactions"`
+}
+
+func newTracedBatch(batch Batch) *TracedBatch {
+	switch b := batch.(type) {
+	case *SingularBatch:
+		return &TracedBatch{
+			Type:       SingularBatchType,
+			ParentHash: b.ParentHash.Bytes(),
+			EpochHash:  b.EpochHash.Bytes(),
+			Blocks: []TracedBlock{{
+				Timestamp:    b.Timestamp,
+				EpochNum:     uint64(b.EpochNum),
+				Transactions: b.Transactions,
+			}},
+		}
+	case *SpanBatch:
+		out := &TracedBatch{
+			Type:       SpanBatchType,
+			ParentHash: b.parentCheck[:],
+			EpochHash:  b.l1OriginCheck[:],
+			Blocks:     make([]TracedBlock, 0, b.GetBlockCount()),
+		}
+		for i := 0; i < b.GetBlockCount(); i++ {
+			out.Blocks = append(out.Blocks, TracedBlock{
+				Timestamp:    b.GetBlockTimestamp(i),
+				EpochNum:     b.GetBlockEpochNum(i),
+				Transactions: b.GetBlockTransactions(i),
+			})
+		}
+		return out
+	default:
+		return &TracedBatch{Type: batch.GetBatchType()}
+	}
+}
+
+// Tracer records the inputs and outputs of the derivation pipeline stages.
+type Tracer interface {
+	TraceEntry(entry *TraceEntry)
+}
+
+type noopTracer struct{}
+
+func (noopTracer) TraceEntry(*TraceEntry) {}
+
+// NoopTracer discards all trace entries.
+var NoopTracer Tracer = noopTracer{}
+
+// JSONTracer writes trace entries to a writer, one JSON object per line.
+// Tracing must not interrupt derivation, so write errors are only reported by Close.
+type JSONTracer struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	c   io.Closer
+	err error
+}
+
+var _ Tracer = (*JSONTracer)(nil)
+
+// NewJSONTracer creates a tracer writing to w. Closing the tracer closes w too, if it is an io.Closer.
+func NewJSONTracer(w io.Writer) *JSONTracer {
+	c, _ := w.(io.Closer)
+	return &JSONTracer{w: bufio.NewWriter(w), c: c}
+}
+
+func (t *JSONTracer) TraceEntry(entry *TraceEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.err = fmt.Errorf("failed to encode %s trace entry: %w", entry.Stage, err)
+		return
+	}
+	if _, err := t.w.Write(append(data, '\n')); err != nil {
+		t.err = fmt.Errorf("failed to write trace entry: %w", err)
+	}
+}
+
+// Close flushes any buffered entries, and returns the first error that was encountered while tracing.
+func (t *JSONTracer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.w.Flush(); err != nil && t.err == nil {
+		t.err = fmt.Errorf("failed to flush trace: %w", err)
+	}
+	if t.c != nil {
+		if err := t.c.Close(); err != nil && t.err == nil {
+			t.err = err
+		}
+	}
+	return t.err
+}
+
+// ReadTrace reads all entries of a trace written by a JSONTracer.
+func ReadTrace(r io.Reader) ([]TraceEntry, error) {
+	var entries []TraceEntry
+	dec := json.NewDecoder(r)
+	for {
+		var entry TraceEntry
+		if err := dec.Decode(&entry); err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode trace entry %d: %w", len(entries), err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// The tracing stages below sit in between two pipeline stages, and record everything passed on to the next stage.
+
+type tracingL1BlockProvider struct {
+	NextBlockProvider
+	tracer Tracer
+}
+
+func (t *tracingL1BlockProvider) NextL1Block(ctx context.Context) (eth.L1BlockRef, error) {
+	ref, err := t.NextBlockProvider.NextL1Block(ctx)
+	if err == nil {
+		t.tracer.TraceEntry(&TraceEntry{Stage: TraceL1Block, Origin: ref.ID(), L1Block: &ref})
+	}
+	return ref, err
+}
+
+// tracingDataProvider traces either the batcher data or the channels read by the next stage.
+type tracingDataProvider struct {
+	NextDataProvider
+	tracer Tracer
+	stage  TraceStage
+}
+
+func (t *tracingDataProvider) NextData(ctx context.Context) ([]byte, error) {
+	data, err := t.NextDataProvider.NextData(ctx)
+	if err == nil {
+		t.tracer.TraceEntry(&TraceEntry{Stage: t.stage, Origin: t.Origin().ID(), Data: common.CopyBytes(data)})
+	}
+	return data, err
+}
+
+type tracingFrameProvider struct {
+	NextFrameProvider
+	tracer Tracer
+}
+
+func (t *tracingFrameProvider) NextFrame(ctx context.Context) (Frame, error) {
+	frame, err := t.NextFrameProvider.NextFrame(ctx)
+	if err == nil {
+		t.tracer.TraceEntry(&TraceEntry{Stage: TraceFrame, Origin: t.Origin().ID(), Frame: &frame})
+	}
+	return frame, err
+}
+
+type tracingBatchProvider struct {
+	NextBatchProvider
+	tracer Tracer
+}
+
+func (t *tracingBatchProvider) NextBatch(ctx context.Context) (Batch, error) {
+	batch, err := t.NextBatchProvider.NextBatch(ctx)
+	if err == nil {
+		t.tracer.TraceEntry(&TraceEntry{Stage: TraceBatch, Origin: t.Origin().ID(), Batch: newTracedBatch(batch)})
+	}
+	return batch, err
+}
+
+type tracingAttributesProvider struct {
+	NextAttributesProvider
+	tracer Tracer
+}
+
+func (t *tracingAttributesProvider) NextAttributes(ctx context.Context, parent eth.L2BlockRef) (*AttributesWithParent, error) {
+	attrs, err := t.NextAttributesProvider.NextAttributes(ctx, parent)
+	if err == nil {
+		t.tracer.TraceEntry(&TraceEntry{
+			Stage:        TraceAttributes,
+			Origin:       t.Origin().ID(),
+			Parent:       &attrs.parent,
+			Attributes:   attrs.attributes,
+			IsLastInSpan: attrs.isLastInSpan,
+		})
+	}
+	return attrs, err
+}
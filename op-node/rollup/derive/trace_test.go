@@ -0,0 +1,311 @@
+package derive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type zlibCompressor struct {
+	buf bytes.Buffer
+	w   *zlib.Writer
+}
+
+func newZlibCompressor() *zlibCompressor {
+	c := &zlibCompressor{}
+	c.w = zlib.NewWriter(&c.buf)
+	return c
+}
+
+func (c *zlibCompressor) Write(p []byte) (int, error) { return c.w.Write(p) }
+func (c *zlibCompressor) Close() error                { return c.w.Close() }
+func (c *zlibCompressor) Read(p []byte) (int, error)  { return c.buf.Read(p) }
+func (c *zlibCompressor) Len() int                    { return c.buf.Len() }
+func (c *zlibCompressor) Flush() error                { return c.w.Flush() }
+func (c *zlibCompressor) FullErr() error              { return nil }
+func (c *zlibCompressor) Reset() {
+	c.buf.Reset()
+	c.w.Reset(&c.buf)
+}
+
+type traceCollector struct {
+	entries []TraceEntry
+}
+
+func (t *traceCollector) TraceEntry(entry *TraceEntry) {
+	t.entries = append(t.entries, *entry)
+}
+
+func TestJSONTracer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1 := testutils.RandomBlockRef(rng)
+	l2 := testutils.RandomL2BlockRef(rng)
+	key := hexutil.Bytes{0x01, 0x02}
+	entries := []TraceEntry{
+		{Stage: TraceReset, Origin: l1.ID(), L1Block: &l1, SystemConfig: &eth.SystemConfig{GasLimit: 30_000_000}, Parent: &l2},
+		{Stage: TraceData, Origin: l1.ID(), Data: []byte{0x00, 0x01}},
+		{Stage: TraceFrame, Origin: l1.ID(), Frame: &Frame{ID: ChannelID{0xaa}, FrameNumber: 1, Data: []byte{0x02}, IsLast: true}},
+		{Stage: TraceBatch, Origin: l1.ID(), Batch: &TracedBatch{Type: SingularBatchType, ParentHash: l2.ParentHash[:], EpochHash: l1.Hash[:], Blocks: []TracedBlock{{Timestamp: 10}}}},
+		{Stage: TraceAttributes, Origin: l1.ID(), Parent: &l2, Attributes: &eth.PayloadAttributes{Timestamp: 12, DecryptionKey: &key}},
+	}
+
+	var buf bytes.Buffer
+	tracer := NewJSONTracer(&buf)
+	for i := range entries {
+		tracer.TraceEntry(&entries[i])
+	}
+	require.NoError(t, tracer.Close())
+
+	result, err := ReadTrace(&buf)
+	require.NoError(t, err)
+	require.Equal(t, entries, result)
+	require.Nil(t, DiffTraces(entries, result))
+}
+
+func TestReplay(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     30,
+		ChannelTimeout:    10,
+		L2ChainID:         big.NewInt(901),
+	}
+
+	l1A := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: 1000, ParentHash: testutils.RandomHash(rng), Time: 10_000}
+	l1B := testutils.NextRandomRef(rng, l1A)
+	l1C := testutils.NextRandomRef(rng, l1B)
+	safeHead := eth.L2BlockRef{
+		Hash:     testutils.RandomHash(rng),
+		Number:   100,
+		Time:     l1A.Time,
+		L1Origin: l1A.ID(),
+	}
+	sysCfg := eth.SystemConfig{BatcherAddr: testutils.RandomAddress(rng), GasLimit: 30_000_000}
+
+	// The batcher submitted a single batch in L1 block B, building on the safe head in epoch A.
+	batch := &SingularBatch{
+		ParentHash:   safeHead.Hash,
+		EpochNum:     rollup.Epoch(l1A.Number),
+		EpochHash:    l1A.Hash,
+		Timestamp:    safeHead.Time + cfg.BlockTime,
+		Transactions: []hexutil.Bytes{{0xf8, 0x01, 0x02}},
+	}
+	co, err := NewSingularChannelOut(newZlibCompressor())
+	require.NoError(t, err)
+	_, err = co.AddSingularBatch(batch, 0)
+	require.NoError(t, err)
+	require.NoError(t, co.Close())
+	var data bytes.Buffer
+	data.WriteByte(DerivationVersion0)
+	_, err = co.OutputFrame(&data, 100_000)
+	require.ErrorIs(t, err, io.EOF)
+
+	l1Info, err := L1InfoDepositBytes(0, &testutils.MockBlockInfo{InfoHash: l1A.Hash, InfoNum: l1A.Number, InfoTime: l1A.Time, InfoBaseFee: big.NewInt(7)}, sysCfg, false)
+	require.NoError(t, err)
+	gasLimit := eth.Uint64Quantity(sysCfg.GasLimit)
+	attrs := &eth.PayloadAttributes{
+		Timestamp:    hexutil.Uint64(batch.Timestamp),
+		Transactions: []eth.Data{l1Info, batch.Transactions[0]},
+		NoTxPool:     true,
+		GasLimit:     &gasLimit,
+	}
+
+	recorded := []TraceEntry{
+		{Stage: TraceReset, Origin: l1A.ID(), L1Block: &l1A, SystemConfig: &sysCfg, Parent: &safeHead},
+		{Stage: TraceL1Block, Origin: l1A.ID(), L1Block: &l1A},
+		{Stage: TraceL1Block, Origin: l1B.ID(), L1Block: &l1B},
+		{Stage: TraceData, Origin: l1B.ID(), Data: data.Bytes()},
+		{Stage: TraceAttributes, Origin: l1B.ID(), Parent: &safeHead, Attributes: attrs},
+		{Stage: TraceL1Block, Origin: l1C.ID(), L1Block: &l1C},
+	}
+
+	replay := func(trace []TraceEntry) []TraceEntry {
+		out := new(traceCollector)
+		require.NoError(t, Replay(context.Background(), logger, cfg, &testutils.TestDerivationMetrics{}, trace, out))
+		return out.entries
+	}
+
+	replayed := replay(recorded)
+	var derived []TraceEntry
+	for _, entry := range replayed {
+		if entry.Stage == TraceAttributes {
+			derived = append(derived, entry)
+		}
+	}
+	require.Len(t, derived, 1, "expected the batch to be derived again")
+	require.Equal(t, safeHead, *derived[0].Parent)
+	require.Equal(t, attrs, derived[0].Attributes)
+
+	// The recording did not trace the intermediate stages, which shows as divergence.
+	div := DiffTraces(recorded, replayed)
+	require.NotNil(t, div)
+	require.Equal(t, TraceFrame, div.Stage)
+	require.Nil(t, div.A)
+
+	// Replaying is deterministic
+	require.Nil(t, DiffTraces(replayed, replay(replayed)))
+
+	// Without the batcher data, the attributes are not derived anymore.
+	var tampered []TraceEntry
+	for _, entry := range replayed {
+		if entry.Stage != TraceData {
+			tampered = append(tampered, entry)
+		}
+	}
+	div = DiffTraces(replayed, replay(tampered))
+	require.NotNil(t, div)
+	require.Equal(t, TraceData, div.Stage)
+	require.Nil(t, div.B)
+}
+
+func TestReplayDivergedChain(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		// The sequence window expires in L1 block C, so empty batches are derived on top of the batch.
+		SeqWindowSize:  2,
+		ChannelTimeout: 10,
+		L2ChainID:      big.NewInt(901),
+	}
+
+	l1A := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: 1000, ParentHash: testutils.RandomHash(rng), Time: 10_000}
+	l1B := testutils.NextRandomRef(rng, l1A)
+	l1C := testutils.NextRandomRef(rng, l1B)
+	l1D := testutils.NextRandomRef(rng, l1C)
+	safeHead := eth.L2BlockRef{
+		Hash:     testutils.RandomHash(rng),
+		Number:   100,
+		Time:     l1A.Time,
+		L1Origin: l1A.ID(),
+	}
+	sysCfg := eth.SystemConfig{BatcherAddr: testutils.RandomAddress(rng), GasLimit: 30_000_000}
+
+	batch := &SingularBatch{
+		ParentHash:   safeHead.Hash,
+		EpochNum:     rollup.Epoch(l1A.Number),
+		EpochHash:    l1A.Hash,
+		Timestamp:    safeHead.Time + cfg.BlockTime,
+		Transactions: []hexutil.Bytes{{0xf8, 0x01, 0x02}},
+	}
+	co, err := NewSingularChannelOut(newZlibCompressor())
+	require.NoError(t, err)
+	_, err = co.AddSingularBatch(batch, 0)
+	require.NoError(t, err)
+	require.NoError(t, co.Close())
+	var data bytes.Buffer
+	data.WriteByte(DerivationVersion0)
+	_, err = co.OutputFrame(&data, 100_000)
+	require.ErrorIs(t, err, io.EOF)
+
+	l1Info, err := L1InfoDepositBytes(0, &testutils.MockBlockInfo{InfoHash: l1A.Hash, InfoNum: l1A.Number, InfoTime: l1A.Time, InfoBaseFee: big.NewInt(7)}, sysCfg, false)
+	require.NoError(t, err)
+	gasLimit := eth.Uint64Quantity(sysCfg.GasLimit)
+	attrs := &eth.PayloadAttributes{
+		Timestamp:    hexutil.Uint64(batch.Timestamp),
+		Transactions: []eth.Data{l1Info, batch.Transactions[0]},
+		NoTxPool:     true,
+		GasLimit:     &gasLimit,
+	}
+
+	replay := func(trace []TraceEntry) []TraceEntry {
+		out := new(traceCollector)
+		require.NoError(t, Replay(context.Background(), logger, cfg, &testutils.TestDerivationMetrics{}, trace, out),
+			"replay stops at the first L2 block without recorded attributes")
+		return out.entries
+	}
+	recorded := replay([]TraceEntry{
+		{Stage: TraceReset, Origin: l1A.ID(), L1Block: &l1A, SystemConfig: &sysCfg, Parent: &safeHead},
+		{Stage: TraceL1Block, Origin: l1A.ID(), L1Block: &l1A},
+		{Stage: TraceL1Block, Origin: l1B.ID(), L1Block: &l1B},
+		{Stage: TraceData, Origin: l1B.ID(), Data: data.Bytes()},
+		{Stage: TraceAttributes, Origin: l1B.ID(), Parent: &safeHead, Attributes: attrs},
+		{Stage: TraceL1Block, Origin: l1C.ID(), L1Block: &l1C},
+		{Stage: TraceL1Block, Origin: l1D.ID(), L1Block: &l1D},
+	})
+
+	// The recorded chain differs from the derived one in its first block, before the last recorded block.
+	// The replay continues on a made-up block, and stops once derivation builds on top of it.
+	var tampered []TraceEntry
+	attrsIndex := -1
+	for _, entry := range recorded {
+		if entry.Stage == TraceAttributes {
+			attrsIndex++
+			if attrsIndex == 0 {
+				diverged := *entry.Attributes
+				diverged.Transactions = []eth.Data{l1Info, {0xf8, 0x03}}
+				entry.Attributes = &diverged
+			}
+		}
+		tampered = append(tampered, entry)
+	}
+	require.Equal(t, 0, attrsIndex, "expected a single recorded attributes entry")
+
+	replayed := replay(tampered)
+	div := DiffTraces(tampered, replayed)
+	require.NotNil(t, div)
+	require.Equal(t, TraceAttributes, div.Stage)
+	require.Equal(t, 0, div.Index)
+	require.Equal(t, attrs, div.B.Attributes)
+}
+
+func TestReplayDivergedTrace(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlCrit)
+	cfg := &rollup.Config{
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     30,
+		ChannelTimeout:    10,
+		L2ChainID:         big.NewInt(901),
+	}
+	l1A := testutils.RandomBlockRef(rng)
+	l1B := testutils.NextRandomRef(rng, l1A)
+	sysCfg := eth.SystemConfig{BatcherAddr: testutils.RandomAddress(rng), GasLimit: 30_000_000}
+
+	// The recorded safe head builds on an L1 origin that is not part of the trace,
+	// so the batch queue keeps requesting a reset.
+	safeHead := testutils.RandomL2BlockRef(rng)
+	safeHead.L1Origin = eth.BlockID{Hash: testutils.RandomHash(rng), Number: l1A.Number - 10}
+	trace := []TraceEntry{
+		{Stage: TraceReset, Origin: l1A.ID(), L1Block: &l1A, SystemConfig: &sysCfg, Parent: &safeHead},
+		{Stage: TraceL1Block, Origin: l1A.ID(), L1Block: &l1A},
+		{Stage: TraceL1Block, Origin: l1B.ID(), L1Block: &l1B},
+	}
+
+	out := new(traceCollector)
+	err := Replay(context.Background(), logger, cfg, &testutils.TestDerivationMetrics{}, trace, out)
+	require.ErrorIs(t, err, ErrReset)
+	require.ErrorContains(t, err, "segment 0")
+
+	var resets int
+	for _, entry := range out.entries {
+		if entry.Stage == TraceReset {
+			resets++
+			require.Equal(t, l1A, *entry.L1Block, "replay restarts from the start of the segment")
+		}
+	}
+	require.Equal(t, 1+replayMaxRetries, resets, "recorded reset and the bounded resets of the replay")
+}
@@ -20,4 +20,8 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerPipelined is true when the sequencer should prepare the inputs of the next block,
+	// like the L1 deposits and the shutter decryption key, while the current block is being built.
+	SequencerPipelined bool `json:"sequencer_pipelined"`
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/client"
@@ -86,11 +87,32 @@ type SequencerIface interface {
 	RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayload, error)
 	BuildingOnto() eth.L2BlockRef
 	CancelBuildingBlock(ctx context.Context)
+	ShutterActive(parent common.Hash) bool
+	DecryptionKey(parent common.Hash) *hexutil.Bytes
+	RestoreShutterActive(head eth.L2BlockRef, active bool)
+}
+
+// SequencerConductor coordinates the sequencing with other sequencers, when running as part of a sequencer set.
+type SequencerConductor interface {
+	// CommitUnsafePayload replicates a newly sequenced payload, along with the shutter state
+	// for the next block, to the other sequencers. It is called before the payload is published.
+	// An error is returned if this sequencer is not allowed to sequence (anymore).
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload, shutterActive bool) error
+}
+
+// NoopConductor is the SequencerConductor of a sequencer that sequences on its own.
+type NoopConductor struct{}
+
+func (NoopConductor) CommitUnsafePayload(context.Context, *eth.ExecutionPayload, bool) error {
+	return nil
 }
 
 type Network interface {
 	// PublishL2Payload is called by the driver whenever there is a new payload to publish, synchronously with the driver main loop.
 	PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error
+	// PublishShutterKey is called by the driver with the shutter decryption key used for a newly sequenced payload,
+	// after the payload itself was published.
+	PublishShutterKey(ctx context.Context, key *eth.ShutterKey) error
 }
 
 type AltSync interface {
@@ -118,19 +140,24 @@ type SequencerStateListener interface {
 }
 
 // NewDriver composes an events handler that tracks L1 state, triggers L2 derivation, and optionally sequences new L2 blocks.
-func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, syncCfg *sync.Config, shutterClient *client.Client) *Driver {
+func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, l1Blobs derive.L1BlobsFetcher, altDA derive.AltDAInputFetcher, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, conductor SequencerConductor, safeHeadListener derive.SafeHeadListener, tracer derive.Tracer, syncCfg *sync.Config, shutterClient *client.Client) *Driver {
 	l1 = NewMeteredL1Fetcher(l1, metrics)
 	l1State := NewL1State(log, metrics)
 	sequencerConfDepth := NewConfDepth(driverCfg.SequencerConfDepth, l1State.L1Head, l1)
 	findL1Origin := NewL1OriginSelector(log, cfg, sequencerConfDepth)
 	verifConfDepth := NewConfDepth(driverCfg.VerifierConfDepth, l1State.L1Head, l1)
-	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l2, metrics, syncCfg)
+	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, altDA, l2, metrics, syncCfg, safeHeadListener, tracer)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 	engine := derivationPipeline
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log)
 
-	shutterEngine := shutter.NewEngine(shutterClient)
-	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics, shutterEngine)
+	// the shutter client is only set up when sequencing
+	// with shutter scheduled
+	var shutterEngine *shutter.Engine
+	if shutterClient != nil {
+		shutterEngine = shutter.NewEngine(shutterClient)
+	}
+	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics, shutterEngine, driverCfg.SequencerPipelined)
 
 	return &Driver{
 		l1State:          l1State,
@@ -141,6 +168,7 @@ func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, al
 		stopSequencer:    make(chan chan hashAndError, 10),
 		sequencerActive:  make(chan chan bool, 10),
 		sequencerNotifs:  sequencerStateListener,
+		conductor:        conductor,
 		config:           cfg,
 		driverConfig:     driverCfg,
 		done:             make(chan struct{}),
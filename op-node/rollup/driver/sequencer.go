@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -28,6 +29,35 @@ type L1OriginSelectorIface interface {
 type SequencerMetrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	RecordSequencerBuildPhase(phase string, duration time.Duration)
+}
+
+// Block building phases, as recorded by SequencerMetrics.RecordSequencerBuildPhase
+const (
+	phaseL1Origin    = "l1_origin"
+	phaseAttributes  = "attributes"
+	phaseShutterKey  = "shutter_key"
+	phaseEngineStart = "engine_start"
+	phaseSeal        = "seal"
+	phasePrefetch    = "prefetch"
+)
+
+// prefetchTimeout bounds the background preparation of the inputs of the next block.
+const prefetchTimeout = time.Second * 20
+
+// prefetchJob prepares the inputs of the next block in the background, while the current block is being built.
+type prefetchJob struct {
+	// onto is the L2 block that is being built, and that the next block is expected to be built on.
+	// Its hash is not known until the block is sealed.
+	onto   eth.L2BlockRef
+	done   chan struct{}
+	cancel context.CancelFunc
+}
+
+// matches returns whether the job prepared the inputs for building on top of the given L2 head.
+func (job *prefetchJob) matches(l2Head eth.L2BlockRef) bool {
+	return job.onto.Number == l2Head.Number && job.onto.ParentHash == l2Head.ParentHash &&
+		job.onto.Time == l2Head.Time && job.onto.L1Origin == l2Head.L1Origin
 }
 
 // Sequencer implements the sequencing interface of the driver: it starts and completes block building jobs.
@@ -48,9 +78,13 @@ type Sequencer struct {
 	timeNow func() time.Time
 
 	nextAction time.Time
+
+	// pipelined enables the preparation of the next block while the current block is being built
+	pipelined bool
+	prefetch  *prefetchJob
 }
 
-func NewSequencer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics, shutterEngine *shutter.Engine) *Sequencer {
+func NewSequencer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics, shutterEngine *shutter.Engine, pipelined bool) *Sequencer {
 	return &Sequencer{
 		log:              log,
 		config:           cfg,
@@ -60,19 +94,28 @@ func NewSequencer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEn
 		l1OriginSelector: l1OriginSelector,
 		metrics:          metrics,
 		shutter:          shutterEngine,
+		pipelined:        pipelined,
 	}
 }
 
 // StartBuildingBlock initiates a block building job on top of the given L2 head, safe and finalized blocks, and using the provided l1Origin.
+// If pipelining is enabled, the inputs of the next block are prepared in the background once the job started.
 func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	l2Head := d.engine.UnsafeL2Head()
 
+	// Await the inputs that were prepared for this block, if any.
+	// The inputs are only prefetched into the caches of the L1 and shutter sources:
+	// the block is built with the same calls as without pipelining, to keep the result deterministic.
+	d.awaitPrefetch(ctx, l2Head)
+
 	// Figure out which L1 origin block we're going to be building on top of.
+	start := time.Now()
 	l1Origin, err := d.l1OriginSelector.FindL1Origin(ctx, l2Head)
 	if err != nil {
 		d.log.Error("Error finding next L1 Origin", "err", err)
 		return err
 	}
+	d.metrics.RecordSequencerBuildPhase(phaseL1Origin, time.Since(start))
 
 	if !(l2Head.L1Origin.Hash == l1Origin.ParentHash || l2Head.L1Origin.Hash == l1Origin.Hash) {
 		d.metrics.RecordSequencerInconsistentL1Origin(l2Head.L1Origin, l1Origin.ID())
@@ -86,17 +129,23 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	fetchCtx, cancel := context.WithTimeout(ctx, time.Second*20)
 	defer cancel()
 
+	start = time.Now()
 	attrs, err := d.attrBuilder.PreparePayloadAttributes(fetchCtx, l2Head, l1Origin.ID())
 	if err != nil {
 		return err
 	}
-	if d.shutter != nil {
+	d.metrics.RecordSequencerBuildPhase(phaseAttributes, time.Since(start))
+	// the decryption key is only included once shutter is activated
+	withShutter := d.shutter != nil && d.config.IsShutter(uint64(attrs.Timestamp))
+	if withShutter {
 		shutterFetchCtx, cancel := context.WithTimeout(ctx, time.Second*2)
 		defer cancel()
+		start = time.Now()
 		attrsWithShutter, err := d.shutter.PreparePayloadAttributes(shutterFetchCtx, attrs, l2Head)
 		if err != nil {
 			return err
 		}
+		d.metrics.RecordSequencerBuildPhase(phaseShutterKey, time.Since(start))
 		attrs = attrsWithShutter
 	}
 
@@ -111,24 +160,98 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool)
 
 	// Start a payload building process.
+	start = time.Now()
 	errTyp, err := d.engine.StartPayload(ctx, l2Head, attrs, false)
-	if d.shutter != nil {
+	if withShutter {
 		d.shutter.RegisterPayloadResult(errTyp, err, l2Head, attrs)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to start building on top of L2 chain %s, error (%d): %w", l2Head, errTyp, err)
 	}
+	d.metrics.RecordSequencerBuildPhase(phaseEngineStart, time.Since(start))
+
+	if d.pipelined {
+		d.startPrefetch(ctx, l2Head, l1Origin, attrs)
+	}
 	return nil
 }
 
+// startPrefetch prepares the inputs of the block after the block that is being built on top of l2Head,
+// with the given L1 origin and attributes.
+// The L1 origin of the next block is selected right away, since the L1 view is owned by the driver.
+// The L1 inputs of the attributes, and the shutter decryption key, are fetched in the background.
+func (d *Sequencer) startPrefetch(ctx context.Context, l2Head eth.L2BlockRef, l1Origin eth.L1BlockRef, attrs *eth.PayloadAttributes) {
+	start := time.Now()
+	seqNr := uint64(0)
+	if l1Origin.Hash == l2Head.L1Origin.Hash {
+		seqNr = l2Head.SequenceNumber + 1
+	}
+	onto := eth.L2BlockRef{
+		Number:         l2Head.Number + 1,
+		ParentHash:     l2Head.Hash,
+		Time:           uint64(attrs.Timestamp),
+		L1Origin:       l1Origin.ID(),
+		SequenceNumber: seqNr,
+	}
+
+	if d.shutter != nil && d.config.IsShutter(onto.Time+d.config.BlockTime) {
+		// the next block inherits the shutter state of the block that is being built
+		if active, _ := d.shutter.ActiveAfter(l2Head.Hash); active {
+			d.shutter.PrefetchKey(uint(onto.Number + 1))
+		}
+	}
+
+	nextOrigin, err := d.l1OriginSelector.FindL1Origin(ctx, onto)
+	if err != nil {
+		d.log.Debug("failed to prefetch next L1 origin", "onto", onto, "err", err)
+		return
+	}
+	prefetcher, ok := d.attrBuilder.(derive.L1InputsPrefetcher)
+	if !ok {
+		d.metrics.RecordSequencerBuildPhase(phasePrefetch, time.Since(start))
+		return
+	}
+	prefetchCtx, cancel := context.WithTimeout(context.Background(), prefetchTimeout)
+	job := &prefetchJob{onto: onto, done: make(chan struct{}), cancel: cancel}
+	d.prefetch = job
+	go func() {
+		defer close(job.done)
+		defer cancel()
+		if err := prefetcher.PrefetchL1Inputs(prefetchCtx, onto, nextOrigin.ID()); err != nil {
+			d.log.Debug("failed to prefetch L1 inputs of next block", "onto", onto, "origin", nextOrigin, "err", err)
+			return
+		}
+		d.metrics.RecordSequencerBuildPhase(phasePrefetch, time.Since(start))
+	}()
+}
+
+// awaitPrefetch waits for the background preparation of the inputs of the block on top of the given L2 head,
+// and cancels any preparation that does not apply to the head.
+func (d *Sequencer) awaitPrefetch(ctx context.Context, l2Head eth.L2BlockRef) {
+	job := d.prefetch
+	if job == nil {
+		return
+	}
+	d.prefetch = nil
+	if job.matches(l2Head) {
+		select {
+		case <-job.done:
+		case <-ctx.Done():
+		}
+	}
+	job.cancel()
+}
+
 // CompleteBuildingBlock takes the current block that is being built, and asks the engine to complete the building, seal the block, and persist it as canonical.
 // Warning: the safe and finalized L2 blocks as viewed during the initiation of the block building are reused for completion of the block building.
 // The Execution engine should not change the safe and finalized blocks between start and completion of block building.
 func (d *Sequencer) CompleteBuildingBlock(ctx context.Context) (*eth.ExecutionPayload, error) {
+	start := time.Now()
 	payload, errTyp, err := d.engine.ConfirmPayload(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to complete building block: error (%d): %w", errTyp, err)
 	}
+	d.metrics.RecordSequencerBuildPhase(phaseSeal, time.Since(start))
 	return payload, nil
 }
 
@@ -186,6 +309,32 @@ func (d *Sequencer) PlanNextSequencerAction() time.Duration {
 	}
 }
 
+// ShutterActive returns whether shutter is assumed active for the block after the given block.
+// It is false if shutter is not used by this sequencer, or if the sequencer did not build on the block yet.
+func (d *Sequencer) ShutterActive(parent common.Hash) bool {
+	if d.shutter == nil {
+		return false
+	}
+	active, _ := d.shutter.ActiveAfter(parent)
+	return active
+}
+
+// DecryptionKey returns the shutter decryption key that was used to build a block on top of the given block,
+// or nil if there was none.
+func (d *Sequencer) DecryptionKey(parent common.Hash) *hexutil.Bytes {
+	if d.shutter == nil {
+		return nil
+	}
+	return d.shutter.DecryptionKeyAfter(parent)
+}
+
+// RestoreShutterActive continues sequencing on the given head with the shutter state of another sequencer.
+func (d *Sequencer) RestoreShutterActive(head eth.L2BlockRef, active bool) {
+	if d.shutter != nil {
+		d.shutter.RestoreActive(head, active)
+	}
+}
+
 // BuildingOnto returns the L2 head reference that the latest block is or was being built on top of.
 func (d *Sequencer) BuildingOnto() eth.L2BlockRef {
 	ref, _, _ := d.engine.BuildingPayload()
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -147,6 +148,43 @@ func (fn testOriginSelectorFn) FindL1Origin(ctx context.Context, l2Head eth.L2Bl
 
 var _ L1OriginSelectorIface = (testOriginSelectorFn)(nil)
 
+type testPrefetchingAttrBuilder struct {
+	testAttrBuilderFn
+
+	mu         sync.Mutex
+	prefetched []eth.L2BlockRef
+	epochs     []eth.BlockID
+}
+
+func (b *testPrefetchingAttrBuilder) PrefetchL1Inputs(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.prefetched = append(b.prefetched, l2Parent)
+	b.epochs = append(b.epochs, epoch)
+	return nil
+}
+
+var _ derive.L1InputsPrefetcher = (*testPrefetchingAttrBuilder)(nil)
+
+type testSequencerMetrics struct {
+	mu     sync.Mutex
+	phases map[string]int
+}
+
+func (m *testSequencerMetrics) RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID) {
+}
+
+func (m *testSequencerMetrics) RecordSequencerReset() {
+}
+
+func (m *testSequencerMetrics) RecordSequencerBuildPhase(phase string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.phases[phase] += 1
+}
+
+var _ SequencerMetrics = (*testSequencerMetrics)(nil)
+
 // TestSequencerChaosMonkey runs the sequencer in a mocked adversarial environment with
 // repeated random errors in dependencies and poor clock timing.
 // At the end the health of the chain is checked to show that the sequencer kept the chain in shape.
@@ -302,7 +340,7 @@ func TestSequencerChaosMonkey(t *testing.T) {
 		}
 	})
 
-	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics, nil)
+	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics, nil, false)
 	seq.timeNow = clockFn
 
 	// try to build 1000 blocks, with 5x as many planning attempts, to handle errors and clock problems
@@ -376,3 +414,136 @@ func TestSequencerChaosMonkey(t *testing.T) {
 	require.Greater(t, engControl.avgBuildingTime(), time.Second, "With 2 second block time and 1 second error backoff and healthy-on-average errors, building time should at least be a second")
 	require.Greater(t, engControl.avgTxsPerBlock(), 3.0, "We expect at least 1 system tx per block, but with a mocked 0-10 txs we expect an higher avg")
 }
+
+// TestSequencerPipelined checks that preparing the inputs of the next block while a block is being built
+// does not change the built chain, and that the inputs are prepared for the block that is built next.
+func TestSequencerPipelined(t *testing.T) {
+	mockL1Hash := func(num uint64) (out common.Hash) {
+		out[31] = 1
+		binary.BigEndian.PutUint64(out[:], num)
+		return
+	}
+	mockL2Hash := func(num uint64) (out common.Hash) {
+		out[31] = 2
+		binary.BigEndian.PutUint64(out[:], num)
+		return
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     eth.BlockID{Hash: mockL1Hash(1000), Number: 1000},
+			L2:     eth.BlockID{Hash: mockL2Hash(2000), Number: 2000},
+			L2Time: 100000,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 30,
+	}
+	// a new L1 block every 12 seconds, adopted as L1 origin every 6 L2 blocks
+	l1Time := func(num uint64) uint64 {
+		return cfg.Genesis.L2Time + (num-cfg.Genesis.L1.Number)*12
+	}
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		num := l2Head.L1Origin.Number
+		if (l2Head.Number+1-cfg.Genesis.L2.Number)%6 == 0 {
+			num += 1
+		}
+		return eth.L1BlockRef{Hash: mockL1Hash(num), Number: num, ParentHash: mockL1Hash(num - 1), Time: l1Time(num)}, nil
+	})
+	attrsFn := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (attrs *eth.PayloadAttributes, err error) {
+		seqNr := l2Parent.SequenceNumber + 1
+		if epoch != l2Parent.L1Origin {
+			seqNr = 0
+		}
+		l1Info := &testutils.MockBlockInfo{
+			InfoHash:       epoch.Hash,
+			InfoParentHash: mockL1Hash(epoch.Number - 1),
+			InfoNum:        epoch.Number,
+			InfoTime:       l1Time(epoch.Number),
+			InfoBaseFee:    big.NewInt(1234),
+		}
+		infoDep, err := derive.L1InfoDepositBytes(seqNr, l1Info, cfg.Genesis.SystemConfig, false)
+		require.NoError(t, err)
+		return &eth.PayloadAttributes{
+			Timestamp:    eth.Uint64Quantity(l2Parent.Time + cfg.BlockTime),
+			Transactions: []eth.Data{infoDep},
+		}, nil
+	})
+
+	build := func(pipelined bool) ([]*eth.ExecutionPayload, *testPrefetchingAttrBuilder, *testSequencerMetrics) {
+		genesisL2 := eth.L2BlockRef{
+			Hash:       cfg.Genesis.L2.Hash,
+			Number:     cfg.Genesis.L2.Number,
+			ParentHash: mockL2Hash(cfg.Genesis.L2.Number - 1),
+			Time:       cfg.Genesis.L2Time,
+			L1Origin:   cfg.Genesis.L1,
+		}
+		clockFn := func() time.Time {
+			return time.Unix(int64(cfg.Genesis.L2Time), 0)
+		}
+		engControl := &FakeEngineControl{
+			finalized: genesisL2,
+			safe:      genesisL2,
+			unsafe:    genesisL2,
+			cfg:       cfg,
+			timeNow:   clockFn,
+			makePayload: func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload {
+				return &eth.ExecutionPayload{
+					ParentHash:   onto.Hash,
+					BlockNumber:  eth.Uint64Quantity(onto.Number) + 1,
+					Timestamp:    attrs.Timestamp,
+					BlockHash:    mockL2Hash(onto.Number + 1),
+					Transactions: append([]eth.Data{}, attrs.Transactions...),
+				}
+			},
+		}
+		attrBuilder := &testPrefetchingAttrBuilder{testAttrBuilderFn: attrsFn}
+		m := &testSequencerMetrics{phases: make(map[string]int)}
+		seq := NewSequencer(testlog.Logger(t, log.LvlError), cfg, engControl, attrBuilder, originSelector, m, nil, pipelined)
+		seq.timeNow = clockFn
+
+		var payloads []*eth.ExecutionPayload
+		for i := 0; i < 100 && len(payloads) < 20; i++ {
+			payload, err := seq.RunNextSequencerAction(context.Background())
+			require.NoError(t, err)
+			if payload != nil {
+				payloads = append(payloads, payload)
+			}
+		}
+		if seq.prefetch != nil {
+			<-seq.prefetch.done
+		}
+		return payloads, attrBuilder, m
+	}
+
+	expected, serialBuilder, serialMetrics := build(false)
+	require.Len(t, expected, 20)
+	require.Empty(t, serialBuilder.prefetched)
+	require.Zero(t, serialMetrics.phases[phasePrefetch])
+
+	payloads, pipelinedBuilder, pipelinedMetrics := build(true)
+	require.Equal(t, expected, payloads, "pipelining must not change the built blocks")
+
+	// after starting block N, the inputs of block N+1 are prefetched on top of N, before N is sealed
+	pipelinedBuilder.mu.Lock()
+	defer pipelinedBuilder.mu.Unlock()
+	require.Len(t, pipelinedBuilder.prefetched, len(payloads))
+	for i, payload := range payloads {
+		ref, err := derive.PayloadToBlockRef(payload, &cfg.Genesis)
+		require.NoError(t, err)
+		onto := pipelinedBuilder.prefetched[i]
+		require.Equal(t, common.Hash{}, onto.Hash, "hash of block is not known before sealing")
+		ref.Hash = common.Hash{}
+		require.Equal(t, ref, onto)
+		if i+1 < len(payloads) {
+			next, err := derive.PayloadToBlockRef(payloads[i+1], &cfg.Genesis)
+			require.NoError(t, err)
+			require.Equal(t, next.L1Origin, pipelinedBuilder.epochs[i], "prefetched L1 origin of the next block")
+		}
+	}
+
+	pipelinedMetrics.mu.Lock()
+	defer pipelinedMetrics.mu.Unlock()
+	for _, phase := range []string{phaseL1Origin, phaseAttributes, phaseEngineStart, phaseSeal, phasePrefetch} {
+		require.Equal(t, len(payloads), pipelinedMetrics.phases[phase], "phase %s", phase)
+	}
+	require.Zero(t, pipelinedMetrics.phases[phaseShutterKey], "shutter is not used")
+}
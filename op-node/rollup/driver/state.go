@@ -55,6 +55,9 @@ type Driver struct {
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
+	// conductor replicates the sequenced blocks before they are published
+	conductor SequencerConductor
+
 	// Rollup config: rollup chain configuration
 	config *rollup.Config
 
@@ -271,6 +274,14 @@ func (s *Driver) eventLoop() {
 				s.log.Error("Sequencer critical error", "err", err)
 				return
 			}
+			if payload != nil {
+				if err := s.conductor.CommitUnsafePayload(ctx, payload, s.sequencer.ShutterActive(payload.ParentHash)); err != nil {
+					// Another sequencer may be leading already: the block is not published, and will be reorged out.
+					s.log.Error("failed to commit newly created block to the sequencer set", "id", payload.ID(), "err", err)
+					planSequencerAction()
+					continue
+				}
+			}
 			if s.network != nil && payload != nil {
 				// Publishing of unsafe data via p2p is optional.
 				// Errors are not severe enough to change/halt sequencing but should be logged and metered.
@@ -278,6 +289,18 @@ func (s *Driver) eventLoop() {
 					s.log.Warn("failed to publish newly created block", "id", payload.ID(), "err", err)
 					s.metrics.RecordPublishingError()
 				}
+				if key := s.sequencer.DecryptionKey(payload.ParentHash); key != nil {
+					shutterKey := &eth.ShutterKey{
+						BlockHash:   payload.BlockHash,
+						BlockNumber: payload.BlockNumber,
+						Timestamp:   payload.Timestamp,
+						Key:         *key,
+					}
+					if err := s.network.PublishShutterKey(ctx, shutterKey); err != nil {
+						s.log.Warn("failed to publish shutter key of newly created block", "id", payload.ID(), "err", err)
+						s.metrics.RecordPublishingError()
+					}
+				}
 			}
 			planSequencerAction() // schedule the next sequencer action to keep the sequencing looping
 		case <-altSyncTicker.C:
@@ -366,6 +389,9 @@ func (s *Driver) eventLoop() {
 					resp.err <- fmt.Errorf("sequencer start notification: %w", err)
 					continue
 				}
+				if resp.shutterActive != nil {
+					s.sequencer.RestoreShutterActive(s.derivation.UnsafeL2Head(), *resp.shutterActive)
+				}
 				s.log.Info("Sequencer has been started")
 				s.driverConfig.SequencerStopped = false
 				close(resp.err)
@@ -433,6 +459,30 @@ func (s *Driver) StartSequencer(ctx context.Context, blockHash common.Hash) erro
 	}
 }
 
+// HandoffSequencer starts the sequencer at the given hash like StartSequencer,
+// continuing with the shutter state that the previous sequencer of the sequencer set replicated.
+func (s *Driver) HandoffSequencer(ctx context.Context, blockHash common.Hash, shutterActive bool) error {
+	if !s.driverConfig.SequencerEnabled {
+		return errors.New("sequencer is not enabled")
+	}
+	h := hashAndErrorChannel{
+		hash:          blockHash,
+		shutterActive: &shutterActive,
+		err:           make(chan error, 1),
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.startSequencer <- h:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-h.err:
+			return e
+		}
+	}
+}
+
 func (s *Driver) StopSequencer(ctx context.Context) (common.Hash, error) {
 	if !s.driverConfig.SequencerEnabled {
 		return common.Hash{}, errors.New("sequencer is not enabled")
@@ -544,7 +594,9 @@ type hashAndError struct {
 
 type hashAndErrorChannel struct {
 	hash common.Hash
-	err  chan error
+	// shutterActive is the shutter state to continue sequencing with, nil to keep the current state.
+	shutterActive *bool
+	err           chan error
 }
 
 // checkForGapInUnsafeQueue checks if there is a gap in the unsafe queue and attempts to retrieve the missing payloads from an alt-sync method.
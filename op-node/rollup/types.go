@@ -34,6 +34,10 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+
+	ErrMissingShutterKeyperSetManagerAddress     = errors.New("missing shutter keyper set manager address")
+	ErrMissingShutterKeyBroadcastContractAddress = errors.New("missing shutter key broadcast contract address")
+	ErrMissingShutterInboxAddress                = errors.New("missing shutter inbox address")
 )
 
 type Genesis struct {
@@ -81,6 +85,30 @@ type Config struct {
 
 	SpanBatchTime *uint64 `json:"span_batch_time,omitempty"`
 
+	// BlobsTime sets the activation time of the blob data-availability source:
+	// from then on batcher data is also read from EIP-4844 blobs of batcher transactions.
+	// This requires L1 to support blob transactions (Cancun).
+	// Active if BlobsTime != nil && L1 block timestamp >= *BlobsTime, inactive otherwise.
+	BlobsTime *uint64 `json:"blobs_time,omitempty"`
+
+	// ChannelCompressionTime sets the activation time of versioned channels:
+	// from then on channel data may be prefixed with a channel version byte,
+	// to compress the channel with brotli or zstd instead of zlib.
+	// Active if ChannelCompressionTime != nil && L1 block timestamp >= *ChannelCompressionTime, inactive otherwise.
+	ChannelCompressionTime *uint64 `json:"channel_compression_time,omitempty"`
+
+	// AltDATime sets the activation time of alternative data-availability commitments:
+	// from then on batcher data that starts with the alt-DA tx data version carries the
+	// commitment to frame data that is retrieved from a DA server, instead of the frames.
+	// Active if AltDATime != nil && L1 block timestamp >= *AltDATime, inactive otherwise.
+	AltDATime *uint64 `json:"alt_da_time,omitempty"`
+
+	// ShutterTime sets the activation time of the shutter encrypted mempool.
+	// From then on the sequencer has to include the decryption key of every
+	// block in the payload attributes, as long as shutter is not paused on L2.
+	// Active if ShutterTime != nil && L2 block timestamp >= *ShutterTime, inactive otherwise.
+	ShutterTime *uint64 `json:"shutter_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -93,6 +121,11 @@ type Config struct {
 
 	// L1 address that declares the protocol versions, optional (Beta feature)
 	ProtocolVersionsAddress common.Address `json:"protocol_versions_address,omitempty"`
+
+	// L2 addresses of the shutter predeploys, required if ShutterTime is set.
+	ShutterKeyperSetManagerAddress     common.Address `json:"shutter_keyper_set_manager_address,omitempty"`
+	ShutterKeyBroadcastContractAddress common.Address `json:"shutter_key_broadcast_contract_address,omitempty"`
+	ShutterInboxAddress                common.Address `json:"shutter_inbox_address,omitempty"`
 }
 
 // ValidateL1Config checks L1 config variables for errors.
@@ -257,11 +290,33 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	if err := cfg.checkShutter(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkShutter verifies that the shutter predeploys are known
+// if shutter is scheduled.
+func (cfg *Config) checkShutter() error {
+	if cfg.ShutterTime == nil {
+		return nil
+	}
+	if cfg.ShutterKeyperSetManagerAddress == (common.Address{}) {
+		return ErrMissingShutterKeyperSetManagerAddress
+	}
+	if cfg.ShutterKeyBroadcastContractAddress == (common.Address{}) {
+		return ErrMissingShutterKeyBroadcastContractAddress
+	}
+	if cfg.ShutterInboxAddress == (common.Address{}) {
+		return ErrMissingShutterInboxAddress
+	}
 	return nil
 }
 
 func (c *Config) L1Signer() types.Signer {
-	return types.NewLondonSigner(c.L1ChainID)
+	// the Cancun signer supports all prior transaction types, plus blob transactions
+	return types.NewCancunSigner(c.L1ChainID)
 }
 
 // IsRegolith returns true if the Regolith hardfork is active at or past the given timestamp.
@@ -278,6 +333,34 @@ func (c *Config) IsSpanBatch(timestamp uint64) bool {
 	return c.SpanBatchTime != nil && timestamp >= *c.SpanBatchTime
 }
 
+// IsBlobs returns true if the blob data-availability source is active
+// at or past the given L1 timestamp.
+func (c *Config) IsBlobs(l1Timestamp uint64) bool {
+	return c.BlobsTime != nil && l1Timestamp >= *c.BlobsTime
+}
+
+// IsChannelCompression returns true if versioned channel compression is active
+// at or past the given L1 timestamp.
+func (c *Config) IsChannelCompression(l1Timestamp uint64) bool {
+	return c.ChannelCompressionTime != nil && l1Timestamp >= *c.ChannelCompressionTime
+}
+
+// IsAltDA returns true if alt-DA commitments are resolved at or past the given L1 timestamp.
+func (c *Config) IsAltDA(l1Timestamp uint64) bool {
+	return c.AltDATime != nil && l1Timestamp >= *c.AltDATime
+}
+
+// IsShutter returns true if shutter is activated at or past the given timestamp.
+// Shutter can still be paused on L2 when it is activated.
+func (c *Config) IsShutter(timestamp uint64) bool {
+	return c.ShutterTime != nil && timestamp >= *c.ShutterTime
+}
+
+// IsShutterScheduled returns true if shutter is activated at any point in time.
+func (c *Config) IsShutterScheduled() bool {
+	return c.ShutterTime != nil
+}
+
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
@@ -307,6 +390,10 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
 	banner += fmt.Sprintf("  - Canyon: %s\n", fmtForkTimeOrUnset(c.CanyonTime))
 	banner += fmt.Sprintf("  - SpanBatch: %s\n", fmtForkTimeOrUnset(c.SpanBatchTime))
+	banner += fmt.Sprintf("  - Blobs: %s\n", fmtForkTimeOrUnset(c.BlobsTime))
+	banner += fmt.Sprintf("  - ChannelCompression: %s\n", fmtForkTimeOrUnset(c.ChannelCompressionTime))
+	banner += fmt.Sprintf("  - AltDA: %s\n", fmtForkTimeOrUnset(c.AltDATime))
+	banner += fmt.Sprintf("  - Shutter: %s\n", fmtForkTimeOrUnset(c.ShutterTime))
 	// Report the protocol version
 	banner += fmt.Sprintf("Node supports up to OP-Stack Protocol Version: %s\n", OPStackSupport)
 	return banner
@@ -334,6 +421,10 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime),
 		"canyon_time", fmtForkTimeOrUnset(c.CanyonTime),
 		"span_batch_time", fmtForkTimeOrUnset(c.SpanBatchTime),
+		"blobs_time", fmtForkTimeOrUnset(c.BlobsTime),
+		"channel_compression_time", fmtForkTimeOrUnset(c.ChannelCompressionTime),
+		"alt_da_time", fmtForkTimeOrUnset(c.AltDATime),
+		"shutter_time", fmtForkTimeOrUnset(c.ShutterTime),
 	)
 }
 
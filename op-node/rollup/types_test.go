@@ -197,6 +197,45 @@ func TestRegolithActivation(t *testing.T) {
 	require.True(t, config.IsRegolith(124))
 }
 
+func TestBlobsActivation(t *testing.T) {
+	config := randConfig()
+	config.BlobsTime = nil
+	require.False(t, config.IsBlobs(0), "false if nil time, even if checking 0")
+	require.False(t, config.IsBlobs(123456), "false if nil time")
+	x := uint64(123)
+	config.BlobsTime = &x
+	require.False(t, config.IsBlobs(122))
+	require.True(t, config.IsBlobs(123))
+	require.True(t, config.IsBlobs(124))
+}
+
+// TestShutterActivation tests the activation condition of shutter.
+func TestShutterActivation(t *testing.T) {
+	config := randConfig()
+	config.ShutterTime = nil
+	require.False(t, config.IsShutterScheduled())
+	require.False(t, config.IsShutter(0), "false if nil time, even if checking 0")
+	require.False(t, config.IsShutter(123456), "false if nil time")
+	config.ShutterTime = new(uint64)
+	require.True(t, config.IsShutterScheduled())
+	require.True(t, config.IsShutter(0), "true at zero")
+	require.True(t, config.IsShutter(123456), "true for any")
+	x := uint64(123)
+	config.ShutterTime = &x
+	require.True(t, config.IsShutterScheduled())
+	require.False(t, config.IsShutter(0))
+	require.False(t, config.IsShutter(122))
+	require.True(t, config.IsShutter(123))
+	require.True(t, config.IsShutter(124))
+}
+
+func setShutter(cfg *Config) {
+	cfg.ShutterTime = new(uint64)
+	cfg.ShutterKeyperSetManagerAddress = common.Address{0x01}
+	cfg.ShutterKeyBroadcastContractAddress = common.Address{0x02}
+	cfg.ShutterInboxAddress = common.Address{0x03}
+}
+
 type mockL2Client struct {
 	chainID *big.Int
 	Hash    common.Hash
@@ -386,6 +425,39 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name: "ShutterNoKeyperSetManagerAddress",
+			modifier: func(cfg *Config) {
+				setShutter(cfg)
+				cfg.ShutterKeyperSetManagerAddress = common.Address{}
+			},
+			expectedErr: ErrMissingShutterKeyperSetManagerAddress,
+		},
+		{
+			name: "ShutterNoKeyBroadcastContractAddress",
+			modifier: func(cfg *Config) {
+				setShutter(cfg)
+				cfg.ShutterKeyBroadcastContractAddress = common.Address{}
+			},
+			expectedErr: ErrMissingShutterKeyBroadcastContractAddress,
+		},
+		{
+			name: "ShutterNoInboxAddress",
+			modifier: func(cfg *Config) {
+				setShutter(cfg)
+				cfg.ShutterInboxAddress = common.Address{}
+			},
+			expectedErr: ErrMissingShutterInboxAddress,
+		},
+		{
+			name: "ShutterPredeploysWithoutActivation",
+			modifier: func(cfg *Config) {
+				setShutter(cfg)
+				cfg.ShutterTime = nil
+				cfg.ShutterInboxAddress = common.Address{}
+			},
+			expectedErr: nil,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
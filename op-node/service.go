@@ -17,6 +17,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/election"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/node"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
@@ -72,9 +74,16 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	}
 	shutter := NewShutterConfig(ctx)
 
+	electionConfig, err := NewElectionConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sequencer election config: %w", err)
+	}
+
 	cfg := &node.Config{
 		Shutter: shutter,
 		L1:      l1Endpoint,
+		Beacon:  NewBeaconEndpointConfig(ctx),
+		AltDA:   altda.ReadCLIConfig(ctx),
 		L2:      l2Endpoint,
 		L2Sync:  l2SyncEndpoint,
 		Rollup:  *rollupConfig,
@@ -103,10 +112,14 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			Moniker: ctx.String(flags.HeartbeatMonikerFlag.Name),
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
 		},
-		ConfigPersistence: configPersistence,
-		Sync:              *syncConfig,
-		RollupHalt:        haltOption,
-		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
+		ConfigPersistence:   configPersistence,
+		Sync:                *syncConfig,
+		RollupHalt:          haltOption,
+		RethDBPath:          ctx.String(flags.L1RethDBPath.Name),
+		SafeDBPath:          ctx.String(flags.SafeDBPath.Name),
+		SafeDBRetention:     ctx.Uint64(flags.SafeDBRetention.Name),
+		DerivationTracePath: ctx.String(flags.DerivationTracePath.Name),
+		Election:            *electionConfig,
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -121,12 +134,20 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
-		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
-		L1TrustRPC:       ctx.Bool(flags.L1TrustRPC.Name),
-		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
-		RateLimit:        ctx.Float64(flags.L1RPCRateLimit.Name),
-		BatchSize:        ctx.Int(flags.L1RPCMaxBatchSize.Name),
-		HttpPollInterval: ctx.Duration(flags.L1HTTPPollInterval.Name),
+		L1NodeAddr:          ctx.String(flags.L1NodeAddr.Name),
+		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:           sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
+		RateLimit:           ctx.Float64(flags.L1RPCRateLimit.Name),
+		BatchSize:           ctx.Int(flags.L1RPCMaxBatchSize.Name),
+		PrefetchDepth:       ctx.Uint64(flags.L1PrefetchDepth.Name),
+		PrefetchConcurrency: ctx.Int(flags.L1PrefetchConcurrency.Name),
+		HttpPollInterval:    ctx.Duration(flags.L1HTTPPollInterval.Name),
+	}
+}
+
+func NewBeaconEndpointConfig(ctx *cli.Context) *node.L1BeaconEndpointConfig {
+	return &node.L1BeaconEndpointConfig{
+		BeaconAddr: ctx.String(flags.BeaconAddr.Name),
 	}
 }
 
@@ -184,6 +205,7 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		SequencerPipelined:  ctx.Bool(flags.SequencerPipelinedFlag.Name),
 	}
 }
 
@@ -251,3 +273,25 @@ func NewSyncConfig(ctx *cli.Context) *sync.Config {
 		SkipSyncStartCheck: ctx.Bool(flags.SkipSyncStartCheck.Name),
 	}
 }
+
+func NewElectionConfig(ctx *cli.Context) (*election.Config, error) {
+	var servers []election.Server
+	for _, s := range ctx.StringSlice(flags.SequencerElectionServersFlag.Name) {
+		server, err := election.ParseServer(s)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, server)
+	}
+	return &election.Config{
+		Enabled:              ctx.Bool(flags.SequencerElectionEnabledFlag.Name),
+		ServerID:             ctx.String(flags.SequencerElectionServerIDFlag.Name),
+		ListenAddr:           ctx.String(flags.SequencerElectionListenAddrFlag.Name),
+		StorageDir:           ctx.String(flags.SequencerElectionStorageDirFlag.Name),
+		Bootstrap:            ctx.Bool(flags.SequencerElectionBootstrapFlag.Name),
+		Servers:              servers,
+		HealthCheckInterval:  ctx.Duration(flags.SequencerElectionHealthIntervalFlag.Name),
+		MaxUnsafeLag:         ctx.Duration(flags.SequencerElectionMaxUnsafeLagFlag.Name),
+		HealthCheckThreshold: ctx.Uint64(flags.SequencerElectionHealthThresholdFlag.Name),
+	}, nil
+}
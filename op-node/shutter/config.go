@@ -3,16 +3,26 @@ package shutter
 import (
 	"errors"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	shclient "github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/client"
 )
 
+var ErrMissingServerAddress = errors.New("shutter is scheduled in the rollup config, but the shutter-node gRPC address is missing")
+
 type Config struct {
 	ServerAddress string
 }
 
-func (c *Config) Check() error {
-	if c.ServerAddress == "" {
-		return errors.New("server address missing")
+// Required returns true if the node has to use a shutter-node.
+// Only the sequencer includes the decryption keys in the payload
+// attributes, and only once shutter is scheduled in the rollup config.
+func (c *Config) Required(rollupCfg *rollup.Config, sequencerEnabled bool) bool {
+	return sequencerEnabled && rollupCfg.IsShutterScheduled()
+}
+
+func (c *Config) Check(rollupCfg *rollup.Config, sequencerEnabled bool) error {
+	if c.Required(rollupCfg, sequencerEnabled) && c.ServerAddress == "" {
+		return ErrMissingServerAddress
 	}
 	return nil
 }
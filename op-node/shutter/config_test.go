@@ -0,0 +1,36 @@
+package shutter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+func TestConfigCheck(t *testing.T) {
+	scheduled := &rollup.Config{ShutterTime: new(uint64)}
+	unscheduled := &rollup.Config{}
+
+	tests := []struct {
+		name        string
+		cfg         Config
+		rollupCfg   *rollup.Config
+		sequencing  bool
+		required    bool
+		expectedErr error
+	}{
+		{name: "verifier", rollupCfg: scheduled},
+		{name: "sequencer without shutter", rollupCfg: unscheduled, sequencing: true},
+		{name: "sequencer with shutter", cfg: Config{ServerAddress: "localhost:8282"}, rollupCfg: scheduled, sequencing: true, required: true},
+		{name: "sequencer with shutter without address", rollupCfg: scheduled, sequencing: true, required: true, expectedErr: ErrMissingServerAddress},
+		{name: "verifier with address", cfg: Config{ServerAddress: "localhost:8282"}, rollupCfg: scheduled},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.required, test.cfg.Required(test.rollupCfg, test.sequencing))
+			require.ErrorIs(t, test.cfg.Check(test.rollupCfg, test.sequencing), test.expectedErr)
+		})
+	}
+}
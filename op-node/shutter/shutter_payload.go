@@ -29,6 +29,7 @@ func init() {
 var (
 	nullTime                  time.Time
 	ShutterDeadline           = 10 * time.Minute
+	KeyPrefetchTimeout        = 30 * time.Second
 	DeactivationDecryptionKey hexutil.Bytes
 )
 
@@ -44,6 +45,7 @@ type stateAt struct {
 	hash                 common.Hash
 	number               uint
 	active               bool
+	key                  *hexutil.Bytes // key that the block on top of this block was built with
 	created              time.Time
 	touchedByExecClient  time.Time
 	touchedByShutterNode time.Time
@@ -89,6 +91,59 @@ type Engine struct {
 	shutter *client.Client
 	log     log.Logger
 	states  map[common.Hash]*stateAt
+
+	// prefetched is the key request for an upcoming block, if any
+	prefetched *keyRequest
+}
+
+// keyRequest is a decryption key request that runs ahead of the block building.
+type keyRequest struct {
+	block  uint
+	done   chan struct{}
+	key    *client.DecryptionKeyResult
+	err    error
+	cancel context.CancelFunc
+}
+
+// PrefetchKey requests the decryption key of the given block in the background,
+// to be used by the PreparePayloadAttributes call for that block.
+// A previous prefetch of another block is cancelled.
+func (sh *Engine) PrefetchKey(block uint) {
+	if sh.prefetched != nil {
+		if sh.prefetched.block == block {
+			return
+		}
+		sh.prefetched.cancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), KeyPrefetchTimeout)
+	req := &keyRequest{block: block, done: make(chan struct{}), cancel: cancel}
+	sh.prefetched = req
+	go func() {
+		defer close(req.done)
+		defer cancel()
+		req.key, req.err = sh.shutter.GetKey(ctx, block)
+	}()
+}
+
+// getKey returns the decryption key of the given block, from the prefetched request if there is one.
+// A prefetched request that is still pending when ctx expires is kept for the next attempt.
+func (sh *Engine) getKey(ctx context.Context, block uint) (*client.DecryptionKeyResult, error) {
+	req := sh.prefetched
+	if req == nil || req.block != block {
+		return sh.shutter.GetKey(ctx, block)
+	}
+	select {
+	case <-req.done:
+		sh.prefetched = nil
+		if req.err != nil {
+			// the prefetch may have failed before the key was available, ask again
+			sh.log.Debug("shutter - prefetched key request failed", "block", block, "error", req.err)
+			return sh.shutter.GetKey(ctx, block)
+		}
+		return req.key, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 func (sh *Engine) getStateAt(hash common.Hash) *stateAt {
@@ -109,6 +164,38 @@ func (sh *Engine) setStateAt(state *stateAt) bool {
 	return ok
 }
 
+// ActiveAfter returns whether shutter is assumed active for the block built on top of the given block,
+// which is only known once a block was built on the given block.
+func (sh *Engine) ActiveAfter(parent common.Hash) (active bool, known bool) {
+	state := sh.getStateAt(parent)
+	if state == nil {
+		return false, false
+	}
+	return state.active, true
+}
+
+// DecryptionKeyAfter returns the decryption key that the block on top of the given block was built with,
+// or nil if there was none.
+func (sh *Engine) DecryptionKeyAfter(parent common.Hash) *hexutil.Bytes {
+	state := sh.getStateAt(parent)
+	if state == nil {
+		return nil
+	}
+	return state.key
+}
+
+// RestoreActive sets the shutter state for the blocks built on top of the given block,
+// e.g. to continue with the shutter state of another sequencer.
+func (sh *Engine) RestoreActive(head eth.L2BlockRef, active bool) {
+	state := &stateAt{
+		hash:   head.Hash,
+		number: uint(head.Number),
+		active: active,
+	}
+	state.touch(updateEntityCreated)
+	sh.setStateAt(state)
+}
+
 var ErrInvalidShutterState = errors.New("shutter state invalid")
 
 func (sh *Engine) RegisterPayloadResult(
@@ -142,7 +229,9 @@ func (sh *Engine) RegisterPayloadResult(
 			// shutter state
 			return
 		}
+		state.key = nil
 	} else {
+		state.key = attrs.DecryptionKey
 		sh.log.Info(
 			"engine API start success",
 			"block", l2Parent.Number+1,
@@ -170,6 +259,56 @@ func (sh *Engine) decideError(state *stateAt, attrs *eth.PayloadAttributes, err
 	return attrs, nil
 }
 
+// handleKeyError decides, based on the typed error the shutter-node
+// returned, whether shutter is inactive, the key request should
+// be retried or the execution client should be asked.
+func (sh *Engine) handleKeyError(state *stateAt, attrs *eth.PayloadAttributes, err error) (*eth.PayloadAttributes, error) {
+	switch {
+	case errors.Is(err, client.ErrNotActive):
+		// the shutter-node knows the state
+		return sh.inactive(state, attrs), nil
+	case errors.Is(err, client.ErrNoEon):
+		// the keypers didn't start an eon for the block yet,
+		// but shutter may already be active. The execution
+		// client decides, and the key is polled again once
+		// the execution client requires one.
+		err := fmt.Errorf("gRPC 'GetDecryptionKey' returned with error: %w", err)
+		return sh.decideError(state, attrs, err)
+	case errors.Is(err, client.ErrNotYetAvailable):
+		// shutter is active, but the keypers didn't
+		// publish the key yet. Poll the shutter API again
+		// on the next action cycle.
+		return nil, derive.NewTemporaryError(
+			fmt.Errorf("%w: %w", ErrShutterFetchKeyTimeout, err),
+		)
+	default:
+		// the shutter-node can't tell us about the state,
+		// e.g. because it is unavailable, the block is too old
+		// or it failed internally
+		err := fmt.Errorf("gRPC 'GetDecryptionKey' returned with error: %w", err)
+		return sh.decideError(state, attrs, err)
+	}
+}
+
+// inactive handles the shutter-node reporting an
+// inactive shutter state for the block.
+func (sh *Engine) inactive(state *stateAt, attrs *eth.PayloadAttributes) *eth.PayloadAttributes {
+	if state.isTouchedBy(updateEntityExecutionClient) {
+		// if we already got confirmed by the
+		// engine API before, we have a mismatch with
+		// the shutter-node.
+		// We can disable shutter now already,
+		// because the API doesn't recover from this.
+		sh.log.Warn("shutter - shutter API mismatch, deactivating shutter")
+		attrs.DecryptionKey = &DeactivationDecryptionKey
+		return attrs
+	}
+	attrs.DecryptionKey = nil
+	state.active = false
+	state.touch(updateEntityShutterNode)
+	return attrs
+}
+
 func (sh *Engine) PreparePayloadAttributes(
 	ctx context.Context,
 	attrs *eth.PayloadAttributes,
@@ -216,37 +355,24 @@ func (sh *Engine) PreparePayloadAttributes(
 	// If it thinks shutter is active, it will block until a key
 	// is received.
 	// Other reasons for blocking long is an undesired connectivity.
-	key, err := sh.shutter.GetKey(ctx, uint(l2Parent.Number+1))
+	key, err := sh.getKey(ctx, uint(l2Parent.Number+1))
 	if err != nil {
-		err := fmt.Errorf("gRPC 'GetDecryptionKey' returned with error: %s", err)
-		return sh.decideError(state, attrs, err)
+		return sh.handleKeyError(state, attrs, err)
 	}
 	// The shutter-node api is not down and returned.
 	sh.log.Info("shutter - received key from shutter-node", "key", key)
-	hexKey := hexutil.Bytes(key.SecretKey.Marshal())
 	if !key.Active {
-		if state.isTouchedBy(updateEntityExecutionClient) {
-			// if we already got confirmed by the
-			// engine API before, we have a mismatch with
-			// the shutter-node.
-			// We can disable shutter now already,
-			// because the API doesn't recover from this.
-			sh.log.Warn("shutter - shutter API mismatch, deactivating shutter")
-			attrs.DecryptionKey = &DeactivationDecryptionKey
-			return attrs, nil
-		}
-		attrs.DecryptionKey = nil
-		state.active = false
-	} else {
-		// as expected, we are active and we got
-		// a key within the timeout
-		attrs.DecryptionKey = &hexKey
-		sh.log.Info("shutter - got valid key",
-			"key", hexKey,
-			"active", key.Active,
-			"block", key.Block,
-		)
+		return sh.inactive(state, attrs), nil
 	}
+	// as expected, we are active and we got
+	// a key within the timeout
+	hexKey := hexutil.Bytes(key.SecretKey.Marshal())
+	attrs.DecryptionKey = &hexKey
+	sh.log.Info("shutter - got valid key",
+		"key", hexKey,
+		"active", key.Active,
+		"block", key.Block,
+	)
 	state.touch(updateEntityShutterNode)
 	return attrs, nil
 }
@@ -0,0 +1,46 @@
+package shutter
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/client"
+)
+
+func TestHandleKeyError(t *testing.T) {
+	sh := &Engine{log: testlog.Logger(t, log.LvlCrit)}
+	newAttrs := func() *eth.PayloadAttributes {
+		return &eth.PayloadAttributes{DecryptionKey: &hexutil.Bytes{0x01}}
+	}
+
+	t.Run("not active", func(t *testing.T) {
+		attrs, err := sh.handleKeyError(&stateAt{active: true}, newAttrs(), client.ErrNotActive)
+		require.NoError(t, err)
+		require.Nil(t, attrs.DecryptionKey)
+	})
+
+	t.Run("not yet available", func(t *testing.T) {
+		_, err := sh.handleKeyError(&stateAt{active: true}, newAttrs(), client.ErrNotYetAvailable)
+		require.ErrorIs(t, err, derive.ErrTemporary)
+	})
+
+	t.Run("no eon", func(t *testing.T) {
+		// the execution client decides first whether shutter is active
+		state := &stateAt{active: true}
+		attrs, err := sh.handleKeyError(state, newAttrs(), client.ErrNoEon)
+		require.NoError(t, err)
+		require.Nil(t, attrs.DecryptionKey)
+		require.False(t, state.active)
+
+		// once the execution client requires a key, it is polled again
+		state.touch(updateEntityExecutionClient)
+		_, err = sh.handleKeyError(state, newAttrs(), client.ErrNoEon)
+		require.ErrorIs(t, err, derive.ErrTemporary)
+	})
+}
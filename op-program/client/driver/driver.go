@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
@@ -36,7 +37,8 @@ type Driver struct {
 }
 
 func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher, l2Source L2Source, targetBlockNum uint64) *Driver {
-	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l2Source, metrics.NoopMetrics, &sync.Config{})
+	// blobs and alt-DA are not supported by the program (yet): derivation fails once either fork is active.
+	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, nil, nil, l2Source, metrics.NoopMetrics, &sync.Config{}, safedb.Disabled, derive.NoopTracer)
 	pipeline.Reset()
 	return &Driver{
 		logger:         logger,
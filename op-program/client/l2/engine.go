@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 var (
@@ -26,7 +27,7 @@ type OracleEngine struct {
 }
 
 func NewOracleEngine(rollupCfg *rollup.Config, logger log.Logger, backend engineapi.EngineBackend) *OracleEngine {
-	engineAPI := engineapi.NewL2EngineAPI(logger, backend)
+	engineAPI := engineapi.NewL2EngineAPI(logger, backend, rollupCfg)
 	return &OracleEngine{
 		api:       engineAPI,
 		backend:   backend,
@@ -59,7 +60,13 @@ func (o *OracleEngine) GetPayload(ctx context.Context, payloadId eth.PayloadID)
 }
 
 func (o *OracleEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
-	return o.api.ForkchoiceUpdatedV2(ctx, state, attr)
+	res, err := o.api.ForkchoiceUpdatedV2(ctx, state, attr)
+	// The derivation handles an invalid shutter state like the engine client does for a RPC error.
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && eth.ErrorCode(rpcErr.ErrorCode()) == eth.InvalidShutterState {
+		return res, eth.InputError{Inner: err, Code: eth.InvalidShutterState}
+	}
+	return res, err
 }
 
 func (o *OracleEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
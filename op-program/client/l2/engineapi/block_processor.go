@@ -39,7 +39,7 @@ type BlockProcessor struct {
 	dataProvider BlockDataProvider
 }
 
-func NewBlockProcessorFromPayloadAttributes(provider BlockDataProvider, parent common.Hash, params *eth.PayloadAttributes) (*BlockProcessor, error) {
+func NewBlockProcessorFromPayloadAttributes(provider BlockDataProvider, shutter ShutterSchedule, parent common.Hash, params *eth.PayloadAttributes) (*BlockProcessor, error) {
 	header := &types.Header{
 		ParentHash: parent,
 		Coinbase:   params.SuggestedFeeRecipient,
@@ -50,7 +50,20 @@ func NewBlockProcessorFromPayloadAttributes(provider BlockDataProvider, parent c
 		MixDigest:  common.Hash(params.PrevRandao),
 		Nonce:      types.EncodeNonce(0),
 	}
-	return NewBlockProcessorFromHeader(provider, header)
+	processor, err := NewBlockProcessorFromHeader(provider, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := processor.CheckShutterState(shutter, params.DecryptionKey != nil); err != nil {
+		return nil, err
+	}
+	// The decryption key is revealed by the first transaction of the block.
+	if params.DecryptionKey != nil {
+		if err := processor.AddTx(newRevealTx(*params.DecryptionKey)); err != nil {
+			return nil, fmt.Errorf("%w: failed to apply reveal transaction: %w", ErrInvalidShutterState, err)
+		}
+	}
+	return processor, nil
 }
 
 func NewBlockProcessorFromHeader(provider BlockDataProvider, h *types.Header) (*BlockProcessor, error) {
@@ -79,6 +92,22 @@ func NewBlockProcessorFromHeader(provider BlockDataProvider, h *types.Header) (*
 	}, nil
 }
 
+// CheckShutterState checks that a block reveals a decryption key if and only if shutter is active for the block.
+func (b *BlockProcessor) CheckShutterState(shutter ShutterSchedule, withKey bool) error {
+	// the state is copied to not touch any accounts of the block
+	active, err := shutterActive(b.dataProvider, shutter, b.header, b.state.Copy())
+	if err != nil {
+		return err
+	}
+	if active && !withKey {
+		return fmt.Errorf("%w: shutter is active, but block %d has no decryption key", ErrInvalidShutterState, b.header.Number)
+	}
+	if !active && withKey {
+		return fmt.Errorf("%w: shutter is inactive, but block %d has a decryption key", ErrInvalidShutterState, b.header.Number)
+	}
+	return nil
+}
+
 func (b *BlockProcessor) CheckTxWithinGasLimit(tx *types.Transaction) error {
 	if tx.Gas() > b.header.GasLimit {
 		return fmt.Errorf("%w tx gas: %d, block gas limit: %d", ErrExceedsGasLimit, tx.Gas(), b.header.GasLimit)
@@ -49,6 +49,8 @@ type EngineBackend interface {
 type L2EngineAPI struct {
 	log     log.Logger
 	backend EngineBackend
+	// shutter schedules shutter, nil if shutter is never active
+	shutter ShutterSchedule
 
 	// L2 block building data
 	blockProcessor *BlockProcessor
@@ -59,10 +61,11 @@ type L2EngineAPI struct {
 	payloadID engine.PayloadID // ID of payload that is currently being built
 }
 
-func NewL2EngineAPI(log log.Logger, backend EngineBackend) *L2EngineAPI {
+func NewL2EngineAPI(log log.Logger, backend EngineBackend, shutter ShutterSchedule) *L2EngineAPI {
 	return &L2EngineAPI{
 		log:     log,
 		backend: backend,
+		shutter: shutter,
 	}
 }
 
@@ -86,6 +89,10 @@ func computePayloadId(headBlockHash common.Hash, params *eth.PayloadAttributes)
 		hasher.Write(tx)
 	}
 	_ = binary.Write(hasher, binary.BigEndian, *params.GasLimit)
+	if params.DecryptionKey != nil {
+		_ = binary.Write(hasher, binary.BigEndian, uint64(len(*params.DecryptionKey)))
+		hasher.Write(*params.DecryptionKey)
+	}
 	var out engine.PayloadID
 	copy(out[:], hasher.Sum(nil)[:8])
 	return out
@@ -139,7 +146,7 @@ func (ea *L2EngineAPI) startBlock(parent common.Hash, params *eth.PayloadAttribu
 		ea.log.Warn("started building new block without ending previous block", "previous", ea.blockProcessor.header, "prev_payload_id", ea.payloadID)
 	}
 
-	processor, err := NewBlockProcessorFromPayloadAttributes(ea.backend, parent, params)
+	processor, err := NewBlockProcessorFromPayloadAttributes(ea.backend, ea.shutter, parent, params)
 	if err != nil {
 		return err
 	}
@@ -341,7 +348,10 @@ func (ea *L2EngineAPI) forkchoiceUpdated(ctx context.Context, state *eth.Forkcho
 	if attr != nil {
 		err := ea.startBlock(state.HeadBlockHash, attr)
 		if err != nil {
-			ea.log.Error("Failed to start block building", "err", err, "noTxPool", attr.NoTxPool, "txs", len(attr.Transactions), "timestamp", attr.Timestamp)
+			ea.log.Error("Failed to start block building", "err", err, "noTxPool", attr.NoTxPool, "txs", len(attr.Transactions), "timestamp", attr.Timestamp, "decryptionKey", attr.DecryptionKey != nil)
+			if errors.Is(err, ErrInvalidShutterState) {
+				return STATUS_INVALID, &shutterStateError{err: err}
+			}
 			return STATUS_INVALID, engine.InvalidPayloadAttributes.With(err)
 		}
 
@@ -421,6 +431,10 @@ func (ea *L2EngineAPI) newPayload(ctx context.Context, payload *eth.ExecutionPay
 		ea.log.Warn("State not available, ignoring new payload")
 		return &eth.PayloadStatusV1{Status: eth.ExecutionAccepted}, nil
 	}
+	if err := checkRevealTx(ea.backend, ea.shutter, block); err != nil {
+		ea.log.Warn("NewPayloadV1: invalid shutter state", "error", err)
+		return ea.invalid(err, parent.Header()), nil
+	}
 	log.Trace("Inserting block without sethead", "hash", block.Hash(), "number", block.Number)
 	if err := ea.backend.InsertBlockWithoutSetHead(block); err != nil {
 		ea.log.Warn("NewPayloadV1: inserting block failed", "error", err)
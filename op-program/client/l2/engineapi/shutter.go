@@ -0,0 +1,93 @@
+package engineapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrInvalidShutterState = errors.New("invalid shutter state")
+
+// shutterStateCallGas is the gas available to read the shutter state from the keyper set manager.
+const shutterStateCallGas = 100_000
+
+// pausedSelector is the selector of the paused() method of the keyper set manager,
+// which pauses shutter when the keypers stop releasing decryption keys.
+var pausedSelector = crypto.Keccak256([]byte("paused()"))[:4]
+
+// shutterStateError is returned by the engine API when the shutter state does not match the
+// payload attributes, with the InvalidShutterState error code of the engine API.
+type shutterStateError struct {
+	err error
+}
+
+func (e *shutterStateError) Error() string {
+	return e.err.Error()
+}
+
+func (e *shutterStateError) ErrorCode() int {
+	return int(eth.InvalidShutterState)
+}
+
+func (e *shutterStateError) Unwrap() error {
+	return e.err
+}
+
+// newRevealTx creates the reveal transaction that publishes the shutter decryption key
+// as first transaction of a block, and triggers the decryption of the inbox transactions.
+func newRevealTx(key hexutil.Bytes) *types.Transaction {
+	return types.NewTx(&types.RevealTx{Key: key})
+}
+
+// ShutterSchedule reports whether shutter is scheduled for a block timestamp, as configured by the
+// shutter time of the rollup config.
+type ShutterSchedule interface {
+	IsShutter(timestamp uint64) bool
+}
+
+// shutterActive returns whether shutter is active for the block with the given header.
+// Shutter is only active once it is scheduled and the keyper set manager is deployed,
+// and then as long as the keyper set manager in the state of the parent block is not paused.
+func shutterActive(provider BlockDataProvider, schedule ShutterSchedule, header *types.Header, statedb *state.StateDB) (bool, error) {
+	if schedule == nil || !schedule.IsShutter(header.Time) {
+		return false, nil
+	}
+	cfg := provider.Config()
+	if cfg.Shutter == nil || statedb.GetCodeSize(cfg.Shutter.KeyperSetManagerAddress) == 0 {
+		return false, nil
+	}
+	blockCtx := core.NewEVMBlockContext(header, provider, nil, cfg, statedb)
+	evm := vm.NewEVM(blockCtx, vm.TxContext{}, statedb, cfg, vm.Config{})
+	ret, _, err := evm.StaticCall(vm.AccountRef(common.Address{}), cfg.Shutter.KeyperSetManagerAddress, pausedSelector, shutterStateCallGas)
+	if err != nil {
+		return false, fmt.Errorf("failed to read shutter state: %w", err)
+	}
+	if len(ret) != 32 {
+		return false, fmt.Errorf("unexpected shutter state result of %d bytes", len(ret))
+	}
+	return ret[31] == 0, nil
+}
+
+// checkRevealTx checks that a block reveals a decryption key if and only if shutter is active for the block,
+// and that only the first transaction of the block reveals the key.
+func checkRevealTx(provider BlockDataProvider, schedule ShutterSchedule, block *types.Block) error {
+	txs := block.Transactions()
+	for i, tx := range txs {
+		if i > 0 && tx.Type() == types.RevealTxType {
+			return fmt.Errorf("%w: reveal transaction (%d) is not the first transaction", ErrInvalidShutterState, i)
+		}
+	}
+	processor, err := NewBlockProcessorFromHeader(provider, block.Header())
+	if err != nil {
+		return err
+	}
+	return processor.CheckShutterState(schedule, len(txs) > 0 && txs[0].Type() == types.RevealTxType)
+}
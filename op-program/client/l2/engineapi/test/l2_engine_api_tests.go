@@ -9,9 +9,11 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -238,6 +240,39 @@ func RunEngineAPITests(t *testing.T, createBackend func(t *testing.T) engineapi.
 		api.assert.Equal(eth.ExecutionInvalid, result.PayloadStatus.Status)
 	})
 
+	t.Run("RejectCreateBlockWithDecryptionKeyWhenShutterInactive", func(t *testing.T) {
+		api := newTestHelper(t, createBackend)
+		genesis := api.backend.CurrentHeader()
+		api.assert.Nil(api.backend.Config().Shutter, "shutter should not be configured")
+
+		nextBlockTime := eth.Uint64Quantity(genesis.Time + 1)
+		var w *types.Withdrawals
+		if api.backend.Config().IsCanyon(uint64(nextBlockTime)) {
+			w = &types.Withdrawals{}
+		}
+		key := hexutil.Bytes{0x01, 0x02, 0x03}
+
+		result, err := api.engine.ForkchoiceUpdatedV2(api.ctx, &eth.ForkchoiceState{
+			HeadBlockHash:      genesis.Hash(),
+			SafeBlockHash:      genesis.Hash(),
+			FinalizedBlockHash: genesis.Hash(),
+		}, &eth.PayloadAttributes{
+			Timestamp:             nextBlockTime,
+			PrevRandao:            eth.Bytes32(genesis.MixDigest),
+			SuggestedFeeRecipient: feeRecipient,
+			Transactions:          nil,
+			NoTxPool:              true,
+			GasLimit:              &gasLimit,
+			Withdrawals:           w,
+			DecryptionKey:         &key,
+		})
+		api.assert.ErrorIs(err, engineapi.ErrInvalidShutterState)
+		var rpcErr rpc.Error
+		api.assert.ErrorAs(err, &rpcErr)
+		api.assert.Equal(int(eth.InvalidShutterState), rpcErr.ErrorCode())
+		api.assert.Equal(eth.ExecutionInvalid, result.PayloadStatus.Status)
+	})
+
 	t.Run("UpdateSafeAndFinalizedHead", func(t *testing.T) {
 		api := newTestHelper(t, createBackend)
 
@@ -292,6 +327,109 @@ func RunEngineAPITests(t *testing.T, createBackend func(t *testing.T) engineapi.
 	})
 }
 
+// RunShutterEngineAPITests runs the engine API tests of the active shutter path. The backend must be
+// created from a genesis with the shutter predeploys, and an unpaused keyper set manager.
+// Shutter is scheduled for the second block after genesis.
+func RunShutterEngineAPITests(t *testing.T, createBackend func(t *testing.T) engineapi.EngineBackend) {
+	shutterOffset := uint64(4)
+	key := hexutil.Bytes{0x01, 0x02, 0x03}
+
+	t.Run("CreateBlockWithoutDecryptionKeyBeforeShutterTime", func(t *testing.T) {
+		api := newShutterTestHelper(t, createBackend, &shutterOffset)
+		genesis := api.backend.CurrentHeader()
+		api.assert.NotNil(api.backend.Config().Shutter, "shutter should be configured")
+
+		_, err := api.startShutterBlock(genesis, eth.Uint64Quantity(genesis.Time+2), &key)
+		api.assert.ErrorIs(err, engineapi.ErrInvalidShutterState, "keys are rejected before the shutter time")
+
+		block := api.addBlock()
+		api.assert.Equal(block.BlockHash, api.headHash(), "should create block without key before the shutter time")
+	})
+
+	t.Run("RejectCreateBlockWithoutDecryptionKeyWhenShutterActive", func(t *testing.T) {
+		api := newShutterTestHelper(t, createBackend, &shutterOffset)
+		head := api.backend.GetHeaderByHash(common.Hash(api.addBlock().BlockHash))
+
+		result, err := api.startShutterBlock(head, eth.Uint64Quantity(head.Time+2), nil)
+		api.assert.ErrorIs(err, engineapi.ErrInvalidShutterState)
+		var rpcErr rpc.Error
+		api.assert.ErrorAs(err, &rpcErr)
+		api.assert.Equal(int(eth.InvalidShutterState), rpcErr.ErrorCode())
+		api.assert.Equal(eth.ExecutionInvalid, result.PayloadStatus.Status)
+	})
+
+	t.Run("CreateBlockWithDecryptionKey", func(t *testing.T) {
+		api := newShutterTestHelper(t, createBackend, &shutterOffset)
+		head := api.backend.GetHeaderByHash(common.Hash(api.addBlock().BlockHash))
+		timestamp := eth.Uint64Quantity(head.Time + 2)
+
+		otherKey := hexutil.Bytes{0x04, 0x05, 0x06}
+		result, err := api.startShutterBlock(head, timestamp, &otherKey)
+		api.assert.NoError(err)
+		otherID := result.PayloadID
+		result, err = api.startShutterBlock(head, timestamp, &key)
+		api.assert.NoError(err)
+		api.assert.Equal(eth.ExecutionValid, result.PayloadStatus.Status)
+		api.assert.NotNil(result.PayloadID)
+		api.assert.NotEqual(*otherID, *result.PayloadID, "decryption key should be part of the payload ID")
+
+		block := api.getPayload(result.PayloadID)
+		api.assert.Len(block.Transactions, 1)
+		var revealTx types.Transaction
+		api.assert.NoError(revealTx.UnmarshalBinary(block.Transactions[0]))
+		api.assert.Equal(uint8(types.RevealTxType), revealTx.Type(), "reveal transaction should be first")
+		api.assert.Equal([]byte(key), revealTx.Data())
+
+		api.newPayload(block)
+		api.forkChoiceUpdated(block.BlockHash, head.Hash(), head.Hash())
+		api.assert.Equal(block.BlockHash, api.headHash(), "should import block with decryption key")
+	})
+
+	t.Run("RejectNewPayloadWithoutRevealTx", func(t *testing.T) {
+		api := newShutterTestHelper(t, createBackend, &shutterOffset)
+		head := api.backend.GetHeaderByHash(common.Hash(api.addBlock().BlockHash))
+
+		result, err := api.startShutterBlock(head, eth.Uint64Quantity(head.Time+2), &key)
+		api.assert.NoError(err)
+		block := api.getPayload(result.PayloadID)
+		block.Transactions = block.Transactions[1:]
+		updateBlockHash(block)
+
+		r, err := api.engine.NewPayloadV2(api.ctx, block)
+		api.assert.NoError(err)
+		api.assert.Equal(eth.ExecutionInvalid, r.Status)
+		api.assert.NotNil(r.ValidationError)
+		api.assert.Contains(*r.ValidationError, engineapi.ErrInvalidShutterState.Error())
+	})
+
+	t.Run("RejectNewPayloadWithMisplacedRevealTx", func(t *testing.T) {
+		api := newShutterTestHelper(t, createBackend, &shutterOffset)
+		head := api.backend.GetHeaderByHash(common.Hash(api.addBlock().BlockHash))
+
+		txData, err := derive.L1InfoDeposit(2, eth.HeaderBlockInfo(head), eth.SystemConfig{}, true)
+		api.assert.NoError(err)
+		result, err := api.startShutterBlock(head, eth.Uint64Quantity(head.Time+2), &key, types.NewTx(txData))
+		api.assert.NoError(err)
+		block := api.getPayload(result.PayloadID)
+		api.assert.Len(block.Transactions, 2)
+		block.Transactions[0], block.Transactions[1] = block.Transactions[1], block.Transactions[0]
+		updateBlockHash(block)
+
+		r, err := api.engine.NewPayloadV2(api.ctx, block)
+		api.assert.NoError(err)
+		api.assert.Equal(eth.ExecutionInvalid, r.Status)
+		api.assert.NotNil(r.ValidationError)
+		api.assert.Contains(*r.ValidationError, "reveal transaction (1) is not the first transaction")
+	})
+}
+
+// shutterTime schedules shutter from a fixed timestamp on.
+type shutterTime uint64
+
+func (s shutterTime) IsShutter(timestamp uint64) bool {
+	return timestamp >= uint64(s)
+}
+
 // Updates the block hash to the expected value based on the other fields in the payload
 func updateBlockHash(newBlock *eth.ExecutionPayload) {
 	// And fix up the block hash
@@ -308,10 +446,20 @@ type testHelper struct {
 }
 
 func newTestHelper(t *testing.T, createBackend func(t *testing.T) engineapi.EngineBackend) *testHelper {
+	return newShutterTestHelper(t, createBackend, nil)
+}
+
+// newShutterTestHelper creates a test helper with shutter scheduled the given number of seconds after genesis.
+// Shutter is never scheduled if the offset is nil.
+func newShutterTestHelper(t *testing.T, createBackend func(t *testing.T) engineapi.EngineBackend, shutterOffset *uint64) *testHelper {
 	logger := testlog.Logger(t, log.LvlDebug)
 	ctx := context.Background()
 	backend := createBackend(t)
-	api := engineapi.NewL2EngineAPI(logger, backend)
+	var schedule engineapi.ShutterSchedule
+	if shutterOffset != nil {
+		schedule = shutterTime(backend.CurrentHeader().Time + *shutterOffset)
+	}
+	api := engineapi.NewL2EngineAPI(logger, backend, schedule)
 	test := &testHelper{
 		t:       t,
 		ctx:     ctx,
@@ -426,3 +574,32 @@ func (h *testHelper) newPayload(block *eth.ExecutionPayload) {
 	h.assert.Equal(eth.ExecutionValid, r.Status)
 	h.assert.Nil(r.ValidationError)
 }
+
+// startShutterBlock starts building a block with the given decryption key, and returns the result of the request.
+func (h *testHelper) startShutterBlock(head *types.Header, newBlockTimestamp eth.Uint64Quantity, key *hexutil.Bytes, txs ...*types.Transaction) (*eth.ForkchoiceUpdatedResult, error) {
+	h.Log("Start shutter block building", "head", head.Hash(), "timestamp", newBlockTimestamp, "key", key)
+	var txData []eth.Data
+	for _, tx := range txs {
+		rlp, err := tx.MarshalBinary()
+		h.assert.NoError(err, "Failed to marshall tx %v", tx)
+		txData = append(txData, rlp)
+	}
+	var w *types.Withdrawals
+	if h.backend.Config().IsCanyon(uint64(newBlockTimestamp)) {
+		w = &types.Withdrawals{}
+	}
+	return h.engine.ForkchoiceUpdatedV2(h.ctx, &eth.ForkchoiceState{
+		HeadBlockHash:      head.Hash(),
+		SafeBlockHash:      head.Hash(),
+		FinalizedBlockHash: head.Hash(),
+	}, &eth.PayloadAttributes{
+		Timestamp:             newBlockTimestamp,
+		PrevRandao:            eth.Bytes32(head.MixDigest),
+		SuggestedFeeRecipient: feeRecipient,
+		Transactions:          txData,
+		NoTxPool:              true,
+		GasLimit:              &gasLimit,
+		Withdrawals:           w,
+		DecryptionKey:         key,
+	})
+}
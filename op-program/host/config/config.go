@@ -30,6 +30,8 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrBlobsNotSupported   = errors.New("blobs are not supported by the program")
+	ErrAltDANotSupported   = errors.New("alt-DA is not supported by the program")
 )
 
 type Config struct {
@@ -76,6 +78,14 @@ func (c *Config) Check() error {
 	if err := c.Rollup.Check(); err != nil {
 		return err
 	}
+	// The program has no preimages of blobs, so it can't derive from blob txs.
+	if c.Rollup.BlobsTime != nil {
+		return ErrBlobsNotSupported
+	}
+	// The program has no preimages of alt-DA inputs, so it can't derive from alt-DA commitments.
+	if c.Rollup.AltDATime != nil {
+		return ErrAltDANotSupported
+	}
 	if c.L1Head == (common.Hash{}) {
 		return ErrInvalidL1Head
 	}
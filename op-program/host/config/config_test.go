@@ -42,6 +42,24 @@ func TestRollupConfig(t *testing.T) {
 		err := config.Check()
 		require.ErrorIs(t, err, rollup.ErrBlockTimeZero)
 	})
+
+	t.Run("BlobsNotSupported", func(t *testing.T) {
+		config := validConfig()
+		rollupCfg := *config.Rollup
+		rollupCfg.BlobsTime = new(uint64)
+		config.Rollup = &rollupCfg
+		err := config.Check()
+		require.ErrorIs(t, err, ErrBlobsNotSupported)
+	})
+
+	t.Run("AltDANotSupported", func(t *testing.T) {
+		config := validConfig()
+		rollupCfg := *config.Rollup
+		rollupCfg.AltDATime = new(uint64)
+		config.Rollup = &rollupCfg
+		err := config.Check()
+		require.ErrorIs(t, err, ErrAltDANotSupported)
+	})
 }
 
 func TestL1HeadRequired(t *testing.T) {
@@ -27,16 +27,22 @@ var (
 		Usage:   "HTTP provider URL for L1",
 		EnvVars: prefixEnvVars("L1_ETH_RPC"),
 	}
-	RollupRpcFlag = &cli.StringFlag{
+	RollupRpcFlag = &cli.StringSliceFlag{
 		Name:    "rollup-rpc",
-		Usage:   "HTTP provider URL for the rollup node",
+		Usage:   "HTTP provider URLs for the rollup nodes, comma separated. The first one is used to track the sync status, all of them are used to verify outputs.",
 		EnvVars: prefixEnvVars("ROLLUP_RPC"),
 	}
+	// One of the L2OutputOracle and DisputeGameFactory addresses is required
 	L2OOAddressFlag = &cli.StringFlag{
 		Name:    "l2oo-address",
 		Usage:   "Address of the L2OutputOracle contract",
 		EnvVars: prefixEnvVars("L2OO_ADDRESS"),
 	}
+	DisputeGameFactoryAddressFlag = &cli.StringFlag{
+		Name:    "game-factory-address",
+		Usage:   "Address of the DisputeGameFactory contract. Outputs are proposed by creating dispute games instead of submitting them to the L2OutputOracle.",
+		EnvVars: prefixEnvVars("GAME_FACTORY_ADDRESS"),
+	}
 
 	// Optional flags
 	PollIntervalFlag = &cli.DurationFlag{
@@ -50,6 +56,27 @@ var (
 		Usage:   "Allow the proposer to submit proposals for L2 blocks derived from non-finalized L1 blocks.",
 		EnvVars: prefixEnvVars("ALLOW_NON_FINALIZED"),
 	}
+	OutputQuorumFlag = &cli.UintFlag{
+		Name:    "output-quorum",
+		Usage:   "Number of rollup nodes that must agree on an output before it is proposed. Defaults to a majority of the rollup nodes.",
+		EnvVars: prefixEnvVars("OUTPUT_QUORUM"),
+	}
+	ProposalIntervalFlag = &cli.DurationFlag{
+		Name:    "proposal-interval",
+		Usage:   "Minimum time between two dispute games created by the proposer. Required with the DisputeGameFactory address.",
+		EnvVars: prefixEnvVars("PROPOSAL_INTERVAL"),
+	}
+	DisputeGameTypeFlag = &cli.UintFlag{
+		Name:    "game-type",
+		Usage:   "Type of the dispute games created by the proposer",
+		Value:   0,
+		EnvVars: prefixEnvVars("GAME_TYPE"),
+	}
+	StoppedFlag = &cli.BoolFlag{
+		Name:    "stopped",
+		Usage:   "Initialize the proposer in a stopped state. The proposer can be started using the admin_startProposer RPC",
+		EnvVars: prefixEnvVars("STOPPED"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -57,12 +84,17 @@ var (
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	RollupRpcFlag,
-	L2OOAddressFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	L2OOAddressFlag,
+	DisputeGameFactoryAddressFlag,
 	PollIntervalFlag,
 	AllowNonFinalizedFlag,
+	OutputQuorumFlag,
+	ProposalIntervalFlag,
+	DisputeGameTypeFlag,
+	StoppedFlag,
 	L2OutputHDPathFlag,
 }
 
@@ -85,5 +117,8 @@ func CheckRequired(ctx *cli.Context) error {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
 	}
+	if ctx.IsSet(L2OOAddressFlag.Name) == ctx.IsSet(DisputeGameFactoryAddressFlag.Name) {
+		return fmt.Errorf("exactly one of the flags %s and %s is required", L2OOAddressFlag.Name, DisputeGameFactoryAddressFlag.Name)
+	}
 	return nil
 }
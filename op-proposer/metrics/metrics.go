@@ -28,6 +28,9 @@ type Metricer interface {
 	txmetrics.TxMetricer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+
+	RecordOutputDisagreement(source string)
+	RecordUnverifiedOutput(source string)
 }
 
 type Metrics struct {
@@ -41,6 +44,9 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	outputDisagreements *prometheus.CounterVec
+	unverifiedOutputs   *prometheus.CounterVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -75,6 +81,20 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		outputDisagreements: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_disagreements_total",
+			Help:      "Number of outputs on which a rollup node, L2OutputOracle proposal or dispute game disagrees with the majority of the rollup nodes",
+		}, []string{
+			"source",
+		}),
+		unverifiedOutputs: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "unverified_outputs_total",
+			Help:      "Number of published outputs that were skipped because their L2OutputOracle proposal or dispute game could not be read",
+		}, []string{
+			"source",
+		}),
 	}
 }
 
@@ -108,11 +128,30 @@ const (
 	BlockProposed = "proposed"
 )
 
+// Sources of outputs that disagree with the majority of the rollup nodes
+const (
+	RollupNodeSource     = "rollup_node"
+	L2OutputOracleSource = "l2oo"
+	DisputeGameSource    = "dispute_game"
+)
+
 // RecordL2BlocksProposed should be called when new L2 block is proposed
 func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordOutputDisagreement should be called when an output of the given source
+// disagrees with the output that the majority of the rollup nodes agree on.
+func (m *Metrics) RecordOutputDisagreement(source string) {
+	m.outputDisagreements.WithLabelValues(source).Inc()
+}
+
+// RecordUnverifiedOutput should be called when a published output of the given
+// source is skipped, because it could not be read to verify it.
+func (m *Metrics) RecordUnverifiedOutput(source string) {
+	m.unverifiedOutputs.WithLabelValues(source).Inc()
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
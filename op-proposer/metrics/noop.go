@@ -17,3 +17,6 @@ func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
 func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
+
+func (*noopMetrics) RecordOutputDisagreement(source string) {}
+func (*noopMetrics) RecordUnverifiedOutput(source string)   {}
@@ -70,3 +70,33 @@ func TestManualABIPacking(t *testing.T) {
 
 	require.Equal(t, txData, tx.Data())
 }
+
+// TestManualABIPackingDGF ensures that the manual ABI packing of dispute game creations is the same
+// as going through the bound contract.
+func TestManualABIPackingDGF(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	require.NoError(t, err)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}}, 50_000_000)
+	_, _, contract, err := bindings.DeployDisputeGameFactory(opts, backend)
+	require.NoError(t, err)
+	backend.Commit()
+	rng := rand.New(rand.NewSource(1234))
+
+	abi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+
+	output := testutils.RandomOutputResponse(rng)
+
+	txData, err := proposeL2OutputDGFTxData(abi, 3, output)
+	require.NoError(t, err)
+
+	// set a gas limit to disable gas estimation, no game implementation is registered in this test.
+	opts.GasLimit = 100_000
+	tx, err := contract.Create(opts, 3, output.OutputRoot, common.BigToHash(new(big.Int).SetUint64(output.BlockRef.Number)).Bytes())
+	require.NoError(t, err)
+
+	require.Equal(t, txData, tx.Data())
+}
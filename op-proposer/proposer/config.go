@@ -1,6 +1,9 @@
 package proposer
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -8,7 +11,6 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
-	"github.com/ethereum-optimism/optimism/op-service/sources"
 
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -25,8 +27,22 @@ type Config struct {
 	NetworkTimeout     time.Duration
 	TxManager          txmgr.TxManager
 	L1Client           *ethclient.Client
-	RollupClient       *sources.RollupClient
+	RollupClient       RollupClient
 	AllowNonFinalized  bool
+
+	// QuorumRollupClients are the rollup nodes, in addition to the RollupClient,
+	// that outputs are verified against before they are proposed.
+	QuorumRollupClients []RollupClient
+	// OutputQuorum is the number of rollup nodes that must agree on an output.
+	// Zero defaults to a majority of the rollup nodes.
+	OutputQuorum int
+
+	// DisputeGameFactoryAddr enables proposing outputs by creating dispute games
+	// through the DisputeGameFactory instead of submitting them to the L2OutputOracle.
+	// Games are created without a bond, as the DisputeGameFactory create function is not payable.
+	DisputeGameFactoryAddr *common.Address
+	DisputeGameType        uint8
+	ProposalInterval       time.Duration
 }
 
 // CLIConfig is a well typed config that is parsed from the CLI params.
@@ -38,12 +54,23 @@ type CLIConfig struct {
 	// L1EthRpc is the HTTP provider URL for L1.
 	L1EthRpc string
 
-	// RollupRpc is the HTTP provider URL for the rollup node.
-	RollupRpc string
+	// RollupRpcs are the HTTP provider URLs for the rollup nodes.
+	// The first one is used to track the sync status, all of them are used to verify outputs.
+	RollupRpcs []string
 
 	// L2OOAddress is the L2OutputOracle contract address.
+	// Exactly one of L2OOAddress and DGFAddress must be set.
 	L2OOAddress string
 
+	// DGFAddress is the DisputeGameFactory contract address.
+	DGFAddress string
+
+	// ProposalInterval is the minimum time between two dispute games created by the proposer.
+	ProposalInterval time.Duration
+
+	// DisputeGameType is the type of the dispute games created by the proposer.
+	DisputeGameType uint
+
 	// PollInterval is the delay between querying L2 for more transaction
 	// and creating a new batch.
 	PollInterval time.Duration
@@ -52,6 +79,13 @@ type CLIConfig struct {
 	// for L2 blocks derived from non-finalized L1 data.
 	AllowNonFinalized bool
 
+	// OutputQuorum is the number of rollup nodes that must agree on an output before it is proposed.
+	// Zero defaults to a majority of the rollup nodes.
+	OutputQuorum uint
+
+	// Stopped starts the proposer in a stopped state, to be started with the admin RPC.
+	Stopped bool
+
 	TxMgrConfig txmgr.CLIConfig
 
 	RPCConfig oprpc.CLIConfig
@@ -64,6 +98,23 @@ type CLIConfig struct {
 }
 
 func (c CLIConfig) Check() error {
+	if len(c.RollupRpcs) == 0 {
+		return errors.New("at least one rollup RPC must be set")
+	}
+	if c.OutputQuorum > uint(len(c.RollupRpcs)) {
+		return fmt.Errorf("output quorum %d exceeds the number of rollup RPCs %d", c.OutputQuorum, len(c.RollupRpcs))
+	}
+	if (c.L2OOAddress == "") == (c.DGFAddress == "") {
+		return errors.New("exactly one of the L2OutputOracle and DisputeGameFactory addresses must be set")
+	}
+	if c.DGFAddress != "" {
+		if c.ProposalInterval == 0 {
+			return errors.New("the proposal interval must be set when creating dispute games")
+		}
+		if c.DisputeGameType > math.MaxUint8 {
+			return fmt.Errorf("invalid dispute game type %d", c.DisputeGameType)
+		}
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -84,12 +135,17 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		// Required Flags
 		L1EthRpc:     ctx.String(flags.L1EthRpcFlag.Name),
-		RollupRpc:    ctx.String(flags.RollupRpcFlag.Name),
+		RollupRpcs:   ctx.StringSlice(flags.RollupRpcFlag.Name),
 		L2OOAddress:  ctx.String(flags.L2OOAddressFlag.Name),
+		DGFAddress:   ctx.String(flags.DisputeGameFactoryAddressFlag.Name),
 		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
 		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
+		OutputQuorum:      ctx.Uint(flags.OutputQuorumFlag.Name),
+		ProposalInterval:  ctx.Duration(flags.ProposalIntervalFlag.Name),
+		DisputeGameType:   ctx.Uint(flags.DisputeGameTypeFlag.Name),
+		Stopped:           ctx.Bool(flags.StoppedFlag.Name),
 		RPCConfig:         oprpc.ReadCLIConfig(ctx),
 		LogConfig:         oplog.ReadCLIConfig(ctx),
 		MetricsConfig:     opmetrics.ReadCLIConfig(ctx),
@@ -14,12 +14,14 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -27,12 +29,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
-	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 var supportedL2OutputVersion = eth.Bytes32{}
 
+var ErrProposerNotRunning = errors.New("proposer is not running")
+
 // Main is the entrypoint into the L2 Output Submitter. This method executes the
 // service and blocks until the service exits.
 func Main(version string, cliCtx *cli.Context) error {
@@ -62,14 +65,18 @@ func Main(version string, cliCtx *cli.Context) error {
 		return err
 	}
 
-	l.Info("Starting L2 Output Submitter")
-	if err := l2OutputSubmitter.Start(); err != nil {
-		l.Error("Unable to start L2 Output Submitter", "error", err)
-		return err
+	if cfg.Stopped {
+		l.Info("L2 Output Submitter not started, it can be started with the admin_startProposer RPC")
+	} else {
+		l.Info("Starting L2 Output Submitter")
+		if err := l2OutputSubmitter.Start(); err != nil {
+			l.Error("Unable to start L2 Output Submitter", "error", err)
+			return err
+		}
+		l.Info("L2 Output Submitter started")
 	}
 	defer l2OutputSubmitter.Stop()
 
-	l.Info("L2 Output Submitter started")
 	pprofConfig := cfg.PprofConfig
 	if pprofConfig.Enabled {
 		l.Debug("starting pprof", "addr", pprofConfig.ListenAddr, "port", pprofConfig.ListenPort)
@@ -107,7 +114,8 @@ func Main(version string, cliCtx *cli.Context) error {
 	rpcCfg := cfg.RPCConfig
 	server := oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version, oprpc.WithLogger(l))
 	if rpcCfg.EnableAdmin {
-		server.AddAPI(oprpc.ToGethAdminAPI(oprpc.NewCommonAdminAPI(&m.RPCMetrics, l)))
+		adminAPI := rpc.NewAdminAPI(l2OutputSubmitter, &m.RPCMetrics, l)
+		server.AddAPI(rpc.GetAdminAPI(adminAPI))
 		l.Info("Admin RPC enabled")
 	}
 	if err := server.Start(); err != nil {
@@ -125,21 +133,50 @@ func Main(version string, cliCtx *cli.Context) error {
 // L2OutputSubmitter is responsible for proposing outputs
 type L2OutputSubmitter struct {
 	txMgr txmgr.TxManager
-	wg    sync.WaitGroup
-	done  chan struct{}
 	log   log.Logger
 	metr  metrics.Metricer
 
-	ctx    context.Context
-	cancel context.CancelFunc
-
-	// RollupClient is used to retrieve output roots from
-	rollupClient *sources.RollupClient
+	mutex   sync.Mutex
+	running bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	// done is closed when the loop exits
+	done chan struct{}
+
+	// proposeMu serializes the proposals of the loop and the admin RPC.
+	proposeMu sync.Mutex
+	// statusMu guards the lastProposal and pendingTx, which are reported by the admin RPC.
+	statusMu     sync.Mutex
+	lastProposal *rpc.ProposalInfo
+	pendingTx    *rpc.PendingTxInfo
+
+	l1Client bind.ContractCaller
+
+	// RollupClient is used to retrieve the sync status and output roots from
+	rollupClient RollupClient
+	// quorumRollupClients are the other rollup nodes that output roots are verified against
+	quorumRollupClients []RollupClient
+	outputQuorum        int
+
+	// nextCheckedOutput is the index of the next published output, or dispute game,
+	// to verify. Nil until the first check.
+	nextCheckedOutput *uint64
+	// outputReadFailures counts the consecutive checks that failed to read the next published output.
+	outputReadFailures int
 
 	l2ooContract     *bindings.L2OutputOracleCaller
 	l2ooContractAddr common.Address
 	l2ooABI          *abi.ABI
 
+	// dgfContractAddr is set if outputs are proposed by creating dispute games, nil otherwise.
+	dgfContract     *bindings.DisputeGameFactoryCaller
+	dgfContractAddr *common.Address
+	dgfABI          *abi.ABI
+	gameType        uint8
+	// proposalInterval is the minimum time between two dispute games created by the proposer.
+	proposalInterval time.Duration
+	lastProposalTime time.Time
+
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
 	// is never valid on an alternative L1 chain that would produce different L2 data.
@@ -161,9 +198,20 @@ func NewL2OutputSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Me
 
 // NewL2OutputSubmitterConfigFromCLIConfig creates the proposer config from the CLI config.
 func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config, error) {
-	l2ooAddress, err := opservice.ParseAddress(cfg.L2OOAddress)
-	if err != nil {
-		return nil, err
+	var l2ooAddress common.Address
+	var dgfAddress *common.Address
+	if cfg.DGFAddress != "" {
+		addr, err := opservice.ParseAddress(cfg.DGFAddress)
+		if err != nil {
+			return nil, err
+		}
+		dgfAddress = &addr
+	} else {
+		addr, err := opservice.ParseAddress(cfg.L2OOAddress)
+		if err != nil {
+			return nil, err
+		}
+		l2ooAddress = addr
 	}
 
 	txManager, err := txmgr.NewSimpleTxManager("proposer", l, m, cfg.TxMgrConfig)
@@ -177,9 +225,13 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		return nil, err
 	}
 
-	rollupClient, err := dial.DialRollupClientWithTimeout(context.Background(), dial.DefaultDialTimeout, l, cfg.RollupRpc)
-	if err != nil {
-		return nil, err
+	var rollupClients []RollupClient
+	for _, rollupRpc := range cfg.RollupRpcs {
+		rollupClient, err := dial.DialRollupClientWithTimeout(context.Background(), dial.DefaultDialTimeout, l, rollupRpc)
+		if err != nil {
+			return nil, err
+		}
+		rollupClients = append(rollupClients, rollupClient)
 	}
 
 	return &Config{
@@ -187,73 +239,256 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		PollInterval:       cfg.PollInterval,
 		NetworkTimeout:     cfg.TxMgrConfig.NetworkTimeout,
 		L1Client:           l1Client,
-		RollupClient:       rollupClient,
+		RollupClient:       rollupClients[0],
 		AllowNonFinalized:  cfg.AllowNonFinalized,
 		TxManager:          txManager,
+
+		QuorumRollupClients: rollupClients[1:],
+		OutputQuorum:        int(cfg.OutputQuorum),
+
+		DisputeGameFactoryAddr: dgfAddress,
+		DisputeGameType:        uint8(cfg.DisputeGameType),
+		ProposalInterval:       cfg.ProposalInterval,
 	}, nil
 
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
 func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2OutputSubmitter, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx := context.Background()
+
+	submitter := &L2OutputSubmitter{
+		txMgr: cfg.TxManager,
+		log:   l,
+		metr:  m,
+
+		l1Client: cfg.L1Client,
+
+		rollupClient:        cfg.RollupClient,
+		quorumRollupClients: cfg.QuorumRollupClients,
+		outputQuorum:        cfg.OutputQuorum,
 
+		allowNonFinalized: cfg.AllowNonFinalized,
+		pollInterval:      cfg.PollInterval,
+		networkTimeout:    cfg.NetworkTimeout,
+	}
+
+	var err error
+	if n := len(cfg.QuorumRollupClients) + 1; submitter.outputQuorum == 0 {
+		submitter.outputQuorum = n/2 + 1
+	} else if submitter.outputQuorum > n {
+		return nil, fmt.Errorf("output quorum %d exceeds the number of rollup nodes %d", submitter.outputQuorum, n)
+	}
+	if cfg.DisputeGameFactoryAddr != nil {
+		err = submitter.initDGF(ctx, cfg)
+	} else {
+		err = submitter.initL2OO(ctx, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return submitter, nil
+}
+
+func (l *L2OutputSubmitter) initL2OO(ctx context.Context, cfg Config) error {
 	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
 	if err != nil {
-		cancel()
-		return nil, fmt.Errorf("failed to create L2OO at address %s: %w", cfg.L2OutputOracleAddr, err)
+		return fmt.Errorf("failed to create L2OO at address %s: %w", cfg.L2OutputOracleAddr, err)
 	}
 
 	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
 	defer cCancel()
 	version, err := l2ooContract.Version(&bind.CallOpts{Context: cCtx})
 	if err != nil {
-		cancel()
-		return nil, err
+		return err
 	}
 	log.Info("Connected to L2OutputOracle", "address", cfg.L2OutputOracleAddr, "version", version)
 
 	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
 	if err != nil {
-		cancel()
-		return nil, err
+		return err
 	}
 
-	return &L2OutputSubmitter{
-		txMgr:  cfg.TxManager,
-		done:   make(chan struct{}),
-		log:    l,
-		ctx:    ctx,
-		cancel: cancel,
-		metr:   m,
+	l.l2ooContract = l2ooContract
+	l.l2ooContractAddr = cfg.L2OutputOracleAddr
+	l.l2ooABI = parsed
+	return nil
+}
+
+func (l *L2OutputSubmitter) initDGF(ctx context.Context, cfg Config) error {
+	dgfContract, err := bindings.NewDisputeGameFactoryCaller(*cfg.DisputeGameFactoryAddr, cfg.L1Client)
+	if err != nil {
+		return fmt.Errorf("failed to create DisputeGameFactory at address %s: %w", cfg.DisputeGameFactoryAddr, err)
+	}
 
-		rollupClient: cfg.RollupClient,
+	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
+	defer cCancel()
+	version, err := dgfContract.Version(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return err
+	}
+	impl, err := dgfContract.GameImpls(&bind.CallOpts{Context: cCtx}, cfg.DisputeGameType)
+	if err != nil {
+		return fmt.Errorf("failed to get implementation of game type %d: %w", cfg.DisputeGameType, err)
+	}
+	if impl == (common.Address{}) {
+		return fmt.Errorf("no implementation registered for game type %d", cfg.DisputeGameType)
+	}
+	// The FaultDisputeGame disputes outputs of the L2OutputOracle: its root claim is a VM status
+	// claim that the disputed output is invalid, not an output root, so it can't carry proposals.
+	if isFaultDisputeGame(cCtx, cfg.L1Client, impl) {
+		return fmt.Errorf("game type %d is implemented by a FaultDisputeGame at %s, which only disputes L2OutputOracle outputs and can't be created with output root claims",
+			cfg.DisputeGameType, impl)
+	}
+	log.Info("Connected to DisputeGameFactory", "address", cfg.DisputeGameFactoryAddr, "version", version,
+		"game_type", cfg.DisputeGameType, "game_impl", impl)
 
-		l2ooContract:     l2ooContract,
-		l2ooContractAddr: cfg.L2OutputOracleAddr,
-		l2ooABI:          parsed,
+	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	l.dgfContract = dgfContract
+	l.dgfContractAddr = cfg.DisputeGameFactoryAddr
+	l.dgfABI = parsed
+	l.gameType = cfg.DisputeGameType
+	l.proposalInterval = cfg.ProposalInterval
+	return nil
+}
 
-		allowNonFinalized: cfg.AllowNonFinalized,
-		pollInterval:      cfg.PollInterval,
-		networkTimeout:    cfg.NetworkTimeout,
-	}, nil
+// isFaultDisputeGame returns whether the game implementation is a FaultDisputeGame,
+// which references the L2OutputOracle of the outputs it disputes.
+func isFaultDisputeGame(ctx context.Context, client bind.ContractCaller, impl common.Address) bool {
+	caller, err := bindings.NewFaultDisputeGameCaller(impl, client)
+	if err != nil {
+		return false
+	}
+	// Other game implementations don't have the getter, and fail the call.
+	l2oo, err := caller.L2OUTPUTORACLE(&bind.CallOpts{Context: ctx})
+	return err == nil && l2oo != (common.Address{})
 }
 
 func (l *L2OutputSubmitter) Start() error {
-	l.wg.Add(1)
-	go l.loop()
-	return nil
+	return l.StartL2OutputSubmitting()
 }
 
+// Stop stops the proposer loop, if it is running.
 func (l *L2OutputSubmitter) Stop() {
+	if err := l.StopL2OutputSubmitting(); err != nil && !errors.Is(err, ErrProposerNotRunning) {
+		l.log.Error("failed to stop L2 Output Submitter", "err", err)
+	}
+}
+
+// StartL2OutputSubmitting starts the loop that proposes outputs.
+func (l *L2OutputSubmitter) StartL2OutputSubmitting() error {
+	l.log.Info("Starting L2 Output Submitter")
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.running {
+		return errors.New("proposer is already running")
+	}
+	l.running = true
+	l.ctx, l.cancel = context.WithCancel(context.Background())
+	l.done = make(chan struct{})
+
+	go l.loop(l.ctx, l.done)
+
+	l.log.Info("L2 Output Submitter started")
+	return nil
+}
+
+// StopL2OutputSubmitting stops the proposer loop and waits for it to exit.
+// A proposal tx in flight is abandoned. The loop may first have to wait for
+// a proposal of the admin RPC to finish, so the proposer is reported as stopped,
+// and can be started again, before the loop exits.
+func (l *L2OutputSubmitter) StopL2OutputSubmitting() error {
+	l.log.Info("Stopping L2 Output Submitter")
+
+	l.mutex.Lock()
+	if !l.running {
+		l.mutex.Unlock()
+		return ErrProposerNotRunning
+	}
+	l.running = false
 	l.cancel()
-	close(l.done)
-	l.wg.Wait()
+	done := l.done
+	l.mutex.Unlock()
+
+	<-done
+
+	l.log.Info("L2 Output Submitter stopped")
+	return nil
+}
+
+// Running returns whether the proposer loop is running.
+func (l *L2OutputSubmitter) Running() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.running
+}
+
+// LastProposal returns the last output proposal that was included on L1, nil if there is none.
+func (l *L2OutputSubmitter) LastProposal() *rpc.ProposalInfo {
+	l.statusMu.Lock()
+	defer l.statusMu.Unlock()
+	if l.lastProposal == nil {
+		return nil
+	}
+	proposal := *l.lastProposal
+	return &proposal
+}
+
+// PendingTx returns the output proposal tx in flight, nil if there is none.
+func (l *L2OutputSubmitter) PendingTx() *rpc.PendingTxInfo {
+	l.statusMu.Lock()
+	defer l.statusMu.Unlock()
+	if l.pendingTx == nil {
+		return nil
+	}
+	pending := *l.pendingTx
+	pending.TxHashes = append([]common.Hash(nil), l.pendingTx.TxHashes...)
+	return &pending
+}
+
+// ProposeOutput immediately proposes the output of the latest finalized, or safe, L2 block,
+// regardless of the proposal interval and whether the proposer loop is running.
+// With an L2OutputOracle, it proposes the next checkpoint block if it is not past that head.
+func (l *L2OutputSubmitter) ProposeOutput(ctx context.Context, finalized bool) (*rpc.ProposalInfo, error) {
+	l.proposeMu.Lock()
+	defer l.proposeMu.Unlock()
+
+	var output *eth.OutputResponse
+	var shouldPropose bool
+	var err error
+	if l.dgfContractAddr != nil {
+		output, shouldPropose, err = l.fetchDGFOutput(ctx, !finalized)
+	} else {
+		output, shouldPropose, err = l.fetchNextOutputInfo(ctx, !finalized)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !shouldPropose {
+		return nil, errors.New("no new output to propose")
+	}
+	if err := l.proposeOutput(ctx, output); err != nil {
+		return nil, err
+	}
+	proposal := l.LastProposal()
+	if proposal == nil || proposal.OutputRoot != output.OutputRoot || proposal.L2Block != output.BlockRef.ID() {
+		return nil, errors.New("proposal tx reverted")
+	}
+	return proposal, nil
 }
 
 // FetchNextOutputInfo gets the block number of the next proposal.
 // It returns: the next block number, if the proposal should be made, error
 func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	return l.fetchNextOutputInfo(ctx, l.allowNonFinalized)
+}
+
+func (l *L2OutputSubmitter) fetchNextOutputInfo(ctx context.Context, allowNonFinalized bool) (*eth.OutputResponse, bool, error) {
 	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
 	defer cancel()
 	callOpts := &bind.CallOpts{
@@ -276,7 +511,7 @@ func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.Outpu
 
 	// Use either the finalized or safe head depending on the config. Finalized head is default & safer.
 	var currentBlockNumber *big.Int
-	if l.allowNonFinalized {
+	if allowNonFinalized {
 		currentBlockNumber = new(big.Int).SetUint64(status.SafeL2.Number)
 	} else {
 		currentBlockNumber = new(big.Int).SetUint64(status.FinalizedL2.Number)
@@ -287,13 +522,63 @@ func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.Outpu
 		return nil, false, nil
 	}
 
-	return l.fetchOutput(ctx, nextCheckpointBlock)
+	return l.fetchOutput(ctx, nextCheckpointBlock, allowNonFinalized)
+}
+
+// FetchDGFOutput gets the output of the latest finalized, or if allowed safe, L2 block
+// if the proposal interval has elapsed since the last dispute game was created.
+// It returns: the output, if a dispute game should be created for it, error
+func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	if since := time.Since(l.lastProposalTime); since < l.proposalInterval {
+		l.log.Debug("proposal interval has not elapsed", "since_last_proposal", since, "proposal_interval", l.proposalInterval)
+		return nil, false, nil
+	}
+	return l.fetchDGFOutput(ctx, l.allowNonFinalized)
 }
 
-func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*eth.OutputResponse, bool, error) {
+func (l *L2OutputSubmitter) fetchDGFOutput(ctx context.Context, allowNonFinalized bool) (*eth.OutputResponse, bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	status, err := l.rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		l.log.Error("proposer unable to get sync status", "err", err)
+		return nil, false, err
+	}
+	var blockNumber uint64
+	if allowNonFinalized {
+		blockNumber = status.SafeL2.Number
+	} else {
+		blockNumber = status.FinalizedL2.Number
+	}
+	if blockNumber == 0 {
+		l.log.Debug("no L2 block to propose yet")
+		return nil, false, nil
+	}
+
+	output, shouldPropose, err := l.fetchOutput(ctx, new(big.Int).SetUint64(blockNumber), allowNonFinalized)
+	if err != nil || !shouldPropose {
+		return nil, false, err
+	}
+
+	// Games are unique by type, root claim and extra data, so creating the same game again would revert.
+	cCtx, cancel = context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	game, err := l.dgfContract.Games(&bind.CallOpts{Context: cCtx}, l.gameType, output.OutputRoot, dgfExtraData(blockNumber))
+	if err != nil {
+		l.log.Error("proposer unable to look up dispute game", "err", err)
+		return nil, false, err
+	}
+	if game.Proxy != (common.Address{}) {
+		l.log.Debug("dispute game already exists", "game", game.Proxy, "l2blocknum", blockNumber, "output_root", output.OutputRoot)
+		return nil, false, nil
+	}
+	return output, true, nil
+}
+
+func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int, allowNonFinalized bool) (*eth.OutputResponse, bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, l.networkTimeout)
 	defer cancel()
-	output, err := l.rollupClient.OutputAtBlock(ctx, block.Uint64())
+	output, err := l.outputAtBlock(ctx, block.Uint64())
 	if err != nil {
 		l.log.Error("failed to fetch output at block %d: %w", block, err)
 		return nil, false, err
@@ -308,12 +593,12 @@ func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*e
 	}
 
 	// Always propose if it's part of the Finalized L2 chain. Or if allowed, if it's part of the safe L2 chain.
-	if !(output.BlockRef.Number <= output.Status.FinalizedL2.Number || (l.allowNonFinalized && output.BlockRef.Number <= output.Status.SafeL2.Number)) {
+	if !(output.BlockRef.Number <= output.Status.FinalizedL2.Number || (allowNonFinalized && output.BlockRef.Number <= output.Status.SafeL2.Number)) {
 		l.log.Debug("not proposing yet, L2 block is not ready for proposal",
 			"l2_proposal", output.BlockRef,
 			"l2_safe", output.Status.SafeL2,
 			"l2_finalized", output.Status.FinalizedL2,
-			"allow_non_finalized", l.allowNonFinalized)
+			"allow_non_finalized", allowNonFinalized)
 		return nil, false, nil
 	}
 	return output, true, nil
@@ -334,6 +619,21 @@ func proposeL2OutputTxData(abi *abi.ABI, output *eth.OutputResponse) ([]byte, er
 		new(big.Int).SetUint64(output.Status.CurrentL1.Number))
 }
 
+// ProposeL2OutputDGFTxData creates the transaction data for the DisputeGameFactory create function
+func (l *L2OutputSubmitter) ProposeL2OutputDGFTxData(output *eth.OutputResponse) ([]byte, error) {
+	return proposeL2OutputDGFTxData(l.dgfABI, l.gameType, output)
+}
+
+// proposeL2OutputDGFTxData creates the transaction data for the DisputeGameFactory create function
+func proposeL2OutputDGFTxData(abi *abi.ABI, gameType uint8, output *eth.OutputResponse) ([]byte, error) {
+	return abi.Pack("create", gameType, output.OutputRoot, dgfExtraData(output.BlockRef.Number))
+}
+
+// dgfExtraData is the extra data of the dispute game of an output: the ABI encoded L2 block number.
+func dgfExtraData(l2BlockNumber uint64) []byte {
+	return common.BigToHash(new(big.Int).SetUint64(l2BlockNumber)).Bytes()
+}
+
 // We wait until l1head advances beyond blocknum. This is used to make sure proposal tx won't
 // immediately fail when checking the l1 blockhash. Note that EstimateGas uses "latest" state to
 // execute the transaction by default, meaning inside the call, the head block is considered
@@ -356,8 +656,8 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 				return err
 			}
 			break
-		case <-l.done:
-			return fmt.Errorf("L2OutputSubmitter is done()")
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
 	return nil
@@ -365,6 +665,9 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 
 // sendTransaction creates & sends transactions through the underlying transaction manager.
 func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
+	if l.dgfContractAddr != nil {
+		return l.sendDGFTransaction(ctx, output)
+	}
 	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
 	if err != nil {
 		return err
@@ -373,7 +676,7 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	if err != nil {
 		return err
 	}
-	receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
+	receipt, err := l.send(ctx, output, txmgr.TxCandidate{
 		TxData:   data,
 		To:       &l.l2ooContractAddr,
 		GasLimit: 0,
@@ -392,39 +695,119 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	return nil
 }
 
-// loop is responsible for creating & submitting the next outputs
-func (l *L2OutputSubmitter) loop() {
-	defer l.wg.Done()
+// sendDGFTransaction creates a dispute game for the output through the underlying transaction manager.
+func (l *L2OutputSubmitter) sendDGFTransaction(ctx context.Context, output *eth.OutputResponse) error {
+	data, err := l.ProposeL2OutputDGFTxData(output)
+	if err != nil {
+		return err
+	}
+	receipt, err := l.send(ctx, output, txmgr.TxCandidate{
+		TxData:   data,
+		To:       l.dgfContractAddr,
+		GasLimit: 0,
+	})
+	// Back off for a proposal interval after every attempt, so that a creation that fails
+	// or reverts isn't retried on every poll.
+	l.lastProposalTime = time.Now()
+	if err != nil {
+		return err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		l.log.Error("dispute game creation tx successfully published but reverted, retrying after the proposal interval",
+			"tx_hash", receipt.TxHash, "proposal_interval", l.proposalInterval)
+	} else {
+		l.log.Info("dispute game creation tx successfully published",
+			"tx_hash", receipt.TxHash,
+			"game_type", l.gameType,
+			"l2blocknum", output.BlockRef.Number,
+			"output_root", output.OutputRoot)
+	}
+	return nil
+}
+
+// send sends the proposal tx of the output through the underlying transaction manager,
+// and keeps track of it while it is in flight and once it is included.
+func (l *L2OutputSubmitter) send(ctx context.Context, output *eth.OutputResponse, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	pending := &rpc.PendingTxInfo{
+		OutputRoot: output.OutputRoot,
+		L2Block:    output.BlockRef.ID(),
+	}
+	candidate.OnPublish = func(tx *types.Transaction) {
+		l.statusMu.Lock()
+		defer l.statusMu.Unlock()
+		pending.Nonce = hexutil.Uint64(tx.Nonce())
+		pending.TxHashes = append(pending.TxHashes, tx.Hash())
+		l.pendingTx = pending
+	}
+	receipt, err := l.txMgr.Send(ctx, candidate)
+
+	l.statusMu.Lock()
+	defer l.statusMu.Unlock()
+	l.pendingTx = nil
+	if err == nil && receipt.Status == types.ReceiptStatusSuccessful {
+		l.lastProposal = &rpc.ProposalInfo{
+			OutputRoot: output.OutputRoot,
+			L2Block:    output.BlockRef.ID(),
+			TxHash:     receipt.TxHash,
+			L1Block:    eth.BlockID{Hash: receipt.BlockHash, Number: receipt.BlockNumber.Uint64()},
+		}
+	}
+	return receipt, err
+}
+
+// proposeOutput sends the proposal tx of the output and waits for it to be included.
+func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.OutputResponse) error {
+	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	if err := l.sendTransaction(cCtx, output); err != nil {
+		l.log.Error("Failed to send proposal transaction",
+			"err", err,
+			"l1blocknum", output.Status.CurrentL1.Number,
+			"l1blockhash", output.Status.CurrentL1.Hash,
+			"l1head", output.Status.HeadL1.Number)
+		return err
+	}
+	l.metr.RecordL2BlocksProposed(output.BlockRef)
+	return nil
+}
+
+// fetchNextOutput gets the next output to propose, depending on how outputs are proposed.
+func (l *L2OutputSubmitter) fetchNextOutput(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	if l.dgfContractAddr != nil {
+		return l.FetchDGFOutput(ctx)
+	}
+	return l.FetchNextOutputInfo(ctx)
+}
 
-	ctx := l.ctx
+// loop is responsible for creating & submitting the next outputs
+func (l *L2OutputSubmitter) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
 
 	ticker := time.NewTicker(l.pollInterval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
-			output, shouldPropose, err := l.FetchNextOutputInfo(ctx)
-			if err != nil {
-				break
-			}
-			if !shouldPropose {
-				break
-			}
-			cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
-			if err := l.sendTransaction(cCtx, output); err != nil {
-				l.log.Error("Failed to send proposal transaction",
-					"err", err,
-					"l1blocknum", output.Status.CurrentL1.Number,
-					"l1blockhash", output.Status.CurrentL1.Hash,
-					"l1head", output.Status.HeadL1.Number)
-				cancel()
-				break
-			}
-			l.metr.RecordL2BlocksProposed(output.BlockRef)
-			cancel()
+			l.checkPublishedOutputs(ctx)
+			l.proposeNextOutput(ctx)
 
-		case <-l.done:
+		case <-ctx.Done():
 			return
 		}
 	}
 }
+
+// proposeNextOutput proposes the next output, if there is one to propose.
+func (l *L2OutputSubmitter) proposeNextOutput(ctx context.Context) {
+	l.proposeMu.Lock()
+	defer l.proposeMu.Unlock()
+	if ctx.Err() != nil {
+		return // stopped while waiting for a proposal of the admin RPC
+	}
+
+	output, shouldPropose, err := l.fetchNextOutput(ctx)
+	if err != nil || !shouldPropose {
+		return
+	}
+	_ = l.proposeOutput(ctx, output) // failures are logged, the output is proposed again on the next tick
+}
@@ -0,0 +1,176 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// publishingTxMgr publishes two versions of every tx, and waits for the test to include it.
+type publishingTxMgr struct {
+	published chan struct{}
+	include   chan *types.Receipt
+}
+
+func (m *publishingTxMgr) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	for _, tip := range []int64{1, 2} {
+		candidate.OnPublish(types.NewTx(&types.DynamicFeeTx{Nonce: 7, GasTipCap: big.NewInt(tip), Data: candidate.TxData}))
+	}
+	m.published <- struct{}{}
+	return <-m.include, nil
+}
+
+func (m *publishingTxMgr) From() common.Address {
+	return common.Address{}
+}
+
+func (m *publishingTxMgr) BlockNumber(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func newTestSubmitter(t *testing.T, txMgr txmgr.TxManager) *L2OutputSubmitter {
+	return &L2OutputSubmitter{
+		txMgr:        txMgr,
+		log:          testlog.Logger(t, log.LvlCrit),
+		metr:         metrics.NoopMetrics,
+		pollInterval: time.Hour,
+	}
+}
+
+func TestL2OutputSubmitterStartStop(t *testing.T) {
+	l := newTestSubmitter(t, nil)
+	require.False(t, l.Running())
+	require.ErrorIs(t, l.StopL2OutputSubmitting(), ErrProposerNotRunning)
+
+	require.NoError(t, l.StartL2OutputSubmitting())
+	require.True(t, l.Running())
+	require.Error(t, l.StartL2OutputSubmitting(), "already running")
+
+	require.NoError(t, l.StopL2OutputSubmitting())
+	require.False(t, l.Running())
+
+	// the proposer can be restarted, and stopped again on shutdown
+	require.NoError(t, l.StartL2OutputSubmitting())
+	l.Stop()
+	require.False(t, l.Running())
+	l.Stop()
+}
+
+func TestL2OutputSubmitterStopsDuringAdminProposal(t *testing.T) {
+	l := newTestSubmitter(t, nil)
+	l.rollupClient = &fakeRollupClient{output: &eth.OutputResponse{}, err: errors.New("offline")}
+	l.pollInterval = time.Millisecond
+
+	// an admin proposal is in flight, the loop waits for it on its next tick
+	l.proposeMu.Lock()
+	require.NoError(t, l.StartL2OutputSubmitting())
+	time.Sleep(10 * time.Millisecond)
+
+	stopped := make(chan error)
+	go func() { stopped <- l.StopL2OutputSubmitting() }()
+	require.Eventually(t, func() bool { return !l.Running() }, time.Second, 10*time.Millisecond)
+	select {
+	case <-stopped:
+		t.Fatal("stopped before the loop exited")
+	default:
+	}
+
+	l.proposeMu.Unlock()
+	require.NoError(t, <-stopped)
+}
+
+func TestL2OutputSubmitterTracksProposals(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	txMgr := &publishingTxMgr{published: make(chan struct{}), include: make(chan *types.Receipt)}
+	l := newTestSubmitter(t, txMgr)
+	output := testutils.RandomOutputResponse(rng)
+	require.Nil(t, l.PendingTx())
+	require.Nil(t, l.LastProposal())
+
+	send := func() {
+		_, err := l.send(context.Background(), output, txmgr.TxCandidate{TxData: []byte{1}})
+		require.NoError(t, err)
+	}
+
+	go send()
+	<-txMgr.published
+	pending := l.PendingTx()
+	require.NotNil(t, pending)
+	require.Equal(t, output.OutputRoot, pending.OutputRoot)
+	require.Equal(t, output.BlockRef.ID(), pending.L2Block)
+	require.Equal(t, hexutil.Uint64(7), pending.Nonce)
+	require.Len(t, pending.TxHashes, 2)
+	require.NotEqual(t, pending.TxHashes[0], pending.TxHashes[1])
+
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: pending.TxHashes[1], BlockHash: common.Hash{0xaa}, BlockNumber: big.NewInt(100)}
+	txMgr.include <- receipt
+	require.Eventually(t, func() bool { return l.PendingTx() == nil }, time.Second, 10*time.Millisecond)
+	proposal := l.LastProposal()
+	require.NotNil(t, proposal)
+	require.Equal(t, output.OutputRoot, proposal.OutputRoot)
+	require.Equal(t, output.BlockRef.ID(), proposal.L2Block)
+	require.Equal(t, receipt.TxHash, proposal.TxHash)
+	require.EqualValues(t, 100, proposal.L1Block.Number)
+
+	// reverted proposals are not reported as the last proposal
+	go send()
+	<-txMgr.published
+	txMgr.include <- &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(101)}
+	require.Eventually(t, func() bool { return l.PendingTx() == nil }, time.Second, 10*time.Millisecond)
+	require.Equal(t, receipt.TxHash, l.LastProposal().TxHash)
+}
+
+// revertingTxMgr includes every tx as reverted.
+type revertingTxMgr struct {
+	sent int
+}
+
+func (m *revertingTxMgr) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m.sent++
+	return &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(1)}, nil
+}
+
+func (m *revertingTxMgr) From() common.Address {
+	return common.Address{}
+}
+
+func (m *revertingTxMgr) BlockNumber(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func TestL2OutputSubmitterBacksOffAfterRevertedGameCreation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	txMgr := &revertingTxMgr{}
+	l := newTestSubmitter(t, txMgr)
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	l.dgfContractAddr = &common.Address{0xdf}
+	l.dgfABI = dgfABI
+	l.proposalInterval = time.Hour
+
+	require.NoError(t, l.sendDGFTransaction(context.Background(), testutils.RandomOutputResponse(rng)))
+	require.Equal(t, 1, txMgr.sent)
+	require.Nil(t, l.LastProposal())
+
+	// The next game is only created after the proposal interval, the sync status isn't even fetched.
+	output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+	require.NoError(t, err)
+	require.False(t, shouldPropose)
+	require.Nil(t, output)
+}
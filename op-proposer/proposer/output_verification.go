@@ -0,0 +1,238 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrNoOutputQuorum = errors.New("rollup nodes do not agree on output")
+
+// RollupClient is the rollup node RPC that the proposer retrieves the sync status and outputs from.
+type RollupClient interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// outputAtBlock fetches the output at the given block from all rollup nodes. If at least outputQuorum
+// nodes agree on it, it returns the output of the first of them, preferring the primary rollup node.
+// Nodes that disagree with the majority are reported.
+func (l *L2OutputSubmitter) outputAtBlock(ctx context.Context, block uint64) (*eth.OutputResponse, error) {
+	if len(l.quorumRollupClients) == 0 {
+		return l.rollupClient.OutputAtBlock(ctx, block)
+	}
+	clients := append([]RollupClient{l.rollupClient}, l.quorumRollupClients...)
+	outputs := make([]*eth.OutputResponse, len(clients))
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client RollupClient) {
+			defer wg.Done()
+			outputs[i], errs[i] = client.OutputAtBlock(ctx, block)
+		}(i, client)
+	}
+	wg.Wait()
+
+	votes := make(map[eth.Bytes32]int)
+	first := make(map[eth.Bytes32]*eth.OutputResponse)
+	var best *eth.OutputResponse
+	for i, output := range outputs {
+		if errs[i] != nil {
+			l.log.Warn("failed to fetch output from rollup node", "node", i, "block", block, "err", errs[i])
+			continue
+		}
+		votes[output.OutputRoot]++
+		if first[output.OutputRoot] == nil {
+			first[output.OutputRoot] = output
+		}
+		if best == nil || votes[output.OutputRoot] > votes[best.OutputRoot] {
+			best = first[output.OutputRoot]
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("failed to fetch output at block %d from any rollup node: %w", block, errs[0])
+	}
+	for i, output := range outputs {
+		if errs[i] == nil && output.OutputRoot != best.OutputRoot {
+			l.log.Error("rollup node disagrees on output", "node", i, "block", block,
+				"output_root", output.OutputRoot, "majority_output_root", best.OutputRoot)
+			l.metr.RecordOutputDisagreement(metrics.RollupNodeSource)
+		}
+	}
+	if votes[best.OutputRoot] < l.outputQuorum {
+		return nil, fmt.Errorf("%w at block %d: %d of %d rollup nodes agree, quorum is %d",
+			ErrNoOutputQuorum, block, votes[best.OutputRoot], len(clients), l.outputQuorum)
+	}
+	return best, nil
+}
+
+// publishedOutput is an output root that was published on L1, by this or any other proposer.
+type publishedOutput struct {
+	// source is the metrics label of where the output was published
+	source        string
+	outputRoot    common.Hash
+	l2BlockNumber uint64
+	// game is the dispute game that claims the output, if any
+	game common.Address
+}
+
+// checkPublishedOutputs verifies the outputs that were published since the last check, in L2OutputOracle
+// proposals or dispute games of the proposer's game type, against the outputs of the rollup nodes.
+// Disagreements are reported through metrics and logs. Outputs are verified once their L2 block is safe,
+// starting with the latest output that was published before the proposer started. Outputs that can't be read
+// are skipped, if the read failed permanently or in maxOutputReadAttempts consecutive checks.
+func (l *L2OutputSubmitter) checkPublishedOutputs(ctx context.Context) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	status, err := l.rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		l.log.Warn("unable to get sync status to verify published outputs", "err", err)
+		return
+	}
+	var next *big.Int
+	if l.dgfContractAddr != nil {
+		next, err = l.dgfContract.GameCount(&bind.CallOpts{Context: cCtx})
+	} else {
+		next, err = l.l2ooContract.NextOutputIndex(&bind.CallOpts{Context: cCtx})
+	}
+	if err != nil {
+		l.log.Warn("unable to get the number of published outputs", "err", err)
+		return
+	}
+	if l.nextCheckedOutput == nil {
+		start := next.Uint64()
+		if start > 0 {
+			start-- // verify the latest output that was published before the start
+		}
+		l.nextCheckedOutput = &start
+	} else if next.Uint64() < *l.nextCheckedOutput {
+		// The L2OutputOracle challenger deleted outputs, the outputs that replace them are verified again.
+		l.log.Info("published outputs were deleted, verifying replaced outputs", "next_index", next, "next_checked_index", *l.nextCheckedOutput)
+		*l.nextCheckedOutput = next.Uint64()
+	}
+
+	for ; *l.nextCheckedOutput < next.Uint64(); *l.nextCheckedOutput++ {
+		index := *l.nextCheckedOutput
+		published, err := l.publishedOutputAt(ctx, index)
+		if err != nil {
+			l.outputReadFailures++
+			if !permanentCallError(err) && l.outputReadFailures < maxOutputReadAttempts {
+				l.log.Warn("unable to get published output", "index", index, "attempt", l.outputReadFailures, "err", err)
+				return
+			}
+			source := metrics.L2OutputOracleSource
+			if l.dgfContractAddr != nil {
+				source = metrics.DisputeGameSource
+			}
+			l.log.Error("skipping published output that can't be read", "source", source, "index", index,
+				"attempts", l.outputReadFailures, "err", err)
+			l.metr.RecordUnverifiedOutput(source)
+			l.outputReadFailures = 0
+			continue
+		}
+		l.outputReadFailures = 0
+		if published == nil {
+			continue
+		}
+		if published.l2BlockNumber > status.SafeL2.Number {
+			l.log.Debug("waiting for the safe head to verify published output", "index", index,
+				"l2blocknum", published.l2BlockNumber, "l2_safe", status.SafeL2.Number)
+			return
+		}
+		cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+		output, err := l.outputAtBlock(cCtx, published.l2BlockNumber)
+		cancel()
+		if err != nil {
+			l.log.Warn("unable to get output to verify published output", "index", index, "l2blocknum", published.l2BlockNumber, "err", err)
+			return
+		}
+		if common.Hash(output.OutputRoot) != published.outputRoot {
+			l.log.Error("published output disagrees with rollup nodes", "source", published.source, "index", index,
+				"game", published.game, "l2blocknum", published.l2BlockNumber,
+				"published_output_root", published.outputRoot, "output_root", output.OutputRoot)
+			l.metr.RecordOutputDisagreement(published.source)
+		} else {
+			l.log.Debug("verified published output", "source", published.source, "index", index, "l2blocknum", published.l2BlockNumber)
+		}
+	}
+}
+
+// maxOutputReadAttempts is the number of consecutive checks that fail to read a published output,
+// after which the output is skipped.
+const maxOutputReadAttempts = 5
+
+// permanentCallError returns whether a contract call failed in a way that retrying doesn't fix:
+// the contract has no code, the call reverted, or its result can't be decoded.
+func permanentCallError(err error) bool {
+	if errors.Is(err, bind.ErrNoCode) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "execution reverted") || strings.Contains(msg, "abi: ")
+}
+
+// publishedOutputAt returns the output of the L2OutputOracle proposal, or the dispute game, at the given index.
+// It returns nil if the dispute game is not of the proposer's game type, or its extra data doesn't start with
+// an L2 block number. It returns an error if the output, or the root claim or extra data of the game, can't be read.
+func (l *L2OutputSubmitter) publishedOutputAt(ctx context.Context, index uint64) (*publishedOutput, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	callOpts := &bind.CallOpts{Context: cCtx}
+	if l.dgfContractAddr == nil {
+		proposal, err := l.l2ooContract.GetL2Output(callOpts, new(big.Int).SetUint64(index))
+		if err != nil {
+			return nil, err
+		}
+		return &publishedOutput{
+			source:        metrics.L2OutputOracleSource,
+			outputRoot:    proposal.OutputRoot,
+			l2BlockNumber: proposal.L2BlockNumber.Uint64(),
+		}, nil
+	}
+
+	game, err := l.dgfContract.GameAtIndex(callOpts, new(big.Int).SetUint64(index))
+	if err != nil {
+		return nil, err
+	}
+	if game.GameType != l.gameType {
+		return nil, nil
+	}
+	caller, err := bindings.NewFaultDisputeGameCaller(game.Proxy, l.l1Client)
+	if err != nil {
+		return nil, err
+	}
+	rootClaim, err := caller.RootClaim(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root claim of dispute game %s: %w", game.Proxy, err)
+	}
+	extraData, err := caller.ExtraData(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extra data of dispute game %s: %w", game.Proxy, err)
+	}
+	if len(extraData) < common.HashLength {
+		l.log.Warn("dispute game extra data does not contain an L2 block number", "game", game.Proxy, "extra_data", extraData)
+		return nil, nil
+	}
+	l2BlockNumber := new(big.Int).SetBytes(extraData[:common.HashLength])
+	if !l2BlockNumber.IsUint64() {
+		l.log.Warn("dispute game L2 block number is out of range", "game", game.Proxy, "l2blocknum", l2BlockNumber)
+		return nil, nil
+	}
+	return &publishedOutput{
+		source:        metrics.DisputeGameSource,
+		outputRoot:    rootClaim,
+		l2BlockNumber: l2BlockNumber.Uint64(),
+		game:          game.Proxy,
+	}, nil
+}
@@ -0,0 +1,394 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeRollupClient struct {
+	output *eth.OutputResponse
+	err    error
+}
+
+func (f *fakeRollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return f.output.Status, f.err
+}
+
+func (f *fakeRollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	return f.output, f.err
+}
+
+type disagreementMetrics struct {
+	metrics.Metricer
+	sources    []string
+	unverified []string
+}
+
+func (m *disagreementMetrics) RecordOutputDisagreement(source string) {
+	m.sources = append(m.sources, source)
+}
+
+func (m *disagreementMetrics) RecordUnverifiedOutput(source string) {
+	m.unverified = append(m.unverified, source)
+}
+
+func TestOutputAtBlockQuorum(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	good := testutils.RandomOutputResponse(rng)
+	goodCopy := *good
+	bad := testutils.RandomOutputResponse(rng)
+
+	newSubmitter := func(quorum int, clients ...RollupClient) (*L2OutputSubmitter, *disagreementMetrics) {
+		m := &disagreementMetrics{Metricer: metrics.NoopMetrics}
+		return &L2OutputSubmitter{
+			log:                 testlog.Logger(t, log.LvlCrit),
+			metr:                m,
+			rollupClient:        clients[0],
+			quorumRollupClients: clients[1:],
+			outputQuorum:        quorum,
+		}, m
+	}
+
+	t.Run("agreement", func(t *testing.T) {
+		l, m := newSubmitter(3, &fakeRollupClient{output: good}, &fakeRollupClient{output: &goodCopy}, &fakeRollupClient{output: good})
+		output, err := l.outputAtBlock(context.Background(), 10)
+		require.NoError(t, err)
+		require.Same(t, good, output, "output of the primary rollup node")
+		require.Empty(t, m.sources)
+	})
+
+	t.Run("primary disagrees", func(t *testing.T) {
+		l, m := newSubmitter(2, &fakeRollupClient{output: bad}, &fakeRollupClient{output: &goodCopy}, &fakeRollupClient{output: good})
+		output, err := l.outputAtBlock(context.Background(), 10)
+		require.NoError(t, err)
+		require.Same(t, &goodCopy, output, "output of the first node of the majority")
+		require.Equal(t, []string{metrics.RollupNodeSource}, m.sources)
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		l, m := newSubmitter(2, &fakeRollupClient{output: good}, &fakeRollupClient{output: bad}, &fakeRollupClient{err: errors.New("offline")})
+		_, err := l.outputAtBlock(context.Background(), 10)
+		require.ErrorIs(t, err, ErrNoOutputQuorum)
+		require.Len(t, m.sources, 1)
+	})
+
+	t.Run("all offline", func(t *testing.T) {
+		offline := errors.New("offline")
+		l, _ := newSubmitter(1, &fakeRollupClient{err: offline}, &fakeRollupClient{err: offline})
+		_, err := l.outputAtBlock(context.Background(), 10)
+		require.ErrorIs(t, err, offline)
+	})
+}
+
+// fakeContract answers calls of the methods of a contract ABI with the given handlers.
+type fakeContract struct {
+	abi     *abi.ABI
+	methods map[string]func(args []interface{}) ([]interface{}, error)
+}
+
+// fakeL1 serves calls to fake contracts.
+type fakeL1 map[common.Address]*fakeContract
+
+func (f fakeL1) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if _, ok := f[contract]; ok {
+		return []byte{0x01}, nil
+	}
+	return nil, nil
+}
+
+func (f fakeL1) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c, ok := f[*call.To]
+	if !ok {
+		return nil, nil
+	}
+	method, err := c.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	handler, ok := c.methods[method.Name]
+	if !ok {
+		return nil, fmt.Errorf("execution reverted: no method %s", method.Name)
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	out, err := handler(args)
+	if err != nil {
+		return nil, err
+	}
+	return method.Outputs.Pack(out...)
+}
+
+// blockOutputsClient serves the output roots of L2 blocks, with the given sync status.
+type blockOutputsClient struct {
+	status *eth.SyncStatus
+	roots  map[uint64]eth.Bytes32
+}
+
+func (c *blockOutputsClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return c.status, nil
+}
+
+func (c *blockOutputsClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	root, ok := c.roots[blockNum]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return &eth.OutputResponse{OutputRoot: root, BlockRef: eth.L2BlockRef{Number: blockNum}, Status: c.status}, nil
+}
+
+func TestCheckPublishedOutputsL2OO(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	rollupClient := &blockOutputsClient{
+		status: &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: 45}},
+		roots:  make(map[uint64]eth.Bytes32),
+	}
+	var proposals []bindings.TypesOutputProposal
+	propose := func(l2BlockNumber uint64, correct bool) {
+		root := testutils.RandomHash(rng)
+		if correct {
+			rollupClient.roots[l2BlockNumber] = eth.Bytes32(root)
+		} else {
+			rollupClient.roots[l2BlockNumber] = eth.Bytes32(testutils.RandomHash(rng))
+		}
+		proposals = append(proposals, bindings.TypesOutputProposal{
+			OutputRoot:    root,
+			Timestamp:     new(big.Int),
+			L2BlockNumber: new(big.Int).SetUint64(l2BlockNumber),
+		})
+	}
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	l2ooAddr := common.Address{0x20}
+	l1 := fakeL1{l2ooAddr: {abi: l2ooABI, methods: map[string]func([]interface{}) ([]interface{}, error){
+		"nextOutputIndex": func([]interface{}) ([]interface{}, error) {
+			return []interface{}{big.NewInt(int64(len(proposals)))}, nil
+		},
+		"getL2Output": func(args []interface{}) ([]interface{}, error) {
+			return []interface{}{proposals[args[0].(*big.Int).Uint64()]}, nil
+		},
+	}}}
+	l2oo, err := bindings.NewL2OutputOracleCaller(l2ooAddr, l1)
+	require.NoError(t, err)
+	m := &disagreementMetrics{Metricer: metrics.NoopMetrics}
+	l := &L2OutputSubmitter{
+		log:            testlog.Logger(t, log.LvlCrit),
+		metr:           m,
+		l1Client:       l1,
+		rollupClient:   rollupClient,
+		l2ooContract:   l2oo,
+		networkTimeout: time.Second,
+	}
+
+	// Only the latest output that was published before the start is verified.
+	propose(10, false)
+	propose(20, false)
+	l.checkPublishedOutputs(context.Background())
+	require.Equal(t, []string{metrics.L2OutputOracleSource}, m.sources)
+	require.EqualValues(t, 2, *l.nextCheckedOutput)
+
+	// Outputs are verified once their L2 block is safe.
+	propose(30, true)
+	propose(50, true)
+	l.checkPublishedOutputs(context.Background())
+	require.Len(t, m.sources, 1)
+	require.EqualValues(t, 3, *l.nextCheckedOutput, "waiting for the safe head to reach the last output")
+
+	// The challenger deleted the last two outputs, the outputs that replace them are verified.
+	proposals = proposals[:2]
+	l.checkPublishedOutputs(context.Background())
+	require.EqualValues(t, 2, *l.nextCheckedOutput)
+	propose(30, false)
+	l.checkPublishedOutputs(context.Background())
+	require.Equal(t, []string{metrics.L2OutputOracleSource, metrics.L2OutputOracleSource}, m.sources)
+	require.EqualValues(t, 3, *l.nextCheckedOutput)
+}
+
+func TestPublishedOutputAtDGF(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	const gameType = 7
+	type game struct {
+		gameType  uint8
+		rootClaim common.Hash
+		extraData []byte
+		// err fails the calls of the game
+		err error
+	}
+	l2BlockData := func(n uint64) []byte {
+		return common.BigToHash(new(big.Int).SetUint64(n)).Bytes()
+	}
+	validClaim := testutils.RandomHash(rng)
+	readErr := errors.New("execution reverted")
+	games := []game{
+		{gameType: gameType + 1, rootClaim: testutils.RandomHash(rng), extraData: l2BlockData(10)},
+		{gameType: gameType, rootClaim: validClaim, extraData: append(l2BlockData(42), 0x01, 0x02)},
+		{gameType: gameType, rootClaim: testutils.RandomHash(rng), extraData: []byte{0x01}},
+		{gameType: gameType, rootClaim: testutils.RandomHash(rng), extraData: append([]byte{0x01}, make([]byte, 32)...)},
+		{gameType: gameType, err: readErr},
+	}
+
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	gameABI, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	dgfAddr := common.Address{0xdf}
+	l1 := fakeL1{dgfAddr: {abi: dgfABI, methods: map[string]func([]interface{}) ([]interface{}, error){
+		"gameAtIndex": func(args []interface{}) ([]interface{}, error) {
+			i := args[0].(*big.Int).Uint64()
+			return []interface{}{games[i].gameType, uint64(0), common.Address{0x10, byte(i)}}, nil
+		},
+	}}}
+	for i, g := range games {
+		g := g
+		l1[common.Address{0x10, byte(i)}] = &fakeContract{abi: gameABI, methods: map[string]func([]interface{}) ([]interface{}, error){
+			"rootClaim": func([]interface{}) ([]interface{}, error) { return []interface{}{g.rootClaim}, g.err },
+			"extraData": func([]interface{}) ([]interface{}, error) { return []interface{}{g.extraData}, g.err },
+		}}
+	}
+	dgf, err := bindings.NewDisputeGameFactoryCaller(dgfAddr, l1)
+	require.NoError(t, err)
+	l := &L2OutputSubmitter{
+		log:             testlog.Logger(t, log.LvlCrit),
+		l1Client:        l1,
+		dgfContract:     dgf,
+		dgfContractAddr: &dgfAddr,
+		gameType:        gameType,
+		networkTimeout:  time.Second,
+	}
+
+	published, err := l.publishedOutputAt(context.Background(), 0)
+	require.NoError(t, err)
+	require.Nil(t, published, "game of another type")
+
+	published, err = l.publishedOutputAt(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, &publishedOutput{
+		source:        metrics.DisputeGameSource,
+		outputRoot:    validClaim,
+		l2BlockNumber: 42,
+		game:          common.Address{0x10, 1},
+	}, published)
+
+	published, err = l.publishedOutputAt(context.Background(), 2)
+	require.NoError(t, err)
+	require.Nil(t, published, "extra data without L2 block number")
+
+	published, err = l.publishedOutputAt(context.Background(), 3)
+	require.NoError(t, err)
+	require.Nil(t, published, "L2 block number out of range")
+
+	_, err = l.publishedOutputAt(context.Background(), 4)
+	require.ErrorIs(t, err, readErr)
+}
+
+func TestCheckPublishedOutputsUnreadableGames(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	const gameType = 7
+	rollupClient := &blockOutputsClient{
+		status: &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: 100}},
+		roots:  make(map[uint64]eth.Bytes32),
+	}
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	gameABI, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+
+	// games are the addresses of the dispute games, by index
+	var games []common.Address
+	dgfAddr := common.Address{0xdf}
+	l1 := fakeL1{dgfAddr: {abi: dgfABI, methods: map[string]func([]interface{}) ([]interface{}, error){
+		"gameCount": func([]interface{}) ([]interface{}, error) {
+			return []interface{}{big.NewInt(int64(len(games)))}, nil
+		},
+		"gameAtIndex": func(args []interface{}) ([]interface{}, error) {
+			return []interface{}{uint8(gameType), uint64(0), games[args[0].(*big.Int).Uint64()]}, nil
+		},
+	}}}
+	// addGame adds a dispute game that fails its calls with the errors returned by fail,
+	// and otherwise claims an output at the given L2 block.
+	addGame := func(l2BlockNumber uint64, correct bool, fail func() error) {
+		addr := common.Address{0x10, byte(len(games))}
+		games = append(games, addr)
+		root := testutils.RandomHash(rng)
+		if correct {
+			rollupClient.roots[l2BlockNumber] = eth.Bytes32(root)
+		} else {
+			rollupClient.roots[l2BlockNumber] = eth.Bytes32(testutils.RandomHash(rng))
+		}
+		if fail == nil {
+			fail = func() error { return nil }
+		}
+		l1[addr] = &fakeContract{abi: gameABI, methods: map[string]func([]interface{}) ([]interface{}, error){
+			"rootClaim": func([]interface{}) ([]interface{}, error) { return []interface{}{root}, fail() },
+			"extraData": func([]interface{}) ([]interface{}, error) {
+				return []interface{}{common.BigToHash(new(big.Int).SetUint64(l2BlockNumber)).Bytes()}, fail()
+			},
+		}}
+	}
+	dgf, err := bindings.NewDisputeGameFactoryCaller(dgfAddr, l1)
+	require.NoError(t, err)
+	m := &disagreementMetrics{Metricer: metrics.NoopMetrics}
+	l := &L2OutputSubmitter{
+		log:             testlog.Logger(t, log.LvlCrit),
+		metr:            m,
+		l1Client:        l1,
+		rollupClient:    rollupClient,
+		dgfContract:     dgf,
+		dgfContractAddr: &dgfAddr,
+		gameType:        gameType,
+		networkTimeout:  time.Second,
+	}
+	l.checkPublishedOutputs(context.Background())
+	require.EqualValues(t, 0, *l.nextCheckedOutput)
+
+	// Games whose calls revert, or that have no code, are skipped right away.
+	addGame(10, true, func() error { return errors.New("execution reverted") })
+	games = append(games, common.Address{0xee})
+	// A game whose calls fail temporarily is verified once they succeed.
+	flaky := 1
+	addGame(20, false, func() error {
+		if flaky > 0 {
+			flaky--
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	l.checkPublishedOutputs(context.Background())
+	require.Equal(t, []string{metrics.DisputeGameSource, metrics.DisputeGameSource}, m.unverified)
+	require.EqualValues(t, 2, *l.nextCheckedOutput)
+	require.Empty(t, m.sources)
+
+	l.checkPublishedOutputs(context.Background())
+	require.Equal(t, []string{metrics.DisputeGameSource}, m.sources, "later game is verified")
+	require.EqualValues(t, 3, *l.nextCheckedOutput)
+
+	// A game whose calls keep failing is skipped after a bounded number of checks.
+	addGame(30, true, func() error { return errors.New("connection refused") })
+	addGame(40, false, nil)
+	for i := 1; i < maxOutputReadAttempts; i++ {
+		l.checkPublishedOutputs(context.Background())
+		require.EqualValues(t, 3, *l.nextCheckedOutput)
+	}
+	l.checkPublishedOutputs(context.Background())
+	require.Len(t, m.unverified, 3)
+	require.Equal(t, []string{metrics.DisputeGameSource, metrics.DisputeGameSource}, m.sources, "later game is verified")
+	require.EqualValues(t, 5, *l.nextCheckedOutput)
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+)
+
+type ProposerDriver interface {
+	StartL2OutputSubmitting() error
+	StopL2OutputSubmitting() error
+	Running() bool
+	LastProposal() *ProposalInfo
+	PendingTx() *PendingTxInfo
+	ProposeOutput(ctx context.Context, finalized bool) (*ProposalInfo, error)
+}
+
+// ProposalInfo describes an output proposal of the proposer that was included on L1.
+type ProposalInfo struct {
+	OutputRoot eth.Bytes32 `json:"output_root"`
+	// L2Block is the L2 block of the output.
+	L2Block eth.BlockID `json:"l2_block"`
+	TxHash  common.Hash `json:"tx_hash"`
+	// L1Block is the L1 block that includes the proposal tx.
+	L1Block eth.BlockID `json:"l1_block"`
+}
+
+// PendingTxInfo describes the output proposal tx in flight.
+type PendingTxInfo struct {
+	OutputRoot eth.Bytes32    `json:"output_root"`
+	L2Block    eth.BlockID    `json:"l2_block"`
+	Nonce      hexutil.Uint64 `json:"nonce"`
+	// TxHashes are the hashes of all published versions of the tx, the last one is the latest fee bump.
+	TxHashes []common.Hash `json:"tx_hashes"`
+}
+
+type adminAPI struct {
+	*rpc.CommonAdminAPI
+	p ProposerDriver
+}
+
+func NewAdminAPI(dr ProposerDriver, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+	return &adminAPI{
+		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
+		p:              dr,
+	}
+}
+
+func GetAdminAPI(api *adminAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "admin",
+		Service:   api,
+	}
+}
+
+func (a *adminAPI) StartProposer(_ context.Context) error {
+	return a.p.StartL2OutputSubmitting()
+}
+
+func (a *adminAPI) StopProposer(_ context.Context) error {
+	return a.p.StopL2OutputSubmitting()
+}
+
+// ProposerActive returns whether the proposer is proposing outputs on its own.
+func (a *adminAPI) ProposerActive(_ context.Context) (bool, error) {
+	return a.p.Running(), nil
+}
+
+// LastProposal returns the last output proposal that was included on L1, or null if there is none
+// since the start of the proposer.
+func (a *adminAPI) LastProposal(_ context.Context) (*ProposalInfo, error) {
+	return a.p.LastProposal(), nil
+}
+
+// PendingTx returns the output proposal tx in flight, or null if there is none.
+func (a *adminAPI) PendingTx(_ context.Context) (*PendingTxInfo, error) {
+	return a.p.PendingTx(), nil
+}
+
+// ProposeOutput immediately proposes the output of the latest "safe" or "finalized" L2 block, also when
+// the proposer is stopped. With an L2OutputOracle only the next checkpoint block can be proposed, so
+// it fails if that block is past the given head. It returns the proposal once it is included on L1.
+func (a *adminAPI) ProposeOutput(ctx context.Context, head string) (*ProposalInfo, error) {
+	switch head {
+	case "safe":
+		return a.p.ProposeOutput(ctx, false)
+	case "finalized":
+		return a.p.ProposeOutput(ctx, true)
+	default:
+		return nil, fmt.Errorf("unknown head %q, expected \"safe\" or \"finalized\"", head)
+	}
+}
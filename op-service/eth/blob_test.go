@@ -0,0 +1,91 @@
+package eth
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobEncodeDecode(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	sizes := []int{0, 1, 26, 27, 28, 31, 32, 127, 128, 1000, 4096, MaxBlobDataSize - 1, MaxBlobDataSize}
+	for _, size := range sizes {
+		data := make(Data, size)
+		rng.Read(data)
+
+		var b Blob
+		require.NoError(t, b.FromData(data))
+		for i := 0; i < BlobSize; i += 32 {
+			require.Zero(t, b[i]&0b1100_0000, "field element %d exceeds the modulus", i/32)
+		}
+		decoded, err := b.ToData()
+		require.NoError(t, err)
+		require.Equal(t, data, decoded, "size %d", size)
+	}
+}
+
+func TestBlobTooLarge(t *testing.T) {
+	var b Blob
+	require.ErrorIs(t, b.FromData(make(Data, MaxBlobDataSize+1)), ErrBlobInputTooLarge)
+}
+
+func TestBlobInvalidDecoding(t *testing.T) {
+	encode := func(t *testing.T) *Blob {
+		var b Blob
+		require.NoError(t, b.FromData(Data("this is a test of invalid blob decoding")))
+		return &b
+	}
+
+	t.Run("version", func(t *testing.T) {
+		b := encode(t)
+		b[VersionOffset] = 0x01
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidEncodingVersion)
+	})
+	t.Run("length", func(t *testing.T) {
+		b := encode(t)
+		b[2] = 0xFF
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidLength)
+	})
+	t.Run("field element", func(t *testing.T) {
+		b := encode(t)
+		b[32] = 0b1000_0000
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidFieldElement)
+	})
+	t.Run("extraneous data in field element", func(t *testing.T) {
+		b := encode(t)
+		b[100] = 0x01
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobExtraneousDataFieldElement)
+	})
+	t.Run("extraneous data", func(t *testing.T) {
+		b := encode(t)
+		b[BlobSize-1] = 0x01
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobExtraneousData)
+	})
+}
+
+func TestBlobSidecarJSON(t *testing.T) {
+	sidecar := &BlobSidecar{
+		BlockRoot:     Bytes32{0x01},
+		Slot:          42,
+		Index:         3,
+		KZGCommitment: Bytes48{0x02},
+		KZGProof:      Bytes48{0x03},
+	}
+	require.NoError(t, sidecar.Blob.FromData(Data("sidecar test data")))
+
+	enc, err := json.Marshal(sidecar)
+	require.NoError(t, err)
+	require.Contains(t, string(enc), `"slot":"42"`)
+	require.Contains(t, string(enc), `"index":"3"`)
+
+	var dec BlobSidecar
+	require.NoError(t, json.Unmarshal(enc, &dec))
+	require.Equal(t, sidecar, &dec)
+}
@@ -0,0 +1,54 @@
+package eth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	shutterKeyHeaderSize = 32 + 8 + 8
+	// MaxShutterKeySize limits the size of a shutter decryption key, which is 128 bytes at most in practice.
+	MaxShutterKeySize = 1024
+)
+
+// ShutterKey is the shutter decryption key the sequencer used to build the given block.
+type ShutterKey struct {
+	BlockHash   common.Hash    `json:"blockHash"`
+	BlockNumber Uint64Quantity `json:"blockNumber"`
+	Timestamp   Uint64Quantity `json:"timestamp"`
+	Key         hexutil.Bytes  `json:"key"`
+}
+
+func (k *ShutterKey) ID() BlockID {
+	return BlockID{Hash: k.BlockHash, Number: uint64(k.BlockNumber)}
+}
+
+// MarshalBinary encodes the key as block_hash ++ block_number ++ timestamp ++ key,
+// with the numbers as 8 byte big-endian integers.
+func (k *ShutterKey) MarshalBinary() ([]byte, error) {
+	if len(k.Key) > MaxShutterKeySize {
+		return nil, fmt.Errorf("shutter key of %d bytes exceeds max size %d", len(k.Key), MaxShutterKeySize)
+	}
+	out := make([]byte, shutterKeyHeaderSize, shutterKeyHeaderSize+len(k.Key))
+	copy(out[:32], k.BlockHash[:])
+	binary.BigEndian.PutUint64(out[32:40], uint64(k.BlockNumber))
+	binary.BigEndian.PutUint64(out[40:48], uint64(k.Timestamp))
+	return append(out, k.Key...), nil
+}
+
+func (k *ShutterKey) UnmarshalBinary(data []byte) error {
+	if len(data) < shutterKeyHeaderSize {
+		return fmt.Errorf("shutter key data of %d bytes is too short", len(data))
+	}
+	if len(data)-shutterKeyHeaderSize > MaxShutterKeySize {
+		return fmt.Errorf("shutter key of %d bytes exceeds max size %d", len(data)-shutterKeyHeaderSize, MaxShutterKeySize)
+	}
+	copy(k.BlockHash[:], data[:32])
+	k.BlockNumber = Uint64Quantity(binary.BigEndian.Uint64(data[32:40]))
+	k.Timestamp = Uint64Quantity(binary.BigEndian.Uint64(data[40:48]))
+	k.Key = append(hexutil.Bytes{}, data[shutterKeyHeaderSize:]...)
+	return nil
+}
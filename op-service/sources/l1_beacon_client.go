@@ -0,0 +1,174 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	versionMethod        = "eth/v1/node/version"
+	genesisMethod        = "eth/v1/beacon/genesis"
+	specMethod           = "eth/v1/config/spec"
+	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+)
+
+// TimeToSlotFn returns the beacon slot of the given L1 block timestamp.
+type TimeToSlotFn func(timestamp uint64) (uint64, error)
+
+// L1BeaconClient is a high level client for the beacon-node API,
+// used to retrieve the blobs that were included in L1 blocks.
+type L1BeaconClient struct {
+	cl client.HTTP
+
+	initLock     sync.Mutex
+	timeToSlotFn TimeToSlotFn
+}
+
+// NewL1BeaconClient returns a client for making requests to an L1 consensus layer node.
+func NewL1BeaconClient(cl client.HTTP) *L1BeaconClient {
+	return &L1BeaconClient{cl: cl}
+}
+
+func (cl *L1BeaconClient) apiReq(ctx context.Context, dest any, method string, query url.Values) error {
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	resp, err := cl.cl.Get(ctx, method, query, headers)
+	if err != nil {
+		return fmt.Errorf("http GET %s failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("failed request to %s: %w", method, ethereum.NotFound)
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed request to %s with status %d: %s", method, resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", method, err)
+	}
+	return nil
+}
+
+// GetVersion fetches the version of the beacon node.
+func (cl *L1BeaconClient) GetVersion(ctx context.Context) (string, error) {
+	var resp struct {
+		Data struct {
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+	if err := cl.apiReq(ctx, &resp, versionMethod, nil); err != nil {
+		return "", err
+	}
+	return resp.Data.Version, nil
+}
+
+// GetTimeToSlotFn returns a function that converts a timestamp to a slot number.
+// The genesis time and slot duration are fetched once and then cached.
+func (cl *L1BeaconClient) GetTimeToSlotFn(ctx context.Context) (TimeToSlotFn, error) {
+	cl.initLock.Lock()
+	defer cl.initLock.Unlock()
+	if cl.timeToSlotFn != nil {
+		return cl.timeToSlotFn, nil
+	}
+
+	var genesisResp eth.APIGenesisResponse
+	if err := cl.apiReq(ctx, &genesisResp, genesisMethod, nil); err != nil {
+		return nil, err
+	}
+	var configResp eth.APIConfigResponse
+	if err := cl.apiReq(ctx, &configResp, specMethod, nil); err != nil {
+		return nil, err
+	}
+
+	genesisTime := uint64(genesisResp.Data.GenesisTime)
+	secondsPerSlot := uint64(configResp.Data.SecondsPerSlot)
+	if secondsPerSlot == 0 {
+		return nil, errors.New("got bad value for seconds per slot: 0")
+	}
+	cl.timeToSlotFn = func(timestamp uint64) (uint64, error) {
+		if timestamp < genesisTime {
+			return 0, fmt.Errorf("provided timestamp (%v) precedes genesis time (%v)", timestamp, genesisTime)
+		}
+		return (timestamp - genesisTime) / secondsPerSlot, nil
+	}
+	return cl.timeToSlotFn, nil
+}
+
+// GetBlobSidecars fetches the blob sidecars of the given hashes that were
+// included in the given L1 block. The sidecars are returned in the order of the hashes.
+// The sidecars are not verified, see GetBlobs.
+func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	if len(hashes) == 0 {
+		return []*eth.BlobSidecar{}, nil
+	}
+	slotFn, err := cl.GetTimeToSlotFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time to slot function: %w", err)
+	}
+	slot, err := slotFn(ref.Time)
+	if err != nil {
+		return nil, fmt.Errorf("error in converting ref.Time to slot: %w", err)
+	}
+
+	indices := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		indices = append(indices, strconv.FormatUint(h.Index, 10))
+	}
+	query := url.Values{}
+	query.Set("indices", strings.Join(indices, ","))
+
+	var resp eth.APIGetBlobSidecarsResponse
+	if err := cl.apiReq(ctx, &resp, sidecarsMethodPrefix+strconv.FormatUint(slot, 10), query); err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars for slot %v block %v: %w", slot, ref, err)
+	}
+
+	byIndex := make(map[uint64]*eth.BlobSidecar, len(resp.Data))
+	for _, sidecar := range resp.Data {
+		byIndex[uint64(sidecar.Index)] = sidecar
+	}
+	sidecars := make([]*eth.BlobSidecar, 0, len(hashes))
+	for _, h := range hashes {
+		sidecar, ok := byIndex[h.Index]
+		if !ok {
+			return nil, fmt.Errorf("missing blob sidecar %v in slot %v block %v: %w", h.Index, slot, ref, ethereum.NotFound)
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+	return sidecars, nil
+}
+
+// GetBlobs fetches the blobs of the given hashes that were included in the given L1 block.
+// The blobs are returned in the order of the hashes. Each blob is verified against its
+// KZG commitment and proof, and the commitment against the versioned hash.
+func (cl *L1BeaconClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	sidecars, err := cl.GetBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, err
+	}
+	blobs := make([]*eth.Blob, 0, len(sidecars))
+	for i, sidecar := range sidecars {
+		commitment := kzg4844.Commitment(sidecar.KZGCommitment)
+		if h := eth.KZGToVersionedHash(commitment); h != hashes[i].Hash {
+			return nil, fmt.Errorf("expected hash %s for blob at index %d but got %s", hashes[i].Hash, hashes[i].Index, h)
+		}
+		if err := eth.VerifyBlobProof(&sidecar.Blob, commitment, kzg4844.Proof(sidecar.KZGProof)); err != nil {
+			return nil, fmt.Errorf("blob at index %d failed verification: %w", hashes[i].Index, err)
+		}
+		blobs = append(blobs, &sidecar.Blob)
+	}
+	return blobs, nil
+}
@@ -0,0 +1,153 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	fakeGenesisTime    = 1000
+	fakeSecondsPerSlot = 12
+)
+
+// fakeBeacon serves the subset of the beacon-node API used by the L1BeaconClient.
+type fakeBeacon struct {
+	sidecars map[uint64][]*eth.BlobSidecar // by slot
+}
+
+func (f *fakeBeacon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var resp any
+	switch path := strings.TrimPrefix(r.URL.Path, "/"); {
+	case path == genesisMethod:
+		resp = &eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: fakeGenesisTime}}
+	case path == specMethod:
+		resp = &eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: fakeSecondsPerSlot}}
+	case strings.HasPrefix(path, sidecarsMethodPrefix):
+		slot, err := strconv.ParseUint(strings.TrimPrefix(path, sidecarsMethodPrefix), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sidecars, ok := f.sidecars[slot]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		out := &eth.APIGetBlobSidecarsResponse{}
+		for _, idx := range strings.Split(r.URL.Query().Get("indices"), ",") {
+			i, err := strconv.ParseUint(idx, 10, 64)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			for _, sc := range sidecars {
+				if uint64(sc.Index) == i {
+					out.Data = append(out.Data, sc)
+				}
+			}
+		}
+		resp = out
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func makeTestSidecar(t *testing.T, index uint64, data string) (*eth.BlobSidecar, eth.IndexedBlobHash) {
+	t.Helper()
+	sidecar := &eth.BlobSidecar{Index: eth.Uint64String(index)}
+	require.NoError(t, sidecar.Blob.FromData(eth.Data(data)))
+	commitment, err := kzg4844.BlobToCommitment(*sidecar.Blob.KZGBlob())
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(*sidecar.Blob.KZGBlob(), commitment)
+	require.NoError(t, err)
+	sidecar.KZGCommitment = eth.Bytes48(commitment)
+	sidecar.KZGProof = eth.Bytes48(proof)
+	return sidecar, eth.IndexedBlobHash{Index: index, Hash: eth.KZGToVersionedHash(commitment)}
+}
+
+func TestL1BeaconClient(t *testing.T) {
+	sc0, h0 := makeTestSidecar(t, 0, "first blob")
+	sc1, h1 := makeTestSidecar(t, 1, "second blob")
+	sc2, h2 := makeTestSidecar(t, 2, "third blob")
+	// a sidecar with a commitment that does not match the blob
+	badProof, hBadProof := makeTestSidecar(t, 3, "bad proof")
+	badProof.KZGProof = sc0.KZGProof
+
+	beacon := &fakeBeacon{sidecars: map[uint64][]*eth.BlobSidecar{
+		10: {sc0, sc1, sc2, badProof},
+	}}
+	srv := httptest.NewServer(beacon)
+	t.Cleanup(srv.Close)
+	cl := NewL1BeaconClient(client.NewBasicHTTPClient(srv.URL, log.New()))
+	ctx := context.Background()
+	ref := eth.L1BlockRef{Number: 100, Time: fakeGenesisTime + 10*fakeSecondsPerSlot + 3}
+
+	t.Run("time to slot", func(t *testing.T) {
+		fn, err := cl.GetTimeToSlotFn(ctx)
+		require.NoError(t, err)
+		slot, err := fn(fakeGenesisTime + 25)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), slot)
+		_, err = fn(fakeGenesisTime - 1)
+		require.Error(t, err)
+	})
+
+	t.Run("blobs", func(t *testing.T) {
+		blobs, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{h2, h0})
+		require.NoError(t, err)
+		require.Len(t, blobs, 2)
+		data, err := blobs[0].ToData()
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("third blob"), data)
+		data, err = blobs[1].ToData()
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("first blob"), data)
+	})
+
+	t.Run("no hashes", func(t *testing.T) {
+		blobs, err := cl.GetBlobs(ctx, eth.L1BlockRef{}, nil)
+		require.NoError(t, err)
+		require.Empty(t, blobs)
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 1, Hash: h0.Hash}})
+		require.ErrorContains(t, err, "expected hash")
+		_, err = cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{h1})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{hBadProof})
+		require.ErrorContains(t, err, "failed verification")
+	})
+
+	t.Run("missing sidecar", func(t *testing.T) {
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 7, Hash: common.Hash{0x01}}})
+		require.True(t, errors.Is(err, ethereum.NotFound), "unexpected error: %v", err)
+	})
+
+	t.Run("missing slot", func(t *testing.T) {
+		next := eth.L1BlockRef{Number: 101, Time: ref.Time + fakeSecondsPerSlot}
+		_, err := cl.GetBlobs(ctx, next, []eth.IndexedBlobHash{h0})
+		require.True(t, errors.Is(err, ethereum.NotFound), "unexpected error: %v", err)
+	})
+}
@@ -20,6 +20,30 @@ type L1ClientConfig struct {
 	EthClientConfig
 
 	L1BlockRefsCacheSize int
+
+	// PrefetchDepth is the number of L1 blocks to prefetch the headers, transactions and receipts of,
+	// ahead of the latest block that was requested by number. Prefetching is disabled if set to 0.
+	PrefetchDepth uint64
+	// PrefetchConcurrency limits the number of concurrent block and receipts requests of the prefetcher.
+	PrefetchConcurrency int
+}
+
+func (c *L1ClientConfig) Check() error {
+	if err := c.EthClientConfig.Check(); err != nil {
+		return err
+	}
+	if c.PrefetchDepth == 0 {
+		return nil
+	}
+	if c.PrefetchConcurrency < 1 {
+		return fmt.Errorf("expected at least 1 concurrent prefetch request, but max is %d", c.PrefetchConcurrency)
+	}
+	// Prefetched data is held in the caches, it is evicted before use if the caches cannot hold the full depth.
+	minCacheSize := min(c.ReceiptsCacheSize, c.TransactionsCacheSize, c.HeadersCacheSize)
+	if c.PrefetchDepth > uint64(minCacheSize) {
+		return fmt.Errorf("prefetch depth %d exceeds the cache size %d", c.PrefetchDepth, minCacheSize)
+	}
+	return nil
 }
 
 func L1ClientDefaultConfig(config *rollup.Config, trustRPC bool, kind RPCProviderKind) *L1ClientConfig {
@@ -57,18 +81,30 @@ type L1Client struct {
 	// cache L1BlockRef by hash
 	// common.Hash -> eth.L1BlockRef
 	l1BlockRefsCache *caching.LRUCache[common.Hash, eth.L1BlockRef]
+
+	// prefetcher warms up the caches ahead of the blocks requested by number, nil if disabled.
+	prefetcher *l1Prefetcher
 }
 
 // NewL1Client wraps a RPC with bindings to fetch L1 data, while logging errors, tracking metrics (optional), and caching.
 func NewL1Client(client client.RPC, log log.Logger, metrics caching.Metrics, config *L1ClientConfig) (*L1Client, error) {
+	if err := config.Check(); err != nil {
+		return nil, fmt.Errorf("bad config, cannot create L1 source: %w", err)
+	}
 	ethClient, err := NewEthClient(client, log, metrics, &config.EthClientConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	var prefetcher *l1Prefetcher
+	if config.PrefetchDepth > 0 {
+		prefetcher = newL1Prefetcher(log, ethClient, config.PrefetchDepth, config.PrefetchConcurrency)
+	}
+
 	return &L1Client{
 		EthClient:        ethClient,
 		l1BlockRefsCache: caching.NewLRUCache[common.Hash, eth.L1BlockRef](metrics, "blockrefs", config.L1BlockRefsCacheSize),
+		prefetcher:       prefetcher,
 	}, nil
 }
 
@@ -91,6 +127,7 @@ func (s *L1Client) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel)
 
 // L1BlockRefByNumber returns an [eth.L1BlockRef] for the given block number.
 // Notice, we cannot cache a block reference by number because L1 re-orgs can invalidate the cached block reference.
+// If prefetching is enabled, the data of the blocks after the returned block is retrieved in the background.
 func (s *L1Client) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
 	info, err := s.InfoByNumber(ctx, num)
 	if err != nil {
@@ -98,6 +135,9 @@ func (s *L1Client) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1Bl
 	}
 	ref := eth.InfoToL1BlockRef(info)
 	s.l1BlockRefsCache.Add(ref.Hash, ref)
+	if s.prefetcher != nil {
+		s.prefetcher.Advance(ref)
+	}
 	return ref, nil
 }
 
@@ -115,3 +155,11 @@ func (s *L1Client) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.
 	s.l1BlockRefsCache.Add(ref.Hash, ref)
 	return ref, nil
 }
+
+// Close stops any L1 prefetching work, and closes the underlying RPC client.
+func (s *L1Client) Close() {
+	if s.prefetcher != nil {
+		s.prefetcher.Close()
+	}
+	s.EthClient.Close()
+}
@@ -0,0 +1,265 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+)
+
+// prefetchTimeout bounds a single prefetching round, so a stalled RPC cannot keep the prefetcher busy forever.
+const prefetchTimeout = time.Minute
+
+// l1Prefetcher fetches the headers, transactions and receipts of the L1 blocks following
+// the most recently requested L1 block, to warm up the caches of the EthClient ahead of the derivation pipeline.
+//
+// The prefetched blocks form a chain on top of the latest requested block: if the next requested
+// block does not match the prefetched chain, the L1 chain reorganized (or the pipeline was reset),
+// and any prefetching work in progress is abandoned to start over from the newly requested block.
+//
+// Memory usage is bounded by the EthClient cache sizes, which must fit the prefetch depth.
+// Concurrency is bounded by the number of concurrent header batches and receipts fetching jobs.
+type l1Prefetcher struct {
+	log    log.Logger
+	client *EthClient
+
+	depth       uint64
+	concurrency int
+
+	mu sync.Mutex
+	// anchor is the latest block that was requested, prefetching happens on top of it.
+	anchor eth.L1BlockRef
+	// prefetched is the chain of blocks after the anchor that headers and transactions were prefetched for.
+	prefetched []eth.L1BlockRef
+	// cancel aborts the prefetching round in progress, nil if no round is running.
+	cancel context.CancelFunc
+	// round identifies the current prefetching round, to ignore the results of abandoned rounds.
+	round uint64
+	// atTip is set when the last round ran into blocks that do not exist yet.
+	// Rounds then only look one block ahead, to not waste requests while following the L1 chain tip.
+	atTip bool
+
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func newL1Prefetcher(log log.Logger, client *EthClient, depth uint64, concurrency int) *l1Prefetcher {
+	return &l1Prefetcher{
+		log:         log,
+		client:      client,
+		depth:       depth,
+		concurrency: concurrency,
+	}
+}
+
+// Advance registers that the given block was requested, and continues prefetching the blocks after it.
+// This never blocks on RPC requests.
+func (p *l1Prefetcher) Advance(ref eth.L1BlockRef) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed || ref == p.anchor {
+		return
+	}
+	consumed := -1
+	for i, b := range p.prefetched {
+		if b.Number == ref.Number {
+			if b.Hash == ref.Hash {
+				consumed = i
+			}
+			break
+		}
+	}
+	switch {
+	case consumed >= 0:
+		p.prefetched = p.prefetched[consumed+1:]
+	case len(p.prefetched) == 0 && ref.ParentHash == p.anchor.Hash:
+		// The requested blocks progress while the first round is still in flight,
+		// the round results that were requested already are dropped when the round completes.
+	default:
+		// Either a reorg or a jump in the requested blocks: the prefetched chain does not apply anymore.
+		p.log.Debug("Restarting L1 prefetching", "previous", p.anchor, "requested", ref)
+		p.abandon()
+		p.prefetched = nil
+	}
+	p.anchor = ref
+	p.startRound()
+}
+
+// tip returns the block that prefetching continues from. Must be called with the lock held.
+func (p *l1Prefetcher) tip() eth.L1BlockRef {
+	if len(p.prefetched) > 0 {
+		return p.prefetched[len(p.prefetched)-1]
+	}
+	return p.anchor
+}
+
+// abandon cancels the prefetching round in progress, if any. Must be called with the lock held.
+func (p *l1Prefetcher) abandon() {
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+	p.round++
+}
+
+// startRound starts prefetching up to the prefetch depth, unless a round is in progress already,
+// or more than half of the prefetch depth is still available. Must be called with the lock held.
+func (p *l1Prefetcher) startRound() {
+	if p.cancel != nil || uint64(len(p.prefetched)) > p.depth/2 {
+		return
+	}
+	count := p.depth - uint64(len(p.prefetched))
+	if p.atTip {
+		count = 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), prefetchTimeout)
+	p.cancel = cancel
+	round := p.round
+	tip := p.tip()
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer cancel()
+		if err := p.prefetch(ctx, round, tip, count); err != nil && ctx.Err() == nil {
+			p.log.Warn("Failed to prefetch L1 blocks", "after", tip, "count", count, "err", err)
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.round == round {
+			p.cancel = nil
+		}
+	}()
+}
+
+// prefetch retrieves the count blocks after the tip by number, in batches, and verifies they form a chain.
+// The receipts of the blocks are then fetched with bounded concurrency.
+func (p *l1Prefetcher) prefetch(ctx context.Context, round uint64, tip eth.L1BlockRef, count uint64) error {
+	numbers := make([]uint64, count)
+	for i := range numbers {
+		numbers[i] = tip.Number + 1 + uint64(i)
+	}
+	fetcher := batching.NewIterativeBatchCall[uint64, *rpcBlock](
+		numbers,
+		makeBlockByNumberRequest,
+		p.client.client.BatchCallContext,
+		p.client.client.CallContext,
+		p.client.maxBatchSize,
+	)
+	var g errgroup.Group
+	for i := 0; i < p.concurrency; i++ {
+		g.Go(func() error {
+			for {
+				if err := fetcher.Fetch(ctx); err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+			}
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("failed to fetch blocks: %w", err)
+	}
+	blocks, err := fetcher.Result()
+	if err != nil {
+		return err
+	}
+
+	refs := make([]eth.L1BlockRef, 0, len(blocks))
+	parent := tip
+	atTip := false
+	for i, block := range blocks {
+		// Blocks that do not exist yet decode as empty result, we are at the L1 chain tip.
+		if block == nil || block.Hash == (common.Hash{}) {
+			atTip = true
+			break
+		}
+		info, txs, err := block.Info(p.client.trustRPC, p.client.mustBePostMerge)
+		if err != nil {
+			return fmt.Errorf("invalid block %d: %w", numbers[i], err)
+		}
+		ref := eth.InfoToL1BlockRef(info)
+		if ref.Number != numbers[i] {
+			return fmt.Errorf("expected block %d but got block %s", numbers[i], ref)
+		}
+		if ref.ParentHash != parent.Hash {
+			// The chain changed while prefetching, only keep the blocks that still connect.
+			p.log.Debug("Prefetched L1 block does not build on previous block", "block", ref, "parent", parent)
+			break
+		}
+		p.client.headersCache.Add(ref.Hash, info)
+		p.client.transactionsCache.Add(ref.Hash, txs)
+		refs = append(refs, ref)
+		parent = ref
+	}
+
+	p.mu.Lock()
+	if p.round != round {
+		p.mu.Unlock()
+		return nil
+	}
+	refs = p.publish(refs)
+	p.atTip = atTip
+	p.mu.Unlock()
+
+	var rg errgroup.Group
+	rg.SetLimit(p.concurrency)
+	for _, ref := range refs {
+		ref := ref
+		rg.Go(func() error {
+			if _, _, err := p.client.FetchReceipts(ctx, ref.Hash); err != nil {
+				return fmt.Errorf("failed to fetch receipts of block %s: %w", ref, err)
+			}
+			return nil
+		})
+	}
+	return rg.Wait()
+}
+
+// publish adds the prefetched blocks that were not requested yet to the prefetched chain,
+// and returns them. Must be called with the lock held.
+func (p *l1Prefetcher) publish(refs []eth.L1BlockRef) []eth.L1BlockRef {
+	for i, ref := range refs {
+		if ref.Number < p.anchor.Number {
+			continue
+		}
+		if ref.Number == p.anchor.Number {
+			if ref.Hash != p.anchor.Hash {
+				return nil
+			}
+			continue
+		}
+		refs = refs[i:]
+		p.prefetched = append(p.prefetched, refs...)
+		return refs
+	}
+	return nil
+}
+
+// Close stops any prefetching in progress, and waits for it to exit.
+func (p *l1Prefetcher) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.abandon()
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+func makeBlockByNumberRequest(num uint64) (*rpcBlock, rpc.BatchElem) {
+	out := new(rpcBlock)
+	return out, rpc.BatchElem{
+		Method: "eth_getBlockByNumber",
+		Args:   []any{hexutil.EncodeUint64(num), true},
+		Result: &out, // block may become nil, double pointer is intentional
+	}
+}
@@ -0,0 +1,338 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// l1ChainBackend serves a fixed L1 chain over the eth RPC namespace, and counts the requests it serves.
+type l1ChainBackend struct {
+	mu       sync.Mutex
+	byNumber map[uint64]*rpcBlock
+	byHash   map[common.Hash]*rpcBlock
+	receipts map[common.Hash][]*types.Receipt
+
+	blockRequests    atomic.Uint64
+	receiptsRequests atomic.Uint64
+}
+
+func newL1ChainBackend() *l1ChainBackend {
+	return &l1ChainBackend{
+		byNumber: make(map[uint64]*rpcBlock),
+		byHash:   make(map[common.Hash]*rpcBlock),
+		receipts: make(map[common.Hash][]*types.Receipt),
+	}
+}
+
+// Extend adds count random blocks with txCount transactions each on top of the given parent,
+// replacing any blocks at the same height.
+func (b *l1ChainBackend) Extend(rng *rand.Rand, parent eth.BlockID, count int, txCount uint64) []eth.L1BlockRef {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	refs := make([]eth.L1BlockRef, 0, count)
+	for i := 0; i < count; i++ {
+		block, receipts := randomChildBlock(rng, parent, txCount)
+		rpcBlock := rpcBlockFromBlock(block)
+		b.byNumber[block.NumberU64()] = rpcBlock
+		b.byHash[block.Hash()] = rpcBlock
+		b.receipts[block.Hash()] = receipts
+		ref := eth.L1BlockRef{Hash: block.Hash(), Number: block.NumberU64(), ParentHash: block.ParentHash(), Time: block.Time()}
+		refs = append(refs, ref)
+		parent = ref.ID()
+	}
+	return refs
+}
+
+func (b *l1ChainBackend) GetBlockByNumber(num hexutil.Uint64, fullTxs bool) (*rpcBlock, error) {
+	b.blockRequests.Add(1)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.byNumber[uint64(num)], nil
+}
+
+func (b *l1ChainBackend) GetBlockByHash(hash common.Hash, fullTxs bool) (*rpcBlock, error) {
+	b.blockRequests.Add(1)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.byHash[hash], nil
+}
+
+func (b *l1ChainBackend) GetBlockReceipts(hash common.Hash) ([]*types.Receipt, error) {
+	b.receiptsRequests.Add(1)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	receipts, ok := b.receipts[hash]
+	if !ok {
+		return nil, fmt.Errorf("unknown block %s", hash)
+	}
+	return receipts, nil
+}
+
+func randomChildBlock(rng *rand.Rand, parent eth.BlockID, txCount uint64) (*types.Block, []*types.Receipt) {
+	block, receipts := testutils.RandomBlock(rng, txCount)
+	header := block.Header()
+	header.ParentHash = parent.Hash
+	header.Number = new(big.Int).SetUint64(parent.Number + 1)
+	block = block.WithSeal(header)
+	for _, r := range receipts {
+		r.BlockHash = block.Hash()
+		r.BlockNumber = block.Number()
+		for _, l := range r.Logs {
+			l.BlockHash = block.Hash()
+			l.BlockNumber = block.NumberU64()
+		}
+	}
+	return block, receipts
+}
+
+// latencyRPC adds a fixed latency to every request, to simulate the round-trip to a remote L1 RPC.
+type latencyRPC struct {
+	client.RPC
+	latency time.Duration
+}
+
+func (l *latencyRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	time.Sleep(l.latency)
+	return l.RPC.CallContext(ctx, result, method, args...)
+}
+
+func (l *latencyRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	time.Sleep(l.latency)
+	return l.RPC.BatchCallContext(ctx, b)
+}
+
+func testL1ClientConfig(prefetchDepth uint64) *L1ClientConfig {
+	return &L1ClientConfig{
+		EthClientConfig: EthClientConfig{
+			ReceiptsCacheSize:     100,
+			TransactionsCacheSize: 100,
+			HeadersCacheSize:      100,
+			PayloadsCacheSize:     100,
+			MaxRequestsPerBatch:   20,
+			MaxConcurrentRequests: 10,
+			TrustRPC:              false,
+			MustBePostMerge:       false,
+			RPCProviderKind:       RPCKindStandard,
+			MethodResetDuration:   time.Minute,
+		},
+		L1BlockRefsCacheSize: 100,
+		PrefetchDepth:        prefetchDepth,
+		PrefetchConcurrency:  4,
+	}
+}
+
+func newTestL1Client(t testing.TB, backend *l1ChainBackend, latency time.Duration, cfg *L1ClientConfig) *L1Client {
+	srv := rpc.NewServer()
+	t.Cleanup(srv.Stop)
+	require.NoError(t, srv.RegisterName("eth", backend))
+	var rpcClient client.RPC = client.NewBaseRPCClient(rpc.DialInProc(srv))
+	if latency > 0 {
+		rpcClient = &latencyRPC{RPC: rpcClient, latency: latency}
+	}
+	cl, err := NewL1Client(rpcClient, testlog.Logger(t, log.LvlError), nil, cfg)
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+	return cl
+}
+
+func prefetchedBlocks(cl *L1Client) []eth.L1BlockRef {
+	cl.prefetcher.mu.Lock()
+	defer cl.prefetcher.mu.Unlock()
+	return append([]eth.L1BlockRef(nil), cl.prefetcher.prefetched...)
+}
+
+func prefetchIdle(cl *L1Client) bool {
+	cl.prefetcher.mu.Lock()
+	defer cl.prefetcher.mu.Unlock()
+	return cl.prefetcher.cancel == nil
+}
+
+func TestL1ClientConfig_Check(t *testing.T) {
+	require.NoError(t, testL1ClientConfig(0).Check())
+	require.NoError(t, testL1ClientConfig(100).Check())
+
+	cfg := testL1ClientConfig(101)
+	require.ErrorContains(t, cfg.Check(), "exceeds the cache size")
+
+	cfg = testL1ClientConfig(10)
+	cfg.PrefetchConcurrency = 0
+	require.ErrorContains(t, cfg.Check(), "concurrent prefetch request")
+
+	// concurrency is not used when prefetching is disabled
+	cfg = testL1ClientConfig(0)
+	cfg.PrefetchConcurrency = 0
+	require.NoError(t, cfg.Check())
+}
+
+func TestL1Prefetcher_Prefetch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	backend := newL1ChainBackend()
+	chain := backend.Extend(rng, eth.BlockID{Number: 99}, 20, 5)
+	cl := newTestL1Client(t, backend, 0, testL1ClientConfig(8))
+
+	ref, err := cl.L1BlockRefByNumber(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, chain[0], ref)
+
+	require.Eventually(t, func() bool {
+		return prefetchIdle(cl) && backend.receiptsRequests.Load() == 8
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, chain[1:9], prefetchedBlocks(cl))
+
+	// The transactions and receipts of the prefetched blocks are served from the cache
+	blockRequests := backend.blockRequests.Load()
+	for _, expected := range chain[1:9] {
+		_, txs, err := cl.InfoAndTxsByHash(context.Background(), expected.Hash)
+		require.NoError(t, err)
+		require.Len(t, txs, 5)
+		info, receipts, err := cl.FetchReceipts(context.Background(), expected.Hash)
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash, info.Hash())
+		require.Len(t, receipts, 5)
+	}
+	require.Equal(t, blockRequests, backend.blockRequests.Load())
+	require.Equal(t, uint64(8), backend.receiptsRequests.Load())
+
+	// Requesting the prefetched blocks does not prefetch again until half the prefetched blocks are consumed
+	for _, expected := range chain[1:4] {
+		ref, err := cl.L1BlockRefByNumber(context.Background(), expected.Number)
+		require.NoError(t, err)
+		require.Equal(t, expected, ref)
+	}
+	require.True(t, prefetchIdle(cl))
+	require.Equal(t, chain[4:9], prefetchedBlocks(cl))
+
+	ref, err = cl.L1BlockRefByNumber(context.Background(), chain[4].Number)
+	require.NoError(t, err)
+	require.Equal(t, chain[4], ref)
+	require.Eventually(t, func() bool {
+		return prefetchIdle(cl) && backend.receiptsRequests.Load() == 12
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, chain[5:13], prefetchedBlocks(cl))
+}
+
+func TestL1Prefetcher_Reorg(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	backend := newL1ChainBackend()
+	chain := backend.Extend(rng, eth.BlockID{Number: 99}, 20, 2)
+	cl := newTestL1Client(t, backend, 0, testL1ClientConfig(8))
+
+	_, err := cl.L1BlockRefByNumber(context.Background(), 100)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return prefetchIdle(cl) && len(prefetchedBlocks(cl)) == 8
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Reorg the chain after block 102
+	fork := backend.Extend(rng, chain[2].ID(), 17, 2)
+	for _, expected := range []eth.L1BlockRef{chain[1], chain[2], fork[0]} {
+		ref, err := cl.L1BlockRefByNumber(context.Background(), expected.Number)
+		require.NoError(t, err)
+		require.Equal(t, expected, ref)
+	}
+	require.Eventually(t, func() bool {
+		return prefetchIdle(cl)
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, fork[1:9], prefetchedBlocks(cl))
+
+	// The receipts of the fork are prefetched
+	receiptsRequests := backend.receiptsRequests.Load()
+	for _, expected := range fork[1:9] {
+		info, _, err := cl.FetchReceipts(context.Background(), expected.Hash)
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash, info.Hash())
+	}
+	require.Equal(t, receiptsRequests, backend.receiptsRequests.Load())
+}
+
+func TestL1Prefetcher_ChainTip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	backend := newL1ChainBackend()
+	chain := backend.Extend(rng, eth.BlockID{Number: 99}, 4, 2)
+	cl := newTestL1Client(t, backend, 0, testL1ClientConfig(8))
+
+	_, err := cl.L1BlockRefByNumber(context.Background(), 100)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return prefetchIdle(cl)
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, chain[1:], prefetchedBlocks(cl))
+
+	// Following the chain tip only looks ahead a single block
+	next := backend.Extend(rng, chain[3].ID(), 2, 2)
+	for _, expected := range chain[1:] {
+		_, err := cl.L1BlockRefByNumber(context.Background(), expected.Number)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return prefetchIdle(cl)
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	blockRequests := backend.blockRequests.Load()
+	_, err = cl.L1BlockRefByNumber(context.Background(), next[0].Number)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return prefetchIdle(cl)
+	}, 10*time.Second, 10*time.Millisecond)
+	// one request for the requested block, and a single block prefetched
+	require.Equal(t, blockRequests+2, backend.blockRequests.Load())
+	require.Equal(t, next[1:], prefetchedBlocks(cl))
+}
+
+// BenchmarkL1Traversal walks a randomly generated L1 chain, of numBlocks blocks with txCount txs each,
+// the way the derivation pipeline does during catch-up: block by block, retrieving the header by number,
+// and then the transactions and receipts by hash. Every RPC request to the in-memory backend has a fixed
+// latency, so the results show the request latency that prefetching hides, not the sync-time on a real chain.
+func BenchmarkL1Traversal(b *testing.B) {
+	const (
+		numBlocks = 100
+		txCount   = 20
+		latency   = 2 * time.Millisecond
+	)
+	rng := rand.New(rand.NewSource(1234))
+	backend := newL1ChainBackend()
+	chain := backend.Extend(rng, eth.BlockID{Number: 999}, numBlocks, txCount)
+
+	for _, depth := range []uint64{0, 8, 32, 64} {
+		depth := depth
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				cl := newTestL1Client(b, backend, latency, testL1ClientConfig(depth))
+				b.StartTimer()
+				for _, expected := range chain {
+					ref, err := cl.L1BlockRefByNumber(context.Background(), expected.Number)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if _, _, err := cl.FetchReceipts(context.Background(), ref.Hash); err != nil {
+						b.Fatal(err)
+					}
+					if _, _, err := cl.InfoAndTxsByHash(context.Background(), ref.Hash); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.StopTimer()
+				cl.Close()
+			}
+			b.ReportMetric(float64(numBlocks*b.N)/b.Elapsed().Seconds(), "blocks/s")
+		})
+	}
+}
@@ -189,6 +189,10 @@ func (tc *ReceiptsTestCase) Run(t *testing.T) {
 
 func randomRpcBlockAndReceipts(rng *rand.Rand, txCount uint64) (*rpcBlock, []*types.Receipt) {
 	block, receipts := testutils.RandomBlock(rng, txCount)
+	return rpcBlockFromBlock(block), receipts
+}
+
+func rpcBlockFromBlock(block *types.Block) *rpcBlock {
 	return &rpcBlock{
 		rpcHeader: rpcHeader{
 			ParentHash:  block.ParentHash(),
@@ -210,7 +214,7 @@ func randomRpcBlockAndReceipts(rng *rand.Rand, txCount uint64) (*rpcBlock, []*ty
 			Hash:        block.Hash(),
 		},
 		Transactions: block.Transactions(),
-	}, receipts
+	}
 }
 
 func TestEthClient_FetchReceipts(t *testing.T) {
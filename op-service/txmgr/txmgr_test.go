@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
 )
 
 type sendTransactionFunc func(ctx context.Context, tx *types.Transaction) error
@@ -157,6 +159,9 @@ type mockBackend struct {
 
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
+
+	// excessBlobGas of the head, nil if blob txs are not supported
+	excessBlobGas *uint64
 }
 
 // newMockBackend initializes a new mockBackend.
@@ -203,7 +208,8 @@ func (b *mockBackend) CallContract(ctx context.Context, call ethereum.CallMsg, b
 
 func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.g.basefee(),
+		BaseFee:       b.g.basefee(),
+		ExcessBlobGas: b.excessBlobGas,
 	}, nil
 }
 
@@ -287,7 +293,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -315,7 +321,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -344,12 +350,52 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
 }
 
+// TestTxMgrOnPublish asserts that the publish hook is called with every fee
+// bumped version of a tx, before it is published.
+func TestTxMgrOnPublish(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     7,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	var published []*types.Transaction
+	onPublish := func(tx *types.Transaction) {
+		published = append(published, tx)
+	}
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		require.Equal(t, tx.Hash(), published[len(published)-1].Hash(), "tx must be passed to hook before publishing")
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.sendTx(ctx, tx, onPublish)
+	require.Nil(t, err)
+	require.NotNil(t, receipt)
+	require.Greater(t, len(published), 1, "fee bumped txs must be passed to hook")
+	for _, ptx := range published {
+		require.Equal(t, uint64(7), ptx.Nonce())
+	}
+	require.Equal(t, published[len(published)-1].Hash(), receipt.TxHash)
+}
+
 // errRpcFailure is a sentinel error used in testing to fail publications.
 var errRpcFailure = errors.New("rpc failure")
 
@@ -375,7 +421,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -403,6 +449,43 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftBlobTx ensures that the tx manager creates blob transactions
+// for candidates with blobs, and only if L1 supports them.
+func TestTxMgr_CraftBlobTx(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data("blob tx test data")))
+	candidate.Blobs = []*eth.Blob{&blob}
+
+	// The head has no excess blob gas, so L1 does not support blob txs yet.
+	_, err := h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorContains(t, err, "blob base fee")
+
+	excessBlobGas := uint64(0)
+	h.backend.excessBlobGas = &excessBlobGas
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+	require.Equal(t, *candidate.To, *tx.To())
+	require.Equal(t, candidate.GasLimit, tx.Gas())
+	// the blob base fee is 1 wei without excess blob gas, so the min blob fee applies
+	require.Equal(t, MinBlobTxFee, tx.BlobGasFeeCap())
+
+	sidecar := tx.BlobTxSidecar()
+	require.NotNil(t, sidecar)
+	require.Len(t, sidecar.Blobs, 1)
+	require.Equal(t, *blob.KZGBlob(), sidecar.Blobs[0])
+	require.Equal(t, []common.Hash{eth.KZGToVersionedHash(sidecar.Commitments[0])}, tx.BlobHashes())
+	require.NoError(t, eth.VerifyBlobProof(&blob, sidecar.Commitments[0], sidecar.Proofs[0]))
+
+	// blob txs can't create contracts
+	candidate.To = nil
+	_, err = h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorContains(t, err, "cannot deploy contracts")
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -512,7 +595,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -547,7 +630,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -592,7 +675,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -692,6 +775,7 @@ type failingBackend struct {
 	returnSuccessBlockNumber bool
 	returnSuccessReceipt     bool
 	baseFee, gasTip          *big.Int
+	excessBlobGas            *uint64
 }
 
 // BlockNumber for the failingBackend returns errRpcFailure on the first
@@ -723,7 +807,8 @@ func (b *failingBackend) TransactionReceipt(
 
 func (b *failingBackend) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.baseFee,
+		BaseFee:       b.baseFee,
+		ExcessBlobGas: b.excessBlobGas,
 	}, nil
 }
 
@@ -885,6 +970,64 @@ func TestIncreaseGasPrice(t *testing.T) {
 	}
 }
 
+// TestIncreaseBlobGasPrice asserts that blob txs are bumped by 100%,
+// including the blob fee cap, and keep their blobs.
+func TestIncreaseBlobGasPrice(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, int64(100), blobPriceBump, "test must be updated if blobPriceBump is adjusted")
+
+	excessBlobGas := uint64(0)
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			FeeLimitMultiplier: 5,
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+		},
+		name: "TEST",
+		backend: &failingBackend{
+			gasTip:        big.NewInt(100),
+			baseFee:       big.NewInt(1000),
+			excessBlobGas: &excessBlobGas,
+		},
+		l:    testlog.Logger(t, log.LvlCrit),
+		metr: &metrics.NoopTxMetrics{},
+	}
+
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data("blob tx test data")))
+	sidecar, hashes, err := MakeSidecar([]*eth.Blob{&blob})
+	require.NoError(t, err)
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		Nonce:      7,
+		To:         common.Address{0x42},
+		GasTipCap:  uint256.NewInt(100),
+		GasFeeCap:  uint256.NewInt(2100),
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.MustFromBig(MinBlobTxFee),
+		BlobHashes: hashes,
+		Sidecar:    sidecar,
+	})
+
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), newTx.Type())
+	require.Equal(t, tx.Nonce(), newTx.Nonce())
+	require.Equal(t, big.NewInt(200), newTx.GasTipCap())
+	require.Equal(t, big.NewInt(4200), newTx.GasFeeCap())
+	require.Equal(t, new(big.Int).Mul(MinBlobTxFee, big.NewInt(2)), newTx.BlobGasFeeCap())
+	require.Equal(t, hashes, newTx.BlobHashes())
+	require.Equal(t, sidecar, newTx.BlobTxSidecar())
+
+	// the blob fee cap is limited by the fee limit multiplier
+	for err == nil {
+		tx = newTx
+		newTx, err = mgr.increaseGasPrice(context.Background(), tx)
+	}
+	require.ErrorContains(t, err, "over 5x multiple")
+}
+
 // TestIncreaseGasPriceNotExponential asserts that if the L1 basefee & tip remain the
 // same, repeated calls to IncreaseGasPrice do not continually increase the gas price.
 func TestIncreaseGasPriceNotExponential(t *testing.T) {
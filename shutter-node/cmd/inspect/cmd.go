@@ -0,0 +1,166 @@
+package inspect
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gorm.io/gorm"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/shutter-node/database"
+	"github.com/ethereum-optimism/optimism/shutter-node/flags"
+	"github.com/ethereum-optimism/optimism/shutter-node/inspect"
+)
+
+var (
+	formatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: fmt.Sprintf("Output format. Available formats: %s", strings.Join(formatNames(), ", ")),
+		Value: string(inspect.FormatText),
+	}
+	outputFlag = &cli.PathFlag{
+		Name:  "output",
+		Usage: "Path to the output file. Writes to stdout if not set.",
+	}
+	fromBlockFlag = &cli.UintFlag{
+		Name:  "from-block",
+		Usage: "First L2 block to inspect",
+		Value: 0,
+	}
+	toBlockFlag = &cli.UintFlag{
+		Name:  "to-block",
+		Usage: "Last L2 block to inspect",
+		Value: math.MaxUint32,
+	}
+
+	commonFlags = []cli.Flag{
+		flags.DatabasePathFlag,
+		formatFlag,
+		outputFlag,
+	}
+	rangeFlags = append([]cli.Flag{fromBlockFlag, toBlockFlag}, commonFlags...)
+)
+
+func formatNames() []string {
+	names := make([]string, 0, len(inspect.Formats))
+	for _, f := range inspect.Formats {
+		names = append(names, string(f))
+	}
+	return names
+}
+
+var Subcommands = []*cli.Command{
+	{
+		Name:  "eons",
+		Usage: "Prints the eon history",
+		Flags: commonFlags,
+		Action: inspectAction(func(ctx *cli.Context, db *gorm.DB) (inspect.Table, error) {
+			return inspect.GetEonHistory(db)
+		}),
+	},
+	{
+		Name:  "keypers",
+		Usage: "Prints the keyper set membership over time",
+		Flags: commonFlags,
+		Action: inspectAction(func(ctx *cli.Context, db *gorm.DB) (inspect.Table, error) {
+			return inspect.GetKeyperMemberships(db)
+		}),
+	},
+	{
+		Name:  "active",
+		Usage: "Prints the block ranges in which shutter was paused or unpaused",
+		Flags: commonFlags,
+		Action: inspectAction(func(ctx *cli.Context, db *gorm.DB) (inspect.Table, error) {
+			return inspect.GetActiveRanges(db)
+		}),
+	},
+	{
+		Name:  "missing-keys",
+		Usage: "Prints the blocks in which shutter was active, but no decryption key was received",
+		Flags: rangeFlags,
+		Action: inspectAction(func(ctx *cli.Context, db *gorm.DB) (inspect.Table, error) {
+			from, to, err := blockRange(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return inspect.GetMissingKeys(db, from, to)
+		}),
+	},
+	{
+		Name:  "key-lateness",
+		Usage: "Prints the arrival time of the decryption keys relative to the timestamp of the block they decrypt",
+		Flags: rangeFlags,
+		Action: inspectAction(func(ctx *cli.Context, db *gorm.DB) (inspect.Table, error) {
+			from, to, err := blockRange(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return inspect.GetKeyLatenesses(db, from, to)
+		}),
+	},
+	{
+		Name:  "key-slo",
+		Usage: "Prints the key availability per eon: the lateness percentiles and the number of late and missing keys",
+		Flags: rangeFlags,
+		Action: inspectAction(func(ctx *cli.Context, db *gorm.DB) (inspect.Table, error) {
+			from, to, err := blockRange(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return inspect.GetKeySLOs(db, from, to)
+		}),
+	},
+}
+
+func blockRange(ctx *cli.Context) (uint, uint, error) {
+	from := ctx.Uint(fromBlockFlag.Name)
+	to := ctx.Uint(toBlockFlag.Name)
+	if from > to {
+		return 0, 0, fmt.Errorf("from-block %d is after to-block %d", from, to)
+	}
+	return from, to, nil
+}
+
+type tableFn func(ctx *cli.Context, db *gorm.DB) (inspect.Table, error)
+
+func inspectAction(fn tableFn) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		logCfg := oplog.ReadCLIConfig(ctx)
+		logger := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
+
+		format, err := inspect.ParseFormat(ctx.String(formatFlag.Name))
+		if err != nil {
+			return err
+		}
+		path := ctx.String(flags.DatabasePathFlag.Name)
+		// don't let the inspection create a new, empty database
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("database %q not readable: %w", path, err)
+		}
+		db := &database.Database{}
+		if err := db.Connect(path); err != nil {
+			return fmt.Errorf("failed to open the database: %w", err)
+		}
+		defer db.Close()
+
+		table, err := fn(ctx, db.Session(ctx.Context, logger))
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if p := ctx.Path(outputFlag.Name); p != "" {
+			f, err := os.Create(p)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		return inspect.Write(out, format, table)
+	}
+}
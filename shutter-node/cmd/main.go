@@ -12,9 +12,13 @@ import (
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 	shutternode "github.com/ethereum-optimism/optimism/shutter-node"
+	"github.com/ethereum-optimism/optimism/shutter-node/cmd/inspect"
+	"github.com/ethereum-optimism/optimism/shutter-node/devkeyper"
 	"github.com/ethereum-optimism/optimism/shutter-node/flags"
+	"github.com/ethereum-optimism/optimism/shutter-node/metrics"
 	"github.com/ethereum-optimism/optimism/shutter-node/node"
 	"github.com/ethereum-optimism/optimism/shutter-node/version"
 )
@@ -39,7 +43,24 @@ func main() {
 	app.Usage = "Shutter decryption key listener node"
 	app.Description = ""
 	app.Action = cliapp.LifecycleCmd(ShutterNodeMain)
-	// app.Commands = []*cli.Command{}
+	app.Commands = []*cli.Command{
+		{
+			Name:        "devkeyper",
+			Usage:       "Serve deterministic decryption keys without a keyper network",
+			Description: "Generates an eon key from a seed, registers it in the local database and serves the derived decryption key of every block on the decryption key gRPC API. Only meant for local development.",
+			Flags:       cliapp.ProtectFlags(flags.DevKeyperFlags),
+			Action:      cliapp.LifecycleCmd(DevKeyperMain),
+		},
+		{
+			Name:        "inspect",
+			Usage:       "Inspect the shutter state recorded in the database",
+			Subcommands: inspect.Subcommands,
+		},
+		{
+			Name:        "doc",
+			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
+		},
+	}
 	ctx := opio.WithInterruptBlocker(context.Background())
 	err := app.RunContext(ctx, os.Args)
 	if err != nil {
@@ -66,3 +87,21 @@ func ShutterNodeMain(ctx *cli.Context, closeApp context.CancelCauseFunc) (cliapp
 
 	return n, nil
 }
+
+func DevKeyperMain(ctx *cli.Context, closeApp context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+	logCfg := oplog.ReadCLIConfig(ctx)
+	log := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
+	oplog.SetGlobalLogHandler(log.GetHandler())
+	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.DevKeyperFlags, log)
+
+	cfg, err := shutternode.NewDevKeyperConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the dev keyper config: %w", err)
+	}
+
+	d, err := devkeyper.New(ctx.Context, cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the dev keyper: %w", err)
+	}
+	return d, nil
+}
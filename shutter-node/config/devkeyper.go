@@ -0,0 +1,31 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DevKeyperConfig configures the deterministic development
+// keyper that replaces the keyper network in local setups.
+type DevKeyperConfig struct {
+	Seed            int64
+	Eon             uint
+	ActivationBlock uint
+
+	GRPC     GRPCConfig
+	Database DatabaseConfig
+}
+
+// Check verifies that the given configuration makes sense
+func (cfg *DevKeyperConfig) Check() error {
+	if cfg.Database.FilePath == "" {
+		return errors.New("no database path provided")
+	}
+	if err := cfg.Database.Check(); err != nil {
+		return fmt.Errorf("database config error: %w", err)
+	}
+	if err := cfg.GRPC.Check(); err != nil {
+		return fmt.Errorf("gRPC config error: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/shutter-node/keys/identity"
 	"github.com/shutter-network/shutter/shlib/shcrypto"
 )
@@ -29,6 +31,28 @@ type Epoch struct {
 	// is required to be included as reveal-tx
 	// when shutter is active
 	Block uint `gorm:"index:,unique,composite:eonblock"`
+
+	// ReceivedAt is the local time the key arrived via gossip.
+	// It is nil for keys that were not received from
+	// the keyper network.
+	ReceivedAt *time.Time
+	// BlockTimestamp is the timestamp of the L2 block 'Block'.
+	// This is the deadline for the key to arrive, since
+	// the sequencer has to include the reveal-tx in that block.
+	BlockTimestamp *uint64
+}
+
+// Lateness returns the arrival time of the key relative
+// to the timestamp of the block it decrypts.
+// A positive lateness means the key missed the deadline.
+// The second return value is false if the arrival
+// time or block timestamp are not known.
+func (k *Epoch) Lateness() (time.Duration, bool) {
+	if k.ReceivedAt == nil || k.BlockTimestamp == nil {
+		return 0, false
+	}
+	deadline := time.Unix(int64(*k.BlockTimestamp), 0)
+	return k.ReceivedAt.Sub(deadline), true
 }
 
 func (k *Epoch) ModelVersion() uint {
@@ -65,3 +65,62 @@ func GetEpochForInclusion(db *gorm.DB, atBlock uint, index uint) (*models.Epoch,
 	db = db.Where("eon_index = ?", index)
 	return getObjByColumn(db, new(models.Epoch), "block", atBlock)
 }
+
+// GetEons returns all eons with their keypers,
+// ordered by activation block.
+func GetEons(db *gorm.DB) ([]*models.Eon, error) {
+	eons := []*models.Eon{}
+	res := db.Preload("Keypers").Order("activation_block ASC, eon_index ASC").Find(&eons)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return eons, nil
+}
+
+// GetPubKeys returns all known eon public keys.
+func GetPubKeys(db *gorm.DB) ([]*models.PublicKey, error) {
+	pks := []*models.PublicKey{}
+	res := db.Order("eon_index ASC").Find(&pks)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return pks, nil
+}
+
+// GetActiveUpdates returns all paused / unpaused updates
+// ordered by the block they take effect in.
+func GetActiveUpdates(db *gorm.DB) ([]*models.ActiveUpdate, error) {
+	updates := []*models.ActiveUpdate{}
+	res := db.Order("block ASC, id ASC").Find(&updates)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return updates, nil
+}
+
+// GetStates returns the states with their eon for all
+// blocks in the inclusive range [from, to].
+func GetStates(db *gorm.DB, from, to uint) ([]*models.State, error) {
+	states := []*models.State{}
+	res := db.Preload("Eon").
+		Where("block >= ? AND block <= ?", from, to).
+		Order("block ASC").
+		Find(&states)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return states, nil
+}
+
+// GetEpochs returns the epochs that are relevant for inclusion
+// in the blocks of the inclusive range [from, to].
+func GetEpochs(db *gorm.DB, from, to uint) ([]*models.Epoch, error) {
+	epochs := []*models.Epoch{}
+	res := db.Where("block >= ? AND block <= ?", from, to).
+		Order("block ASC").
+		Find(&epochs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return epochs, nil
+}
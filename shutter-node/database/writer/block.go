@@ -2,6 +2,7 @@ package writer
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/shutter-node/database/models"
 	"github.com/ethereum-optimism/optimism/shutter-node/database/query"
@@ -54,6 +55,7 @@ func (w *DBWriter) handleLatestBlock(lb *syncevent.LatestBlock) error {
 		return errors.Wrap(err, "convert event")
 	}
 	w.log.Info("handle new l2 unsafe head", "block-number", newState.Block)
+	var missedKey bool
 	err = w.db.Transaction(func(tx *gorm.DB) error {
 		latest, err := query.GetLatestBlock(tx)
 		if err != nil {
@@ -132,6 +134,10 @@ func (w *DBWriter) handleLatestBlock(lb *syncevent.LatestBlock) error {
 		if result.Error != nil {
 			return errors.Wrap(result.Error, "insert new block")
 		}
+		missedKey, err = w.isKeyMissed(tx, newState)
+		if err != nil {
+			return errors.Wrap(err, "check key availability")
+		}
 		return nil
 	})
 
@@ -146,6 +152,47 @@ func (w *DBWriter) handleLatestBlock(lb *syncevent.LatestBlock) error {
 			"block-number", newState.Block,
 			"shutter-active", newState.Active,
 		)
+		if missedKey {
+			w.log.Warn("decryption-key did not arrive before the block's deadline",
+				"eon-index", eonIndex,
+				"block-number", newState.Block,
+			)
+			w.metrics.RecordMissedKey(newState.Eon.EonIndex)
+		}
 	}
 	return err
 }
+
+// isKeyMissed checks wether the decryption-key for the newly
+// inserted state's block did not arrive before the block's deadline.
+// Since the state is inserted for the new unsafe head, the sequencer
+// already had to include the key in the block.
+// Blocks with a deadline before the writer was started are not
+// considered, since keys are not received retroactively.
+// NOTE: After a reorg, the same block number can be checked again.
+func (w *DBWriter) isKeyMissed(tx *gorm.DB, state *models.State) (bool, error) {
+	if w.blockTimestamp == nil || !state.Active || state.Eon == nil {
+		return false, nil
+	}
+	deadline := time.Unix(int64(w.blockTimestamp(uint64(state.Block))), 0)
+	if deadline.Before(w.startTime) {
+		return false, nil
+	}
+	// shutter is considered inactive without an eon public-key
+	pk, err := query.GetPubKey(tx, state.Eon.EonIndex)
+	if err != nil {
+		return false, errors.Wrap(err, "query public key")
+	}
+	if pk == nil || pk.Key == nil {
+		return false, nil
+	}
+	epoch, err := query.GetEpochForInclusion(tx, state.Block, state.Eon.EonIndex)
+	if err != nil {
+		return false, errors.Wrap(err, "query epoch")
+	}
+	if epoch == nil {
+		return true, nil
+	}
+	lateness, known := epoch.Lateness()
+	return known && lateness > 0, nil
+}
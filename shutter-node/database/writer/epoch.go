@@ -8,6 +8,10 @@ import (
 )
 
 func (w *DBWriter) handleNewEpoch(epoch *models.Epoch) error {
+	if epoch.ReceivedAt != nil && w.blockTimestamp != nil {
+		ts := w.blockTimestamp(uint64(epoch.Block))
+		epoch.BlockTimestamp = &ts
+	}
 	var duplicate bool
 	err := w.db.Transaction(func(tx *gorm.DB) error {
 		epochResult := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&epoch)
@@ -23,9 +27,16 @@ func (w *DBWriter) handleNewEpoch(epoch *models.Epoch) error {
 		return err
 	}
 	if !duplicate {
+		lateness, known := epoch.Lateness()
+		// only the first arrival of a key counts,
+		// multiple keypers can broadcast the same key
+		if known {
+			w.metrics.RecordKeyLateness(epoch.EonIndex, lateness)
+		}
 		w.log.Info("decryption-key inserted into db",
 			"reveal-block", epoch.Block,
 			"eon-index", epoch.EonIndex,
+			"lateness", lateness,
 		)
 	} else {
 		w.log.Info("handled duplicate decryption-key, not inserted into db",
@@ -1,14 +1,22 @@
 package writer
 
+import (
+	"github.com/ethereum-optimism/optimism/shutter-node/metrics"
+)
+
 type options struct {
-	unitTesting bool
+	unitTesting    bool
+	metrics        metrics.Metricer
+	blockTimestamp func(block uint64) uint64
 }
 
 type Option func(*options) error
 
 func defaultOptions() *options {
 	return &options{
-		unitTesting: false,
+		unitTesting:    false,
+		metrics:        metrics.NoopMetrics,
+		blockTimestamp: nil,
 	}
 }
 
@@ -28,3 +36,24 @@ func UnitTesting() Option {
 		return nil
 	}
 }
+
+// WithMetrics sets the metricer that records the
+// key availability of the received decryption keys.
+func WithMetrics(m metrics.Metricer) Option {
+	return func(o *options) error {
+		o.metrics = m
+		return nil
+	}
+}
+
+// WithBlockTimestamps sets the function that derives the timestamp
+// of an L2 block from its number.
+// The block timestamp is the deadline for the arrival of
+// the block's decryption key. Without it, the lateness
+// of the keys is not tracked.
+func WithBlockTimestamps(fn func(block uint64) uint64) Option {
+	return func(o *options) error {
+		o.blockTimestamp = fn
+		return nil
+	}
+}
@@ -3,12 +3,14 @@ package writer
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/shutter-node/database"
 	"github.com/ethereum-optimism/optimism/shutter-node/database/models"
 	"github.com/ethereum-optimism/optimism/shutter-node/database/query"
+	"github.com/ethereum-optimism/optimism/shutter-node/metrics"
 	syncclient "github.com/shutter-network/rolling-shutter/rolling-shutter/medley/chainsync"
 	syncevent "github.com/shutter-network/rolling-shutter/rolling-shutter/medley/chainsync/event"
 	"github.com/shutter-network/rolling-shutter/rolling-shutter/medley/encodeable/number"
@@ -24,6 +26,7 @@ func NewDBWriter(url string, logger log.Logger, db *database.Database, opts ...O
 		log:       logger,
 		url:       url,
 		database:  db,
+		metrics:   metrics.NoopMetrics,
 		eventChan: make(chan any),
 	}
 }
@@ -41,6 +44,12 @@ type DBWriter struct {
 	db       *gorm.DB
 	client   *syncclient.Client
 
+	metrics        metrics.Metricer
+	blockTimestamp func(block uint64) uint64
+	// keys for blocks before the start time
+	// can't have been received by the writer
+	startTime time.Time
+
 	eventChan chan any
 }
 
@@ -60,6 +69,9 @@ func (w *DBWriter) Init(ctx context.Context) error {
 	if err := opts.apply(w.options...); err != nil {
 		return err
 	}
+	w.metrics = opts.metrics
+	w.blockTimestamp = opts.blockTimestamp
+	w.startTime = time.Now()
 	var syncStartBlock *uint64 = nil
 	err := w.db.Transaction(func(tx *gorm.DB) error {
 		latest, err := query.GetLatestBlock(tx)
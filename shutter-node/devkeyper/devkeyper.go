@@ -0,0 +1,166 @@
+package devkeyper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/config"
+	"github.com/ethereum-optimism/optimism/shutter-node/database"
+	"github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/server"
+	"github.com/ethereum-optimism/optimism/shutter-node/keys"
+	service "github.com/shutter-network/rolling-shutter/rolling-shutter/medley/service"
+)
+
+// DevKeyper serves deterministically derived decryption keys
+// on the DecryptionKeyService gRPC API.
+// It does not follow the L2 chain and does not participate
+// in the keyper p2p network, so it is only meant to be used
+// in local development setups.
+type DevKeyper struct {
+	log    log.Logger
+	keyper *Keyper
+	db     *database.Database
+	grpc   *server.Server
+
+	errgrp *errgroup.Group
+	stop   context.CancelFunc
+
+	closed atomic.Bool
+}
+
+func New(ctx context.Context, cfg *config.DevKeyperConfig, log log.Logger) (*DevKeyper, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	keyper, err := NewKeyper(cfg.Seed, cfg.Eon, cfg.ActivationBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the dev keyper: %w", err)
+	}
+	d := &DevKeyper{
+		log:    log,
+		keyper: keyper,
+	}
+	if err := d.init(ctx, cfg); err != nil {
+		if d.db != nil {
+			if closeErr := d.db.Close(); closeErr != nil {
+				return nil, multierror.Append(err, closeErr)
+			}
+		}
+		return nil, err
+	}
+	d.log.Info("initialised dev keyper",
+		"eon", keyper.Eon(),
+		"activation-block", keyper.ActivationBlock(),
+		"keyper", keyper.Address(),
+		"eon-public-key", hexutil.Bytes(keyper.EonPublicKey().Marshal()),
+	)
+	return d, nil
+}
+
+func (d *DevKeyper) init(ctx context.Context, cfg *config.DevKeyperConfig) error {
+	db := &database.Database{}
+	if err := db.Connect(cfg.Database.FilePath); err != nil {
+		return fmt.Errorf("failed to init the database: %w", err)
+	}
+	d.db = db
+	if err := d.keyper.Register(db.Session(ctx, d.log)); err != nil {
+		return fmt.Errorf("failed to register the eon key: %w", err)
+	}
+	grpc, err := server.NewServer(
+		d.RequestDecryptionKey,
+		server.WithLogger(d.log),
+		server.WithListenAddress(cfg.GRPC.ListenNetwork, cfg.GRPC.ListenAddress),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to open grpc server: %w", err)
+	}
+	d.grpc = grpc
+	return nil
+}
+
+// RequestDecryptionKey implements keys.RequestDecryptionKey.
+// The key is derived immediately, so the returned promise
+// is always already filled.
+func (d *DevKeyper) RequestDecryptionKey(ctx context.Context, block uint) (<-chan *keys.KeyRequestResult, keys.CancelRequest) {
+	promise := make(chan *keys.KeyRequestResult, 1)
+	promise <- d.decryptionKey(d.db.Session(ctx, d.log), block)
+	close(promise)
+	return promise, func(error) {}
+}
+
+func (d *DevKeyper) decryptionKey(db *gorm.DB, block uint) *keys.KeyRequestResult {
+	if block < d.keyper.ActivationBlock() {
+		return &keys.KeyRequestResult{
+			Block: block,
+			Error: keys.ErrNotActive,
+		}
+	}
+	epoch, err := d.keyper.Epoch(block)
+	if err != nil {
+		return &keys.KeyRequestResult{
+			Block: block,
+			Error: err,
+		}
+	}
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(epoch)
+	if res.Error != nil {
+		// the key is valid even if it can't be persisted
+		d.log.Error("couldn't persist epoch", "block", block, "error", res.Error)
+	}
+	d.log.Info("derived decryption key", "block", block, "eon-index", epoch.EonIndex)
+	return &keys.KeyRequestResult{
+		Block:     block,
+		SecretKey: epoch.SecretKey,
+	}
+}
+
+func (d *DevKeyper) Start(ctx context.Context) error {
+	// the gRPC server is stopped by canceling the
+	// context, not by the start context
+	runCtx, stop := context.WithCancel(context.Background())
+	d.stop = stop
+	errgrp, teardown := service.RunBackground(runCtx, d.grpc)
+	d.errgrp = errgrp
+	go func() {
+		defer teardown()
+		err := d.errgrp.Wait()
+		if err != nil && !errors.Is(err, context.Canceled) {
+			d.log.Error("errgroup wait returned", "error", err)
+		}
+	}()
+	d.log.Info("dev keyper started")
+	return nil
+}
+
+// Stop stops the gRPC server and closes the database.
+func (d *DevKeyper) Stop(ctx context.Context) error {
+	if d.closed.Load() {
+		return errors.New("dev keyper is already closed")
+	}
+	var result *multierror.Error
+	if d.stop != nil {
+		d.stop()
+		if err := d.errgrp.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+			result = multierror.Append(result, err)
+		}
+	}
+	if err := d.db.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	d.closed.Store(true)
+	d.log.Info("dev keyper stopped")
+	return result.ErrorOrNil()
+}
+
+func (d *DevKeyper) Stopped() bool {
+	return d.closed.Load()
+}
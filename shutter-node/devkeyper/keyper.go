@@ -0,0 +1,145 @@
+package devkeyper
+
+import (
+	"math/big"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/shutter-network/shutter/shlib/shcrypto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/database/models"
+	"github.com/ethereum-optimism/optimism/shutter-node/database/query"
+	"github.com/ethereum-optimism/optimism/shutter-node/keys/identity"
+)
+
+var ErrEonKeyMismatch = errors.New("database already contains a different public key for the eon")
+
+// Keyper is a single keyper with a threshold of 1.
+// All key material is derived from the seed, so that
+// the same seed always results in the same eon key and the
+// same epoch secret keys.
+type Keyper struct {
+	eon             uint
+	activationBlock uint
+	address         common.Address
+
+	eonSecretKeyShare *shcrypto.EonSecretKeyShare
+	eonPublicKey      *shcrypto.EonPublicKey
+}
+
+func NewKeyper(seed int64, eon, activationBlock uint) (*Keyper, error) {
+	rng := rand.New(rand.NewSource(seed))
+	// a single keyper with threshold 1 only needs a
+	// polynomial of degree 0
+	poly, err := shcrypto.RandomPolynomial(rng, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate polynomial")
+	}
+	k := &Keyper{
+		eon:               eon,
+		activationBlock:   activationBlock,
+		eonSecretKeyShare: shcrypto.ComputeEonSecretKeyShare([]*big.Int{poly.EvalForKeyper(0)}),
+		eonPublicKey:      shcrypto.ComputeEonPublicKey([]*shcrypto.Gammas{poly.Gammas()}),
+	}
+	if _, err := rng.Read(k.address[:]); err != nil {
+		return nil, errors.Wrap(err, "generate keyper address")
+	}
+	return k, nil
+}
+
+func (k *Keyper) Eon() uint {
+	return k.eon
+}
+
+func (k *Keyper) ActivationBlock() uint {
+	return k.activationBlock
+}
+
+func (k *Keyper) Address() common.Address {
+	return k.address
+}
+
+func (k *Keyper) EonPublicKey() *shcrypto.EonPublicKey {
+	return k.eonPublicKey
+}
+
+// EpochSecretKey derives the epoch secret key that has to be
+// revealed in block 'block'.
+func (k *Keyper) EpochSecretKey(block uint) (*shcrypto.EpochSecretKey, error) {
+	preim := identity.BlockNumberToPreimage(uint64(block))
+	epochID := shcrypto.ComputeEpochID(preim)
+	share := shcrypto.ComputeEpochSecretKeyShare(k.eonSecretKeyShare, epochID)
+	return shcrypto.ComputeEpochSecretKey(
+		[]int{0},
+		[]*shcrypto.EpochSecretKeyShare{share},
+		1,
+	)
+}
+
+// Epoch derives the epoch secret key for block 'block'
+// and returns it as a database model.
+func (k *Keyper) Epoch(block uint) (*models.Epoch, error) {
+	key, err := k.EpochSecretKey(block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "derive epoch secret key for block %d", block)
+	}
+	preim := identity.BlockNumberToPreimage(uint64(block))
+	return &models.Epoch{
+		Metadata: models.Metadata{
+			InsertBlock: block,
+		},
+		EonIndex:  k.eon,
+		Identity:  &preim,
+		SecretKey: key,
+		Block:     block,
+	}, nil
+}
+
+// Register writes the keyper set and the eon public key of
+// the keyper to the database.
+// Registering is idempotent, but it fails if the database
+// already knows a different public key for the same eon.
+func (k *Keyper) Register(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		pk, err := query.GetPubKey(tx, k.eon)
+		if err != nil {
+			return errors.Wrap(err, "query eon public key")
+		}
+		if pk != nil {
+			if pk.Key == nil || !pk.Key.Equal(k.eonPublicKey) {
+				return ErrEonKeyMismatch
+			}
+			return nil
+		}
+
+		keyper := &models.Keyper{
+			Address: k.address,
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(keyper)
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "create keyper")
+		}
+		eon := &models.Eon{
+			EonIndex:        k.eon,
+			IsFinalized:     true,
+			ActivationBlock: uint64(k.activationBlock),
+			Threshold:       1,
+			Keypers:         []*models.Keyper{keyper},
+		}
+		res = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(eon)
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "create eon")
+		}
+		res = tx.Create(&models.PublicKey{
+			EonIndex: k.eon,
+			Key:      k.eonPublicKey,
+		})
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "create eon public key")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,80 @@
+package devkeyper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/shutter-network/shutter/shlib/shcrypto"
+	"gotest.tools/assert"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/database"
+	"github.com/ethereum-optimism/optimism/shutter-node/database/query"
+	"github.com/ethereum-optimism/optimism/shutter-node/keys/identity"
+)
+
+func TestKeyperDeterministic(t *testing.T) {
+	k1, err := NewKeyper(42, 1, 0)
+	assert.NilError(t, err)
+	k2, err := NewKeyper(42, 1, 0)
+	assert.NilError(t, err)
+	k3, err := NewKeyper(43, 1, 0)
+	assert.NilError(t, err)
+
+	assert.Assert(t, k1.EonPublicKey().Equal(k2.EonPublicKey()))
+	assert.Equal(t, k1.Address(), k2.Address())
+	assert.Assert(t, !k1.EonPublicKey().Equal(k3.EonPublicKey()))
+
+	for block := uint(0); block < 5; block++ {
+		sk1, err := k1.EpochSecretKey(block)
+		assert.NilError(t, err)
+		sk2, err := k2.EpochSecretKey(block)
+		assert.NilError(t, err)
+		assert.Assert(t, sk1.Equal(sk2))
+
+		preim := identity.BlockNumberToPreimage(uint64(block))
+		ok, err := shcrypto.VerifyEpochSecretKey(sk1, k1.EonPublicKey(), preim)
+		assert.NilError(t, err)
+		assert.Assert(t, ok, "epoch secret key for block %d not valid", block)
+	}
+}
+
+func TestKeyperRegister(t *testing.T) {
+	path, err := os.MkdirTemp("", "test-shutter-node-devkeyper-*")
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+	db := &database.Database{}
+	assert.NilError(t, db.Connect(path+"/db"))
+	t.Cleanup(func() {
+		assert.NilError(t, db.Close())
+	})
+	session := db.Session(context.Background(), log.New())
+
+	k, err := NewKeyper(42, 1, 3)
+	assert.NilError(t, err)
+	assert.NilError(t, k.Register(session))
+	// registering the same key again is a noop
+	assert.NilError(t, k.Register(session))
+
+	eon, err := query.GetEonByIndex(session, 1)
+	assert.NilError(t, err)
+	assert.Assert(t, eon != nil)
+	assert.Equal(t, eon.ActivationBlock, uint64(3))
+	assert.Equal(t, eon.Threshold, uint64(1))
+	assert.Equal(t, len(eon.Keypers), 1)
+	assert.Equal(t, eon.Keypers[0].Address, k.Address())
+
+	pk, err := query.GetPubKey(session, 1)
+	assert.NilError(t, err)
+	assert.Assert(t, pk != nil)
+	assert.Assert(t, pk.Key.Equal(k.EonPublicKey()))
+
+	other, err := NewKeyper(43, 1, 3)
+	assert.NilError(t, err)
+	err = other.Register(session)
+	assert.Assert(t, errors.Is(err, ErrEonKeyMismatch))
+}
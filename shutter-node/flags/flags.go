@@ -134,6 +134,25 @@ var (
 		Usage:   "Load protocol versions from the superchain L1 ProtocolVersions contract (if available), and report in logs and metrics",
 		EnvVars: prefixEnvVars("ROLLUP_LOAD_PROTOCOL_VERSIONS"),
 	}
+	/* Dev-Keyper Flags */
+	DevKeyperSeedFlag = &cli.Int64Flag{
+		Name:    "devkeyper.seed",
+		Usage:   "Seed the eon key and all decryption keys are deterministically derived from",
+		Value:   1,
+		EnvVars: prefixEnvVars("DEVKEYPER_SEED"),
+	}
+	DevKeyperEonFlag = &cli.UintFlag{
+		Name:    "devkeyper.eon",
+		Usage:   "Eon index the generated eon key is registered for",
+		Value:   1,
+		EnvVars: prefixEnvVars("DEVKEYPER_EON"),
+	}
+	DevKeyperActivationBlockFlag = &cli.UintFlag{
+		Name:    "devkeyper.activation-block",
+		Usage:   "First L2 block decryption keys are served for. Shutter is reported as inactive before that block.",
+		Value:   0,
+		EnvVars: prefixEnvVars("DEVKEYPER_ACTIVATION_BLOCK"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -161,12 +180,26 @@ var optionalFlags = []cli.Flag{
 	DatabasePathFlag,
 }
 
+var devKeyperFlags = []cli.Flag{
+	DevKeyperSeedFlag,
+	DevKeyperEonFlag,
+	DevKeyperActivationBlockFlag,
+	GRPCListenAddressFlag,
+	GRPCListenNetworkFlag,
+	DatabasePathFlag,
+}
+
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
+// DevKeyperFlags contains the list of configuration options available to the
+// devkeyper command.
+var DevKeyperFlags []cli.Flag
+
 func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	Flags = append(requiredFlags, optionalFlags...)
+	DevKeyperFlags = append(devKeyperFlags, oplog.CLIFlags(EnvVarPrefix)...)
 }
 
 func CheckRequired(ctx *cli.Context) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	grpc "github.com/ethereum-optimism/optimism/shutter-node/grpc/v1"
+	"github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/errs"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/pkg/errors"
 	"github.com/shutter-network/shutter/shlib/shcrypto"
@@ -79,7 +80,9 @@ func (c *Client) waitState(ctx context.Context) bool {
 	}
 }
 
-// Returns a promise
+// GetKey requests the decryption key for block 'block'.
+// Failed requests return one of the typed errors
+// of this package, which can be matched with errors.Is().
 func (c *Client) GetKey(ctx context.Context, block uint) (*DecryptionKeyResult, error) {
 	req := &grpc.GetDecryptionKeyRequest{
 		Block: uint64(block),
@@ -89,24 +92,34 @@ func (c *Client) GetKey(ctx context.Context, block uint) (*DecryptionKeyResult,
 	ok := c.waitState(ctx)
 	if !ok {
 		// ctx done, or rpc shutting down
-		// TODO: error message
-		return nil, errors.New("wait state failed")
+		return nil, errors.Wrap(ErrUnavailable, "connection not ready")
 	}
 
 	resp, err := c.client.GetDecryptionKey(ctx, req, opts...)
 	if err != nil {
-		return nil, err
+		return nil, errs.FromStatus(err)
 	}
 	decrKey := resp.GetDecryptionKey()
-	if resp == nil {
-		// TODO:
-		return nil, errors.New("no value returned")
+	if decrKey == nil {
+		return nil, errs.NewKeyError(grpc.ErrorCode_ERROR_CODE_INTERNAL, block, errors.New("no value returned"))
+	}
+
+	if decrKey.GetBlock() != uint64(block) {
+		return nil, errs.NewKeyError(grpc.ErrorCode_ERROR_CODE_INTERNAL, block,
+			errors.Errorf("response for wrong block %d", decrKey.GetBlock()))
 	}
 
 	k := &DecryptionKeyResult{
 		Block:  uint(decrKey.Block),
 		Active: decrKey.Active,
 	}
+	if !decrKey.Active {
+		// inactive responses don't carry a key
+		return k, nil
+	}
+	if decrKey.Key == nil {
+		return nil, errs.NewKeyError(grpc.ErrorCode_ERROR_CODE_INTERNAL, block, errors.New("active response without key"))
+	}
 
 	key := &shcrypto.EpochSecretKey{}
 	if err := key.Unmarshal(decrKey.Key); err != nil {
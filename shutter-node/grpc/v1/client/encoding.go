@@ -4,6 +4,9 @@ import (
 	"github.com/shutter-network/shutter/shlib/shcrypto"
 )
 
+// DecryptionKeyResult is the response of the shutter-node
+// for a block.
+// SecretKey is only set when shutter is active for the block.
 type DecryptionKeyResult struct {
 	Block     uint
 	Active    bool
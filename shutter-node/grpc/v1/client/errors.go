@@ -0,0 +1,23 @@
+package client
+
+import (
+	"github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/errs"
+)
+
+// Typed errors returned by Client.GetKey.
+var (
+	// ErrNotActive means that shutter is not active for the block.
+	ErrNotActive = errs.ErrNotActive
+	// ErrNoEon means that the shutter-node knows no eon for the block.
+	ErrNoEon = errs.ErrNoEon
+	// ErrTooOld means that the block is older than the state
+	// known to the shutter-node.
+	ErrTooOld = errs.ErrTooOld
+	// ErrNotYetAvailable means that shutter is active, but the
+	// decryption key was not received before the request's deadline.
+	ErrNotYetAvailable = errs.ErrNotYetAvailable
+	// ErrInternal means that the shutter-node failed to process the request.
+	ErrInternal = errs.ErrInternal
+	// ErrUnavailable means that the shutter-node could not be reached.
+	ErrUnavailable = errs.ErrUnavailable
+)
@@ -1,7 +1,22 @@
 package errs
 
+import (
+	grpc "github.com/ethereum-optimism/optimism/shutter-node/grpc/v1"
+)
+
 var (
-	Inactive         = Error(errorInactive)
 	ConnectionClosed = Error(errorConnectionClose)
 	Canceled         = Error(errorCanceled)
 )
+
+// Protocol-level errors.
+// Those are sent to the client as ErrorDetail in the gRPC
+// status details and can be matched with errors.Is()
+// on both ends of the connection.
+var (
+	ErrNotActive       = &KeyError{Code: grpc.ErrorCode_ERROR_CODE_NOT_ACTIVE}
+	ErrNoEon           = &KeyError{Code: grpc.ErrorCode_ERROR_CODE_NO_EON}
+	ErrTooOld          = &KeyError{Code: grpc.ErrorCode_ERROR_CODE_TOO_OLD}
+	ErrNotYetAvailable = &KeyError{Code: grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE}
+	ErrInternal        = &KeyError{Code: grpc.ErrorCode_ERROR_CODE_INTERNAL}
+)
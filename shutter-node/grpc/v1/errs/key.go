@@ -0,0 +1,133 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	grpc "github.com/ethereum-optimism/optimism/shutter-node/grpc/v1"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// ErrUnavailable is returned by the client when the
+// shutter-node could not be reached.
+var ErrUnavailable = errors.New("shutter-node unavailable")
+
+var codeMessages = map[grpc.ErrorCode]string{
+	grpc.ErrorCode_ERROR_CODE_UNSPECIFIED:       "unspecified error",
+	grpc.ErrorCode_ERROR_CODE_NOT_ACTIVE:        "shutter not active",
+	grpc.ErrorCode_ERROR_CODE_NO_EON:            "no eon known for block",
+	grpc.ErrorCode_ERROR_CODE_TOO_OLD:           "block too old",
+	grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE: "decryption key not yet available",
+	grpc.ErrorCode_ERROR_CODE_INTERNAL:          "internal error",
+}
+
+// KeyError is a protocol-level error of a decryption key request.
+type KeyError struct {
+	Code  grpc.ErrorCode
+	Block uint
+	msg   string
+}
+
+// NewKeyError creates a protocol-level error for the requested block.
+// The cause is only used for the error message,
+// it can't be unwrapped on the client side.
+func NewKeyError(code grpc.ErrorCode, block uint, cause error) *KeyError {
+	msg := codeMessages[code]
+	if cause != nil {
+		msg = fmt.Sprintf("%s: %s", msg, cause)
+	}
+	return &KeyError{
+		Code:  code,
+		Block: block,
+		msg:   msg,
+	}
+}
+
+func (e *KeyError) Error() string {
+	if e.msg == "" {
+		return codeMessages[e.Code]
+	}
+	return e.msg
+}
+
+// Is matches on the error code only,
+// so that errors.Is(err, ErrNotActive) is true
+// for every block.
+func (e *KeyError) Is(target error) bool {
+	t, ok := target.(*KeyError)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+func (e *KeyError) statusCode() codes.Code {
+	switch e.Code {
+	case grpc.ErrorCode_ERROR_CODE_NOT_ACTIVE,
+		grpc.ErrorCode_ERROR_CODE_NO_EON:
+		return codes.FailedPrecondition
+	case grpc.ErrorCode_ERROR_CODE_TOO_OLD:
+		return codes.OutOfRange
+	case grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE:
+		return codes.Unavailable
+	case grpc.ErrorCode_ERROR_CODE_INTERNAL:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+func (e *KeyError) GRPCStatus() *status.Status {
+	st := status.New(e.statusCode(), e.Error())
+	ds, err := st.WithDetails(
+		&grpc.ErrorDetail{
+			Code:  e.Code,
+			Block: uint64(e.Block),
+		},
+	)
+	if err != nil {
+		return st
+	}
+	return ds
+}
+
+// FromStatus converts an error returned by a gRPC call
+// back to a typed error.
+// If the status carries an ErrorDetail, a KeyError is returned.
+// A request that exceeded its deadline is converted to
+// ErrNotYetAvailable, a canceled request to context.Canceled.
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, d := range st.Details() {
+		if detail, ok := d.(*grpc.ErrorDetail); ok {
+			return &KeyError{
+				Code:  detail.GetCode(),
+				Block: uint(detail.GetBlock()),
+				msg:   st.Message(),
+			}
+		}
+	}
+	switch st.Code() {
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		// The server only blocks a request when shutter is
+		// active and the key was not received yet.
+		return &KeyError{
+			Code: grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE,
+			msg:  fmt.Sprintf("%s: %s", codeMessages[grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE], st.Message()),
+		}
+	case codes.Unavailable:
+		return fmt.Errorf("%w: %s", ErrUnavailable, st.Message())
+	default:
+		return err
+	}
+}
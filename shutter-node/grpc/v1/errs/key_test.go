@@ -0,0 +1,66 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	"gotest.tools/assert"
+
+	grpc "github.com/ethereum-optimism/optimism/shutter-node/grpc/v1"
+)
+
+func TestKeyErrorStatusRoundtrip(t *testing.T) {
+	testCases := []struct {
+		code       grpc.ErrorCode
+		sentinel   error
+		statusCode codes.Code
+	}{
+		{grpc.ErrorCode_ERROR_CODE_NOT_ACTIVE, ErrNotActive, codes.FailedPrecondition},
+		{grpc.ErrorCode_ERROR_CODE_NO_EON, ErrNoEon, codes.FailedPrecondition},
+		{grpc.ErrorCode_ERROR_CODE_TOO_OLD, ErrTooOld, codes.OutOfRange},
+		{grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE, ErrNotYetAvailable, codes.Unavailable},
+		{grpc.ErrorCode_ERROR_CODE_INTERNAL, ErrInternal, codes.Internal},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.code.String(), func(t *testing.T) {
+			sent := NewKeyError(tc.code, 42, errors.New("cause"))
+			assert.Assert(t, errors.Is(sent, tc.sentinel))
+
+			// this is what the gRPC server sends over the wire
+			st := sent.GRPCStatus()
+			assert.Equal(t, st.Code(), tc.statusCode)
+
+			received := FromStatus(st.Err())
+			assert.Assert(t, errors.Is(received, tc.sentinel))
+			var keyErr *KeyError
+			assert.Assert(t, errors.As(received, &keyErr))
+			assert.Equal(t, keyErr.Block, uint(42))
+			assert.Equal(t, keyErr.Error(), sent.Error())
+			for _, other := range testCases {
+				if other.code != tc.code {
+					assert.Assert(t, !errors.Is(received, other.sentinel))
+				}
+			}
+		})
+	}
+}
+
+func TestFromStatusWithoutDetail(t *testing.T) {
+	assert.NilError(t, FromStatus(nil))
+
+	err := FromStatus(status.Error(codes.DeadlineExceeded, "deadline"))
+	assert.Assert(t, errors.Is(err, ErrNotYetAvailable))
+
+	err = FromStatus(status.Error(codes.Canceled, "canceled"))
+	assert.Assert(t, errors.Is(err, context.Canceled))
+
+	err = FromStatus(ConnectionClosed.GRPCStatus().Err())
+	assert.Assert(t, errors.Is(err, ErrUnavailable))
+
+	err = FromStatus(status.Error(codes.Unknown, "unknown"))
+	assert.Assert(t, !errors.Is(err, ErrInternal))
+}
@@ -91,24 +91,54 @@ func (s *Server) getDecryptionKey(ctx context.Context, block uint) (
 	select {
 	case <-ctx.Done():
 		cancelRequest(ctx.Err())
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// the client gave up waiting for the key
+			return nil, errs.NewKeyError(grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE, block, ctx.Err())
+		}
 		return nil, errs.Canceled
 	case res := <-resPromise:
-		if res.Error != nil {
-			return nil, errs.Error(res.Error)
-		} else if errors.Is(res.Error, keys.ErrNotActive) {
+		if errors.Is(res.Error, keys.ErrNotActive) {
+			// This is not an error, inactive blocks
+			// are answered with a response without a key
 			return &grpc.DecryptionKey{
 				Active: false,
-				Block:  uint64(res.Block),
+				Block:  uint64(block),
 			}, nil
 		}
+		if res.Error != nil {
+			return nil, toKeyError(block, res.Error)
+		}
+		if res.SecretKey == nil {
+			return nil, errs.NewKeyError(grpc.ErrorCode_ERROR_CODE_INTERNAL, block, errors.New("no key in result"))
+		}
 		return &grpc.DecryptionKey{
 			Active: true,
 			Key:    res.SecretKey.Marshal(),
-			Block:  uint64(res.Block),
+			Block:  uint64(block),
 		}, nil
 	}
 }
 
+// toKeyError translates the errors of the key manager
+// to protocol-level errors.
+// keys.ErrNotActive is not an error on the protocol level
+// and has to be handled by the caller.
+func toKeyError(block uint, err error) *errs.KeyError {
+	var code grpc.ErrorCode
+	switch {
+	case errors.Is(err, keys.ErrNoEonForBlock):
+		code = grpc.ErrorCode_ERROR_CODE_NO_EON
+	case errors.Is(err, keys.ErrPastBlockNotKnown):
+		code = grpc.ErrorCode_ERROR_CODE_TOO_OLD
+	case errors.Is(err, keys.ErrNoBlock),
+		errors.Is(err, keys.ErrNoEpochForBlock):
+		code = grpc.ErrorCode_ERROR_CODE_NOT_YET_AVAILABLE
+	default:
+		code = grpc.ErrorCode_ERROR_CODE_INTERNAL
+	}
+	return errs.NewKeyError(code, block, err)
+}
+
 // Unary API
 func (s *Server) GetDecryptionKey(
 	ctx context.Context,
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/shutter-network/shutter/shlib/shcrypto"
+	googrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/assert"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/client"
+	"github.com/ethereum-optimism/optimism/shutter-node/keys"
+	"github.com/ethereum-optimism/optimism/shutter-node/keys/identity"
+)
+
+// fakeManager fills every request with the configured
+// outcome, or never fills it if there is none.
+type fakeManager struct {
+	secretKey *shcrypto.EpochSecretKey
+	err       error
+	pending   bool
+}
+
+func (m *fakeManager) RequestDecryptionKey(ctx context.Context, block uint) (<-chan *keys.KeyRequestResult, keys.CancelRequest) {
+	promise := make(chan *keys.KeyRequestResult, 1)
+	if !m.pending {
+		promise <- &keys.KeyRequestResult{
+			Block:     block,
+			SecretKey: m.secretKey,
+			Error:     m.err,
+		}
+	}
+	return promise, func(error) {}
+}
+
+func newTestClient(t *testing.T, m *fakeManager) *client.Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s, err := NewServer(m.RequestDecryptionKey)
+	assert.NilError(t, err)
+	go func() {
+		_ = s.serv.Serve(lis)
+	}()
+	t.Cleanup(s.serv.Stop)
+
+	c, err := client.NewClient(
+		client.WithServerAddress("bufnet"),
+		client.WithGRPCOption(googrpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			},
+		)),
+	)
+	assert.NilError(t, err)
+	assert.NilError(t, c.Init(context.Background()))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	return c
+}
+
+func testSecretKey(t *testing.T, block uint64) *shcrypto.EpochSecretKey {
+	t.Helper()
+	kg, err := shcrypto.NewTestKeyGen()
+	assert.NilError(t, err)
+	epochID := shcrypto.ComputeEpochID(identity.BlockNumberToPreimage(block))
+	key, err := kg.ComputeEpochSecretKey(epochID)
+	assert.NilError(t, err)
+	return key
+}
+
+func TestGetDecryptionKey(t *testing.T) {
+	const block = 42
+	secretKey := testSecretKey(t, block)
+
+	testCases := []struct {
+		name      string
+		manager   *fakeManager
+		expActive bool
+		expKey    *shcrypto.EpochSecretKey
+		expErr    error
+	}{
+		{
+			name:      "key available",
+			manager:   &fakeManager{secretKey: secretKey},
+			expActive: true,
+			expKey:    secretKey,
+		},
+		{
+			name:      "not active",
+			manager:   &fakeManager{err: keys.ErrNotActive},
+			expActive: false,
+		},
+		{
+			name:      "not active wrapped",
+			manager:   &fakeManager{err: pkgerrors.Wrap(keys.ErrNotActive, "query")},
+			expActive: false,
+		},
+		{
+			name:    "no eon",
+			manager: &fakeManager{err: keys.ErrNoEonForBlock},
+			expErr:  client.ErrNoEon,
+		},
+		{
+			name:    "past block not known",
+			manager: &fakeManager{err: keys.ErrPastBlockNotKnown},
+			expErr:  client.ErrTooOld,
+		},
+		{
+			name:    "no block state",
+			manager: &fakeManager{err: keys.ErrNoBlock},
+			expErr:  client.ErrNotYetAvailable,
+		},
+		{
+			name:    "no epoch",
+			manager: &fakeManager{err: keys.ErrNoEpochForBlock},
+			expErr:  client.ErrNotYetAvailable,
+		},
+		{
+			name:    "request aborted",
+			manager: &fakeManager{err: keys.ErrRequestAborted},
+			expErr:  client.ErrInternal,
+		},
+		{
+			name:    "database error",
+			manager: &fakeManager{err: errors.New("database is locked")},
+			expErr:  client.ErrInternal,
+		},
+		{
+			name:    "success without key",
+			manager: &fakeManager{},
+			expErr:  client.ErrInternal,
+		},
+		{
+			name:    "key not received in time",
+			manager: &fakeManager{pending: true},
+			expErr:  client.ErrNotYetAvailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, tc.manager)
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			res, err := c.GetKey(ctx, block)
+			if tc.expErr != nil {
+				assert.Assert(t, errors.Is(err, tc.expErr), "unexpected error: %v", err)
+				assert.Assert(t, res == nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, res.Block, uint(block))
+			assert.Equal(t, res.Active, tc.expActive)
+			if tc.expKey == nil {
+				assert.Assert(t, res.SecretKey == nil)
+			} else {
+				assert.Assert(t, tc.expKey.Equal(res.SecretKey))
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package inspect
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/database/query"
+)
+
+// KeyLatenessRecord is the arrival time of a decryption key
+// relative to the timestamp of the block it decrypts.
+// The arrival time is only known for keys received via gossip.
+type KeyLatenessRecord struct {
+	Block           uint       `json:"block"`
+	EonIndex        uint       `json:"eonIndex"`
+	ReceivedAt      *time.Time `json:"receivedAt"`
+	BlockTimestamp  *uint64    `json:"blockTimestamp"`
+	LatenessSeconds *float64   `json:"latenessSeconds"`
+}
+
+type KeyLatenesses []*KeyLatenessRecord
+
+func (KeyLatenesses) Header() []string {
+	return []string{"block", "eon", "received-at", "block-timestamp", "lateness-seconds"}
+}
+
+func (k KeyLatenesses) Rows() [][]string {
+	rows := make([][]string, 0, len(k))
+	for _, r := range k {
+		receivedAt := ""
+		if r.ReceivedAt != nil {
+			receivedAt = r.ReceivedAt.UTC().Format(time.RFC3339Nano)
+		}
+		rows = append(rows, []string{
+			formatUint(r.Block),
+			formatUint(r.EonIndex),
+			receivedAt,
+			formatOptUint(r.BlockTimestamp),
+			formatOptSeconds(r.LatenessSeconds),
+		})
+	}
+	return rows
+}
+
+// GetKeyLatenesses returns the lateness of the decryption keys
+// for the blocks in the inclusive range [from, to].
+func GetKeyLatenesses(db *gorm.DB, from, to uint) (KeyLatenesses, error) {
+	epochs, err := query.GetEpochs(db, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "query epochs")
+	}
+	records := make(KeyLatenesses, 0, len(epochs))
+	for _, e := range epochs {
+		r := &KeyLatenessRecord{
+			Block:          e.Block,
+			EonIndex:       e.EonIndex,
+			ReceivedAt:     e.ReceivedAt,
+			BlockTimestamp: e.BlockTimestamp,
+		}
+		if lateness, ok := e.Lateness(); ok {
+			s := lateness.Seconds()
+			r.LatenessSeconds = &s
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// KeySLORecord summarizes the key availability of one eon.
+// Keys is the number of keys with a known arrival time,
+// Late the number of those that arrived after the block's timestamp
+// and Missing the number of blocks in which shutter was active,
+// but no key was received at all.
+// The percentiles are the lateness in seconds and are nil
+// if there are no keys with a known arrival time.
+type KeySLORecord struct {
+	EonIndex uint     `json:"eonIndex"`
+	Keys     uint     `json:"keys"`
+	Late     uint     `json:"late"`
+	Missing  uint     `json:"missing"`
+	P50      *float64 `json:"p50Seconds"`
+	P90      *float64 `json:"p90Seconds"`
+	P99      *float64 `json:"p99Seconds"`
+	Max      *float64 `json:"maxSeconds"`
+}
+
+type KeySLOs []*KeySLORecord
+
+func (KeySLOs) Header() []string {
+	return []string{"eon", "keys", "late", "missing", "p50-seconds", "p90-seconds", "p99-seconds", "max-seconds"}
+}
+
+func (k KeySLOs) Rows() [][]string {
+	rows := make([][]string, 0, len(k))
+	for _, r := range k {
+		rows = append(rows, []string{
+			formatUint(r.EonIndex),
+			formatUint(r.Keys),
+			formatUint(r.Late),
+			formatUint(r.Missing),
+			formatOptSeconds(r.P50),
+			formatOptSeconds(r.P90),
+			formatOptSeconds(r.P99),
+			formatOptSeconds(r.Max),
+		})
+	}
+	return rows
+}
+
+// GetKeySLOs returns the per-eon key availability
+// for the blocks in the inclusive range [from, to],
+// ordered by eon index.
+func GetKeySLOs(db *gorm.DB, from, to uint) (KeySLOs, error) {
+	latenesses, err := GetKeyLatenesses(db, from, to)
+	if err != nil {
+		return nil, err
+	}
+	missing, err := GetMissingKeys(db, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[uint]*KeySLORecord{}
+	samples := map[uint][]float64{}
+	get := func(eon uint) *KeySLORecord {
+		r, ok := records[eon]
+		if !ok {
+			r = &KeySLORecord{EonIndex: eon}
+			records[eon] = r
+		}
+		return r
+	}
+	for _, l := range latenesses {
+		if l.LatenessSeconds == nil {
+			continue
+		}
+		r := get(l.EonIndex)
+		r.Keys++
+		if *l.LatenessSeconds > 0 {
+			r.Late++
+		}
+		samples[l.EonIndex] = append(samples[l.EonIndex], *l.LatenessSeconds)
+	}
+	for _, m := range missing {
+		get(m.EonIndex).Missing++
+	}
+
+	slos := make(KeySLOs, 0, len(records))
+	for eon, r := range records {
+		if s := samples[eon]; len(s) > 0 {
+			sort.Float64s(s)
+			r.P50 = percentile(s, 50)
+			r.P90 = percentile(s, 90)
+			r.P99 = percentile(s, 99)
+			r.Max = &s[len(s)-1]
+		}
+		slos = append(slos, r)
+	}
+	sort.Slice(slos, func(i, j int) bool {
+		return slos[i].EonIndex < slos[j].EonIndex
+	})
+	return slos, nil
+}
+
+// percentile returns the nearest-rank percentile
+// of the sorted, non-empty samples.
+func percentile(sorted []float64, p float64) *float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	v := sorted[rank-1]
+	return &v
+}
+
+// formatOptSeconds formats nil values as an empty string
+func formatOptSeconds(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 3, 64)
+}
@@ -0,0 +1,87 @@
+package inspect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shutter-network/shutter/shlib/shcrypto"
+	"gotest.tools/assert"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/database/models"
+)
+
+func TestKeySLOs(t *testing.T) {
+	db := setupDB(t)
+	kg, err := shcrypto.NewTestKeyGen()
+	assert.NilError(t, err)
+
+	eon := &models.Eon{
+		Metadata:        models.Metadata{InsertBlock: 1},
+		EonIndex:        1,
+		ActivationBlock: 1,
+		Threshold:       1,
+	}
+	create(t, db, eon)
+	create(t, db, &models.PublicKey{
+		Metadata: models.Metadata{InsertBlock: 1},
+		EonIndex: 1,
+		Key:      kg.EonPublicKey,
+	})
+
+	genesis := time.Unix(1_700_000_000, 0)
+	// block -> lateness, nil for a missing key
+	latenesses := map[uint]*time.Duration{}
+	for block, lateness := range []time.Duration{-2 * time.Second, -time.Second, 500 * time.Millisecond, 0, 3 * time.Second} {
+		lateness := lateness
+		latenesses[uint(block+2)] = &lateness
+	}
+	latenesses[7] = nil
+
+	for block := uint(2); block <= 7; block++ {
+		create(t, db, &models.State{
+			Metadata: models.Metadata{InsertBlock: block},
+			Block:    block,
+			Active:   true,
+			EonID:    &eon.ID,
+		})
+		lateness := latenesses[block]
+		if lateness == nil {
+			continue
+		}
+		ts := uint64(genesis.Unix()) + uint64(block)*2
+		receivedAt := time.Unix(int64(ts), 0).Add(*lateness)
+		create(t, db, &models.Epoch{
+			Metadata:       models.Metadata{InsertBlock: block},
+			EonIndex:       1,
+			Block:          block,
+			ReceivedAt:     &receivedAt,
+			BlockTimestamp: &ts,
+		})
+	}
+
+	records, err := GetKeyLatenesses(db, 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(records), 5)
+	assert.Equal(t, records[0].Block, uint(2))
+	assert.Equal(t, *records[0].LatenessSeconds, -2.0)
+	assert.Equal(t, *records[2].LatenessSeconds, 0.5)
+
+	slos, err := GetKeySLOs(db, 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(slos), 1)
+	slo := slos[0]
+	assert.Equal(t, slo.EonIndex, uint(1))
+	assert.Equal(t, slo.Keys, uint(5))
+	assert.Equal(t, slo.Late, uint(2))
+	assert.Equal(t, slo.Missing, uint(1))
+	assert.Equal(t, *slo.P50, 0.0)
+	assert.Equal(t, *slo.P90, 3.0)
+	assert.Equal(t, *slo.Max, 3.0)
+
+	slos, err = GetKeySLOs(db, 0, 3)
+	assert.NilError(t, err)
+	assert.Equal(t, len(slos), 1)
+	assert.Equal(t, slos[0].Late, uint(0))
+	assert.Equal(t, slos[0].Missing, uint(0))
+	assert.Equal(t, *slos[0].Max, -1.0)
+}
@@ -0,0 +1,77 @@
+package inspect
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+)
+
+var Formats = []Format{FormatText, FormatJSON, FormatCSV}
+
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q", s)
+}
+
+// Table is a list of records that can be written
+// in all supported formats.
+// JSON output is the JSON encoding of the table itself.
+type Table interface {
+	Header() []string
+	Rows() [][]string
+}
+
+func Write(w io.Writer, format Format, t Table) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(t)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.Header()); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(t.Rows()); err != nil {
+			return err
+		}
+		return cw.Error()
+	case FormatText:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.Header(), "\t"))
+		for _, row := range t.Rows() {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func formatUint[T ~uint | ~uint64](v T) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// formatOptUint formats nil values as an empty string
+func formatOptUint[T ~uint | ~uint64](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return formatUint(*v)
+}
@@ -0,0 +1,283 @@
+package inspect
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/database/models"
+	"github.com/ethereum-optimism/optimism/shutter-node/database/query"
+)
+
+// EonRecord is one eon of the eon history.
+// An eon is superseded by the next eon, so it is
+// in effect until the block before the next eon's activation.
+type EonRecord struct {
+	EonIndex        uint             `json:"eonIndex"`
+	ActivationBlock uint64           `json:"activationBlock"`
+	UntilBlock      *uint64          `json:"untilBlock"`
+	Threshold       uint64           `json:"threshold"`
+	Keypers         []common.Address `json:"keypers"`
+	InsertBlock     uint             `json:"insertBlock"`
+	PublicKeyBlock  *uint            `json:"publicKeyBlock"`
+}
+
+type Eons []*EonRecord
+
+func (Eons) Header() []string {
+	return []string{"eon", "activation-block", "until-block", "threshold", "num-keypers", "insert-block", "public-key-block", "keypers"}
+}
+
+func (e Eons) Rows() [][]string {
+	rows := make([][]string, 0, len(e))
+	for _, r := range e {
+		keypers := make([]string, 0, len(r.Keypers))
+		for _, k := range r.Keypers {
+			keypers = append(keypers, k.Hex())
+		}
+		rows = append(rows, []string{
+			formatUint(r.EonIndex),
+			formatUint(r.ActivationBlock),
+			formatOptUint(r.UntilBlock),
+			formatUint(r.Threshold),
+			strconv.Itoa(len(r.Keypers)),
+			formatUint(r.InsertBlock),
+			formatOptUint(r.PublicKeyBlock),
+			strings.Join(keypers, " "),
+		})
+	}
+	return rows
+}
+
+// GetEonHistory returns all known eons ordered by activation.
+func GetEonHistory(db *gorm.DB) (Eons, error) {
+	eons, err := query.GetEons(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "query eons")
+	}
+	pks, err := query.GetPubKeys(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "query public keys")
+	}
+	pkBlocks := map[uint]uint{}
+	for _, pk := range pks {
+		pkBlocks[pk.EonIndex] = pk.InsertBlock
+	}
+
+	history := make(Eons, 0, len(eons))
+	for i, eon := range eons {
+		r := &EonRecord{
+			EonIndex:        eon.EonIndex,
+			ActivationBlock: eon.ActivationBlock,
+			Threshold:       eon.Threshold,
+			Keypers:         make([]common.Address, 0, len(eon.Keypers)),
+			InsertBlock:     eon.InsertBlock,
+		}
+		if i+1 < len(eons) && eons[i+1].ActivationBlock > 0 {
+			until := eons[i+1].ActivationBlock - 1
+			r.UntilBlock = &until
+		}
+		for _, k := range eon.Keypers {
+			r.Keypers = append(r.Keypers, k.Address)
+		}
+		if b, ok := pkBlocks[eon.EonIndex]; ok {
+			r.PublicKeyBlock = &b
+		}
+		history = append(history, r)
+	}
+	return history, nil
+}
+
+// MembershipRecord is the membership of a keyper
+// in the keyper set of one eon.
+type MembershipRecord struct {
+	Keyper     common.Address `json:"keyper"`
+	EonIndex   uint           `json:"eonIndex"`
+	FromBlock  uint64         `json:"fromBlock"`
+	UntilBlock *uint64        `json:"untilBlock"`
+}
+
+type Memberships []*MembershipRecord
+
+func (Memberships) Header() []string {
+	return []string{"keyper", "eon", "from-block", "until-block"}
+}
+
+func (m Memberships) Rows() [][]string {
+	rows := make([][]string, 0, len(m))
+	for _, r := range m {
+		rows = append(rows, []string{
+			r.Keyper.Hex(),
+			formatUint(r.EonIndex),
+			formatUint(r.FromBlock),
+			formatOptUint(r.UntilBlock),
+		})
+	}
+	return rows
+}
+
+// GetKeyperMemberships returns the keyper set membership
+// over time, ordered by eon activation.
+func GetKeyperMemberships(db *gorm.DB) (Memberships, error) {
+	eons, err := GetEonHistory(db)
+	if err != nil {
+		return nil, err
+	}
+	memberships := Memberships{}
+	for _, eon := range eons {
+		for _, k := range eon.Keypers {
+			memberships = append(memberships, &MembershipRecord{
+				Keyper:     k,
+				EonIndex:   eon.EonIndex,
+				FromBlock:  eon.ActivationBlock,
+				UntilBlock: eon.UntilBlock,
+			})
+		}
+	}
+	return memberships, nil
+}
+
+// ActiveRangeRecord is a range of blocks in which shutter
+// was either paused or unpaused.
+// UpdateBlock is the block the paused / unpaused event
+// was emitted in. It is nil for the initial range,
+// in which shutter is considered unpaused.
+type ActiveRangeRecord struct {
+	Active      bool  `json:"active"`
+	FromBlock   uint  `json:"fromBlock"`
+	UntilBlock  *uint `json:"untilBlock"`
+	UpdateBlock *uint `json:"updateBlock"`
+}
+
+type ActiveRanges []*ActiveRangeRecord
+
+func (ActiveRanges) Header() []string {
+	return []string{"active", "from-block", "until-block", "update-block"}
+}
+
+func (a ActiveRanges) Rows() [][]string {
+	rows := make([][]string, 0, len(a))
+	for _, r := range a {
+		rows = append(rows, []string{
+			strconv.FormatBool(r.Active),
+			formatUint(r.FromBlock),
+			formatOptUint(r.UntilBlock),
+			formatOptUint(r.UpdateBlock),
+		})
+	}
+	return rows
+}
+
+// GetActiveRanges returns the paused / unpaused block ranges.
+func GetActiveRanges(db *gorm.DB) (ActiveRanges, error) {
+	updates, err := query.GetActiveUpdates(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "query active updates")
+	}
+	return activeRanges(updates), nil
+}
+
+// activeRanges returns the block ranges between the given updates,
+// which are ordered by block. Of several updates for the same block,
+// only the last one takes effect.
+func activeRanges(updates []*models.ActiveUpdate) ActiveRanges {
+	ranges := ActiveRanges{}
+	if len(updates) == 0 || updates[0].Block > 0 {
+		// the DB-writer considers shutter unpaused
+		// until the first update
+		ranges = append(ranges, &ActiveRangeRecord{
+			Active:    true,
+			FromBlock: 0,
+		})
+	}
+	for i, u := range updates {
+		if i+1 < len(updates) && updates[i+1].Block == u.Block {
+			continue
+		}
+		if len(ranges) > 0 {
+			until := u.Block - 1
+			ranges[len(ranges)-1].UntilBlock = &until
+		}
+		insertBlock := u.InsertBlock
+		ranges = append(ranges, &ActiveRangeRecord{
+			Active:      u.Active,
+			FromBlock:   u.Block,
+			UpdateBlock: &insertBlock,
+		})
+	}
+	return ranges
+}
+
+// MissingKeyRecord is a block in which shutter was active,
+// but no decryption key was received.
+type MissingKeyRecord struct {
+	Block    uint `json:"block"`
+	EonIndex uint `json:"eonIndex"`
+}
+
+type MissingKeys []*MissingKeyRecord
+
+func (MissingKeys) Header() []string {
+	return []string{"block", "eon"}
+}
+
+func (m MissingKeys) Rows() [][]string {
+	rows := make([][]string, 0, len(m))
+	for _, r := range m {
+		rows = append(rows, []string{
+			formatUint(r.Block),
+			formatUint(r.EonIndex),
+		})
+	}
+	return rows
+}
+
+// GetMissingKeys returns the blocks in the inclusive range [from, to]
+// for which shutter was active, but the database contains no
+// decryption key.
+// Blocks of eons without a public key are not reported, since
+// shutter is considered inactive without an eon public key.
+func GetMissingKeys(db *gorm.DB, from, to uint) (MissingKeys, error) {
+	states, err := query.GetStates(db, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "query states")
+	}
+	epochs, err := query.GetEpochs(db, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "query epochs")
+	}
+	pks, err := query.GetPubKeys(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "query public keys")
+	}
+	type eonBlock struct {
+		eon   uint
+		block uint
+	}
+	hasKey := map[eonBlock]bool{}
+	for _, e := range epochs {
+		hasKey[eonBlock{e.EonIndex, e.Block}] = true
+	}
+	hasPubKey := map[uint]bool{}
+	for _, pk := range pks {
+		hasPubKey[pk.EonIndex] = pk.Key != nil
+	}
+
+	missing := MissingKeys{}
+	for _, s := range states {
+		if !s.Active || s.Eon == nil || !hasPubKey[s.Eon.EonIndex] {
+			continue
+		}
+		if hasKey[eonBlock{s.Eon.EonIndex, s.Block}] {
+			continue
+		}
+		missing = append(missing, &MissingKeyRecord{
+			Block:    s.Block,
+			EonIndex: s.Eon.EonIndex,
+		})
+	}
+	return missing, nil
+}
@@ -0,0 +1,184 @@
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/shutter-network/shutter/shlib/shcrypto"
+	"gorm.io/gorm"
+	"gotest.tools/assert"
+
+	"github.com/ethereum-optimism/optimism/shutter-node/database"
+	"github.com/ethereum-optimism/optimism/shutter-node/database/models"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	path, err := os.MkdirTemp("", "test-shutter-node-inspect-*")
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+	db := &database.Database{}
+	assert.NilError(t, db.Connect(path+"/db"))
+	t.Cleanup(func() {
+		assert.NilError(t, db.Close())
+	})
+	return db.Session(context.Background(), log.New())
+}
+
+func create(t *testing.T, db *gorm.DB, values ...any) {
+	t.Helper()
+	for _, v := range values {
+		assert.NilError(t, db.Create(v).Error)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	db := setupDB(t)
+	kg, err := shcrypto.NewTestKeyGen()
+	assert.NilError(t, err)
+
+	k1 := &models.Keyper{Address: common.HexToAddress("0x01")}
+	k2 := &models.Keyper{Address: common.HexToAddress("0x02")}
+	k3 := &models.Keyper{Address: common.HexToAddress("0x03")}
+	eon1 := &models.Eon{
+		Metadata:        models.Metadata{InsertBlock: 1},
+		EonIndex:        1,
+		ActivationBlock: 3,
+		Threshold:       2,
+		Keypers:         []*models.Keyper{k1, k2},
+	}
+	eon2 := &models.Eon{
+		Metadata:        models.Metadata{InsertBlock: 5},
+		EonIndex:        2,
+		ActivationBlock: 10,
+		Threshold:       2,
+		Keypers:         []*models.Keyper{k2, k3},
+	}
+	create(t, db, eon1, eon2)
+	create(t, db, &models.PublicKey{
+		Metadata: models.Metadata{InsertBlock: 2},
+		EonIndex: 1,
+		Key:      kg.EonPublicKey,
+	})
+	create(t, db,
+		&models.ActiveUpdate{Metadata: models.Metadata{InsertBlock: 3}, Block: 4, Active: true},
+		&models.ActiveUpdate{Metadata: models.Metadata{InsertBlock: 6}, Block: 7, Active: false},
+	)
+	for block := uint(3); block <= 11; block++ {
+		eon := eon1
+		if block >= 10 {
+			eon = eon2
+		}
+		create(t, db, &models.State{
+			Metadata: models.Metadata{InsertBlock: block},
+			Block:    block,
+			Active:   block >= 4 && block < 7,
+			EonID:    &eon.ID,
+		})
+	}
+	// block 5 is missing a key
+	for _, block := range []uint{4, 6} {
+		create(t, db, &models.Epoch{
+			Metadata: models.Metadata{InsertBlock: block},
+			EonIndex: 1,
+			Block:    block,
+		})
+	}
+
+	t.Run("eons", func(t *testing.T) {
+		eons, err := GetEonHistory(db)
+		assert.NilError(t, err)
+		assert.Equal(t, len(eons), 2)
+		assert.Equal(t, eons[0].EonIndex, uint(1))
+		assert.Equal(t, *eons[0].UntilBlock, uint64(9))
+		assert.Equal(t, *eons[0].PublicKeyBlock, uint(2))
+		assert.DeepEqual(t, eons[0].Keypers, []common.Address{k1.Address, k2.Address})
+		assert.Equal(t, eons[1].EonIndex, uint(2))
+		assert.Assert(t, eons[1].UntilBlock == nil)
+		assert.Assert(t, eons[1].PublicKeyBlock == nil)
+	})
+
+	t.Run("keypers", func(t *testing.T) {
+		memberships, err := GetKeyperMemberships(db)
+		assert.NilError(t, err)
+		assert.Equal(t, len(memberships), 4)
+		assert.Equal(t, memberships[1].Keyper, k2.Address)
+		assert.Equal(t, memberships[1].FromBlock, uint64(3))
+		assert.Equal(t, *memberships[1].UntilBlock, uint64(9))
+		assert.Equal(t, memberships[2].Keyper, k2.Address)
+		assert.Equal(t, memberships[2].FromBlock, uint64(10))
+		assert.Assert(t, memberships[2].UntilBlock == nil)
+	})
+
+	t.Run("active", func(t *testing.T) {
+		ranges, err := GetActiveRanges(db)
+		assert.NilError(t, err)
+		assert.Equal(t, len(ranges), 3)
+		assert.Equal(t, ranges[0].Active, true)
+		assert.Assert(t, ranges[0].UpdateBlock == nil)
+		assert.Equal(t, *ranges[0].UntilBlock, uint(3))
+		assert.Equal(t, ranges[1].Active, true)
+		assert.Equal(t, ranges[1].FromBlock, uint(4))
+		assert.Equal(t, *ranges[1].UntilBlock, uint(6))
+		assert.Equal(t, *ranges[1].UpdateBlock, uint(3))
+		assert.Equal(t, ranges[2].Active, false)
+		assert.Equal(t, ranges[2].FromBlock, uint(7))
+		assert.Assert(t, ranges[2].UntilBlock == nil)
+	})
+
+	t.Run("active-same-block", func(t *testing.T) {
+		ranges := activeRanges([]*models.ActiveUpdate{
+			{Metadata: models.Metadata{InsertBlock: 0}, Block: 0, Active: false},
+			{Metadata: models.Metadata{InsertBlock: 0}, Block: 0, Active: true},
+			{Metadata: models.Metadata{InsertBlock: 3}, Block: 4, Active: false},
+			{Metadata: models.Metadata{InsertBlock: 3}, Block: 4, Active: true},
+			{Metadata: models.Metadata{InsertBlock: 4}, Block: 4, Active: false},
+		})
+		assert.Equal(t, len(ranges), 2)
+		assert.Equal(t, ranges[0].Active, true)
+		assert.Equal(t, ranges[0].FromBlock, uint(0))
+		assert.Equal(t, *ranges[0].UntilBlock, uint(3))
+		assert.Equal(t, ranges[1].Active, false)
+		assert.Equal(t, ranges[1].FromBlock, uint(4))
+		assert.Equal(t, *ranges[1].UpdateBlock, uint(4))
+		assert.Assert(t, ranges[1].UntilBlock == nil)
+	})
+
+	t.Run("missing-keys", func(t *testing.T) {
+		missing, err := GetMissingKeys(db, 0, 100)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, missing, MissingKeys{{Block: 5, EonIndex: 1}})
+
+		missing, err = GetMissingKeys(db, 6, 100)
+		assert.NilError(t, err)
+		assert.Equal(t, len(missing), 0)
+	})
+
+	t.Run("output", func(t *testing.T) {
+		eons, err := GetEonHistory(db)
+		assert.NilError(t, err)
+
+		var buf bytes.Buffer
+		assert.NilError(t, Write(&buf, FormatCSV, eons))
+		records, err := csv.NewReader(&buf).ReadAll()
+		assert.NilError(t, err)
+		assert.Equal(t, len(records), 3)
+		assert.DeepEqual(t, records[0], eons.Header())
+		assert.Equal(t, records[1][2], "9")
+		assert.Equal(t, records[2][2], "")
+
+		buf.Reset()
+		assert.NilError(t, Write(&buf, FormatJSON, eons))
+		var decoded Eons
+		assert.NilError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.DeepEqual(t, decoded, eons)
+	})
+}
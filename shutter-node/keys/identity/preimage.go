@@ -48,6 +48,12 @@ func (eid Preimage) Uint64() uint64 {
 	return identitypreimage.IdentityPreimage([]byte(eid)).Uint64()
 }
 
+// BlockNumberToPreimage returns the identity preimage
+// the keypers use for the epoch that is revealed in block b.
+func BlockNumberToPreimage(b uint64) Preimage {
+	return Preimage(identitypreimage.Uint64ToIdentityPreimage(b).Bytes())
+}
+
 // TODO: use LRU cache
 func BlockNumberToEpochID(b uint64) (Preimage, error) {
 	preim := identitypreimage.Uint64ToIdentityPreimage(b)
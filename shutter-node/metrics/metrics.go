@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "shutter_node"
+
+// KeyLatenessBuckets are the buckets of the key lateness histogram in seconds.
+// Keys arriving before the timestamp of the block they decrypt
+// have a negative lateness.
+var KeyLatenessBuckets = []float64{-12, -8, -4, -2, -1, -0.5, -0.25, 0, 0.25, 0.5, 1, 2, 4, 8, 12, 30, 60}
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	// RecordKeyLateness records the time the decryption key for a block
+	// arrived, relative to the timestamp of that block.
+	RecordKeyLateness(eon uint, lateness time.Duration)
+	// RecordMissedKey records a block in which shutter was active,
+	// but the decryption key did not arrive before the block's deadline.
+	RecordMissedKey(eon uint)
+}
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	keyLateness *prometheus.HistogramVec
+	missedKeys  *prometheus.CounterVec
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics(procName string) *Metrics {
+	if procName == "" {
+		procName = "default"
+	}
+	ns := Namespace + "_" + procName
+
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       ns,
+		registry: registry,
+		factory:  factory,
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "up",
+			Help:      "1 if the shutter-node has finished starting up",
+		}),
+		keyLateness: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "keys",
+			Name:      "lateness_seconds",
+			Help:      "Arrival time of the decryption keys relative to the timestamp of the L2 block they decrypt",
+			Buckets:   KeyLatenessBuckets,
+		}, []string{
+			"eon",
+		}),
+		missedKeys: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "keys",
+			Name:      "missed_total",
+			Help:      "Count of L2 blocks with active shutter, for which the decryption key did not arrive before the block's deadline",
+		}, []string{
+			"eon",
+		}),
+	}
+}
+
+func (m *Metrics) StartServer(host string, port int) (*httputil.HTTPServer, error) {
+	return opmetrics.StartServer(m.registry, host, port)
+}
+
+// RecordInfo sets a pseudo-metric that contains versioning and
+// config info for the shutter-node.
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	m.up.Set(1)
+}
+
+func (m *Metrics) RecordKeyLateness(eon uint, lateness time.Duration) {
+	m.keyLateness.WithLabelValues(eonLabel(eon)).Observe(lateness.Seconds())
+}
+
+func (m *Metrics) RecordMissedKey(eon uint) {
+	m.missedKeys.WithLabelValues(eonLabel(eon)).Inc()
+}
+
+func (m *Metrics) Document() []opmetrics.DocumentedMetric {
+	return m.factory.Document()
+}
+
+func eonLabel(eon uint) string {
+	return strconv.FormatUint(uint64(eon), 10)
+}
@@ -0,0 +1,13 @@
+package metrics
+
+import "time"
+
+type noopMetrics struct{}
+
+var NoopMetrics Metricer = new(noopMetrics)
+
+func (*noopMetrics) RecordInfo(version string) {}
+func (*noopMetrics) RecordUp()                 {}
+
+func (*noopMetrics) RecordKeyLateness(eon uint, lateness time.Duration) {}
+func (*noopMetrics) RecordMissedKey(eon uint)                           {}
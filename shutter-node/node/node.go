@@ -12,11 +12,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/shutter-node/config"
 	"github.com/ethereum-optimism/optimism/shutter-node/database"
 	"github.com/ethereum-optimism/optimism/shutter-node/database/writer"
 	"github.com/ethereum-optimism/optimism/shutter-node/grpc/v1/server"
 	"github.com/ethereum-optimism/optimism/shutter-node/keys"
+	"github.com/ethereum-optimism/optimism/shutter-node/metrics"
 	"github.com/ethereum-optimism/optimism/shutter-node/p2p"
 	service "github.com/shutter-network/rolling-shutter/rolling-shutter/medley/service"
 	shp2p "github.com/shutter-network/rolling-shutter/rolling-shutter/p2p"
@@ -31,6 +33,8 @@ type ShutterNode struct {
 	writer     *writer.DBWriter
 	db         *database.Database
 	grpc       *server.Server
+	metrics    *metrics.Metrics
+	metricsSrv *httputil.HTTPServer
 
 	p2p    shp2p.Messaging
 	errgrp *errgroup.Group
@@ -81,6 +85,7 @@ func New(ctx context.Context, cfg *config.Config, log log.Logger, appVersion str
 func (n *ShutterNode) init(ctx context.Context, cfg *config.Config) error {
 	var err error
 	n.log.Info("Initializing shutter node", "version", n.appVersion)
+	n.metrics = metrics.NewMetrics("default")
 	if err := n.initDatabase(cfg); err != nil {
 		return fmt.Errorf("failed to init the database: %w", err)
 	}
@@ -89,13 +94,37 @@ func (n *ShutterNode) init(ctx context.Context, cfg *config.Config) error {
 	if err != nil {
 		return err
 	}
-	n.writer = writer.NewDBWriter(cfg.L2Sync.L2NodeAddr, n.log, n.db)
+	n.writer = writer.NewDBWriter(
+		cfg.L2Sync.L2NodeAddr, n.log, n.db,
+		writer.WithMetrics(n.metrics),
+		writer.WithBlockTimestamps(cfg.Rollup.TimestampForBlock),
+	)
 	if err := n.initP2P(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P stack: %w", err)
 	}
 	if err := n.initGRPCServer(cfg, n.log, n.keyManager.RequestDecryptionKey); err != nil {
 		return fmt.Errorf("failed to open grpc server: %w", err)
 	}
+	if err := n.initMetricsServer(cfg); err != nil {
+		return fmt.Errorf("failed to init the metrics server: %w", err)
+	}
+	n.metrics.RecordInfo(n.appVersion)
+	n.metrics.RecordUp()
+	return nil
+}
+
+func (n *ShutterNode) initMetricsServer(cfg *config.Config) error {
+	if !cfg.Metrics.Enabled {
+		n.log.Info("metrics disabled")
+		return nil
+	}
+	n.log.Debug("starting metrics server", "addr", cfg.Metrics.ListenAddr, "port", cfg.Metrics.ListenPort)
+	metricsSrv, err := n.metrics.StartServer(cfg.Metrics.ListenAddr, cfg.Metrics.ListenPort)
+	if err != nil {
+		return fmt.Errorf("failed to start metrics server: %w", err)
+	}
+	n.log.Info("started metrics server", "addr", metricsSrv.Addr())
+	n.metricsSrv = metricsSrv
 	return nil
 }
 
@@ -187,6 +216,12 @@ func (n *ShutterNode) Stop(ctx context.Context) error {
 		n.resourcesClose()
 	}
 
+	if n.metricsSrv != nil {
+		if err := n.metricsSrv.Stop(ctx); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close metrics server: %w", err))
+		}
+	}
+
 	if result == nil { // mark as closed if we successfully fully closed
 		n.closed.Store(true)
 	}
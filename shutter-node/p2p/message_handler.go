@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"math"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -148,11 +149,13 @@ func (h *DecryptionKeyHandler) HandleMessage(
 	ctx context.Context,
 	msg p2pmsg.Message,
 ) ([]p2pmsg.Message, error) {
+	receivedAt := time.Now()
 	decrKeys := msg.(*p2pmsg.DecryptionKeys)
 	epoch, err := DecryptionKeysEventToModel(decrKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "decode message to model")
 	}
+	epoch.ReceivedAt = &receivedAt
 	h.log.Info("received decryption-key message",
 		"reveal-block", epoch,
 		"message", decrKeys.LogInfo(),
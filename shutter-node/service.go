@@ -112,6 +112,26 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*config.Config, error) {
 	return cfg, nil
 }
 
+// NewDevKeyperConfig creates a DevKeyperConfig from the provided flags or environment variables.
+func NewDevKeyperConfig(ctx *cli.Context) (*config.DevKeyperConfig, error) {
+	cfg := &config.DevKeyperConfig{
+		Seed:            ctx.Int64(flags.DevKeyperSeedFlag.Name),
+		Eon:             ctx.Uint(flags.DevKeyperEonFlag.Name),
+		ActivationBlock: ctx.Uint(flags.DevKeyperActivationBlockFlag.Name),
+		GRPC: config.GRPCConfig{
+			ListenAddress: ctx.String(flags.GRPCListenAddressFlag.Name),
+			ListenNetwork: ctx.String(flags.GRPCListenNetworkFlag.Name),
+		},
+		Database: config.DatabaseConfig{
+			FilePath: ctx.String(flags.DatabasePathFlag.Name),
+		},
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func NewRollupConfig(log log.Logger, ctx *cli.Context) (*rollup.Config, error) {
 	network := ctx.String(flags.Network.Name)
 	rollupConfigPath := ctx.String(flags.RollupConfig.Name)
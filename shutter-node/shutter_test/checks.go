@@ -98,6 +98,7 @@ func DefaultCmpOpts() []cmp.Option {
 		cmpopts.IgnoreFields(models.State{}, "EonID", "ActiveUpdateID"),
 		cmpopts.IgnoreFields(models.Metadata{}, "ID", "CreatedAt", "UpdatedAt", "DeletedAt"),
 		cmpopts.IgnoreFields(models.Keyper{}, "Eons"),
+		cmpopts.IgnoreFields(models.Epoch{}, "ReceivedAt", "BlockTimestamp"),
 		CompareAddress(),
 	}
 }